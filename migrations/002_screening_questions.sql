@@ -0,0 +1,34 @@
+-- Screening questions attached to jobs and candidate answers
+
+CREATE TABLE IF NOT EXISTS job_screening_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    question_type VARCHAR(50) NOT NULL CHECK (question_type IN ('text', 'yes_no', 'single_choice', 'multi_choice', 'numeric')),
+    options JSONB,
+    is_required BOOLEAN DEFAULT TRUE,
+    knockout_answers JSONB,
+    min_value DECIMAL(12,2),
+    max_value DECIMAL(12,2),
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Answers keep a copy of the question so they survive question edits
+CREATE TABLE IF NOT EXISTS application_screening_answers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL,
+    question TEXT NOT NULL,
+    question_type VARCHAR(50) NOT NULL,
+    answer TEXT,
+    choices JSONB,
+    is_knockout BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(application_id, question_id)
+);
+
+ALTER TABLE job_applications ADD COLUMN IF NOT EXISTS knocked_out BOOLEAN DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_job_screening_questions_job_id ON job_screening_questions(job_id);
+CREATE INDEX IF NOT EXISTS idx_application_screening_answers_application_id ON application_screening_answers(application_id);
@@ -0,0 +1,12 @@
+-- Job posting lifecycle: drafts, scheduled publishing and auto-close
+
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('draft', 'scheduled', 'open', 'paused', 'closed', 'archived'));
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS close_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS max_applications INTEGER CHECK (max_applications > 0);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_publish_at ON jobs(publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_jobs_close_at ON jobs(close_at) WHERE close_at IS NOT NULL;
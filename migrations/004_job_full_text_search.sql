@@ -0,0 +1,18 @@
+-- Full-text search over jobs, weighted title > requirements > description
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector_pt tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('portuguese', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('portuguese', COALESCE(requirements, '')), 'B') ||
+        setweight(to_tsvector('portuguese', COALESCE(description, '')), 'C')
+    ) STORED;
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector_en tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(requirements, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_search_vector_pt ON jobs USING GIN (search_vector_pt);
+CREATE INDEX IF NOT EXISTS idx_jobs_search_vector_en ON jobs USING GIN (search_vector_en);
@@ -0,0 +1,15 @@
+-- Faceted job search: category, work mode, employment type and seniority
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS category VARCHAR(100);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS work_mode VARCHAR(20)
+    CHECK (work_mode IN ('', 'remote', 'hybrid', 'onsite'));
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS employment_type VARCHAR(20)
+    CHECK (employment_type IN ('', 'full_time', 'part_time', 'contract', 'internship', 'temporary', 'freelance'));
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS seniority VARCHAR(20)
+    CHECK (seniority IN ('', 'intern', 'junior', 'mid', 'senior', 'lead', 'principal'));
+
+CREATE INDEX IF NOT EXISTS idx_jobs_category ON jobs(LOWER(category));
+CREATE INDEX IF NOT EXISTS idx_jobs_work_mode ON jobs(work_mode);
+CREATE INDEX IF NOT EXISTS idx_jobs_employment_type ON jobs(employment_type);
+CREATE INDEX IF NOT EXISTS idx_jobs_seniority ON jobs(seniority);
+CREATE INDEX IF NOT EXISTS idx_job_skills_skill_id_job_id ON job_skills(skill_id, job_id);
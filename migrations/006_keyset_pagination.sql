@@ -0,0 +1,7 @@
+-- Indexes backing cursor (keyset) pagination ordered by (created_at, id)
+
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at_id ON jobs(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_created_by_created_at_id ON jobs(created_by, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_skills_created_at_id ON skills(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_job_applications_job_applied_at_id ON job_applications(job_id, applied_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_job_applications_candidate_applied_at_id ON job_applications(candidate_id, applied_at DESC, id DESC);
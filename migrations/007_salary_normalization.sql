@@ -0,0 +1,23 @@
+-- Salary currency, pay period and visibility, plus admin-managed exchange rates
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_currency CHAR(3) NOT NULL DEFAULT 'BRL'
+    CHECK (salary_currency ~ '^[A-Z]{3}$');
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_period VARCHAR(20) NOT NULL DEFAULT 'monthly'
+    CHECK (salary_period IN ('hourly', 'daily', 'weekly', 'monthly', 'yearly'));
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_type VARCHAR(10) NOT NULL DEFAULT 'gross'
+    CHECK (salary_type IN ('gross', 'net'));
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    from_currency CHAR(3) NOT NULL CHECK (from_currency ~ '^[A-Z]{3}$'),
+    to_currency CHAR(3) NOT NULL CHECK (to_currency ~ '^[A-Z]{3}$'),
+    rate DECIMAL(18,8) NOT NULL CHECK (rate > 0),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(from_currency, to_currency),
+    CHECK (from_currency <> to_currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_salary_currency ON jobs(salary_currency);
@@ -0,0 +1,14 @@
+-- Structured job location, remote flag and coordinates for radius search
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS country CHAR(2);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS state VARCHAR(100);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS city VARCHAR(150);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS latitude DECIMAL(9,6) CHECK (latitude BETWEEN -90 AND 90);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS longitude DECIMAL(9,6) CHECK (longitude BETWEEN -180 AND 180);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS is_remote BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE jobs SET is_remote = TRUE WHERE work_mode = 'remote';
+
+CREATE INDEX IF NOT EXISTS idx_jobs_country_state_city ON jobs(country, LOWER(state), LOWER(city));
+CREATE INDEX IF NOT EXISTS idx_jobs_coordinates ON jobs(latitude, longitude) WHERE latitude IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_jobs_is_remote ON jobs(is_remote) WHERE is_remote;
@@ -0,0 +1,25 @@
+-- Saved job searches and the alert bookkeeping for them
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS opened_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE jobs SET opened_at = COALESCE(publish_at, created_at)
+WHERE opened_at IS NULL AND status IN ('open', 'paused', 'closed');
+
+CREATE INDEX IF NOT EXISTS idx_jobs_opened_at ON jobs(opened_at) WHERE opened_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    frequency VARCHAR(20) NOT NULL DEFAULT 'daily' CHECK (frequency IN ('instant', 'daily', 'weekly')),
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    unsubscribe_token VARCHAR(64) NOT NULL UNIQUE,
+    last_alerted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_id ON saved_searches(user_id);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_due ON saved_searches(frequency, last_alerted_at) WHERE alerts_enabled;
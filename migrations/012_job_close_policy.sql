@@ -0,0 +1,7 @@
+-- What happens to open applications when a job closes
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS close_policy VARCHAR(20) NOT NULL DEFAULT 'notify'
+    CHECK (close_policy IN ('notify', 'reject'));
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS rejection_message TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_job_applications_job_status ON job_applications(job_id, status);
@@ -0,0 +1,11 @@
+-- Applied schema version, checked by the services' readiness probes.
+-- Every migration from now on ends by recording its own number.
+
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO schema_migrations (version)
+SELECT generate_series(1, 15)
+ON CONFLICT (version) DO NOTHING;
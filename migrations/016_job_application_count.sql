@@ -0,0 +1,32 @@
+-- Applications per job, counted by job-service from application.submitted
+-- events so it can close jobs that reach max_applications without reading
+-- candidate-service's tables. The count only grows: applications are never
+-- deleted, and withdrawn or rejected ones still count toward the cap, as in
+-- candidate-service's own check.
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS application_count INTEGER NOT NULL DEFAULT 0;
+
+-- job-service starts consuming events after the current end of the log.
+INSERT INTO event_consumer_offsets (consumer, last_seq, updated_at)
+SELECT 'job-service', COALESCE(MAX(seq), 0), NOW() FROM event_log
+ON CONFLICT (consumer) DO NOTHING;
+
+-- Backfill only what the consumer will not count: applications whose
+-- application.submitted event is at or before its offset, plus those made
+-- before the outbox existed. Events still in the outbox or logged after the
+-- offset are counted when they are consumed.
+UPDATE jobs SET application_count = (
+    SELECT COUNT(*) FROM job_applications a
+    WHERE a.job_id = jobs.id
+      AND NOT EXISTS (
+          SELECT 1 FROM outbox_events o
+          WHERE o.type = 'application.submitted' AND o.aggregate_id = a.id
+            AND NOT EXISTS (
+                SELECT 1 FROM event_log l
+                JOIN event_consumer_offsets c ON c.consumer = 'job-service'
+                WHERE l.id = o.id AND l.seq <= c.last_seq
+            )
+      )
+);
+
+INSERT INTO schema_migrations (version) VALUES (16) ON CONFLICT (version) DO NOTHING;
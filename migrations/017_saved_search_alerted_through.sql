@@ -0,0 +1,12 @@
+-- How far each saved search's alerts have covered, by job opened_at. It
+-- trails last_alerted_at when a digest was capped, so the jobs left out
+-- are sent in the next one.
+
+ALTER TABLE saved_searches ADD COLUMN IF NOT EXISTS alerted_through TIMESTAMP WITH TIME ZONE;
+
+UPDATE saved_searches SET alerted_through = last_alerted_at WHERE alerted_through IS NULL;
+
+ALTER TABLE saved_searches ALTER COLUMN alerted_through SET NOT NULL;
+ALTER TABLE saved_searches ALTER COLUMN alerted_through SET DEFAULT CURRENT_TIMESTAMP;
+
+INSERT INTO schema_migrations (version) VALUES (17) ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,18 @@
+-- Events a consumer gave up on, kept so they can be replayed once the cause
+-- is fixed, and the failed attempts at each consumer's next event, shared
+-- by its replicas and kept across restarts.
+
+ALTER TABLE event_consumer_offsets ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS event_dead_letters (
+    consumer VARCHAR(100) NOT NULL,
+    seq BIGINT NOT NULL REFERENCES event_log(seq),
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (consumer, seq)
+);
+
+INSERT INTO schema_migrations (version) VALUES (18) ON CONFLICT (version) DO NOTHING;
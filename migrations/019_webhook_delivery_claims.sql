@@ -0,0 +1,7 @@
+-- Claims on webhook deliveries being sent. They used to be taken by
+-- moving next_attempt_at, which manual redeliveries couldn't tell apart
+-- from a retry waiting for its turn.
+
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP WITH TIME ZONE;
+
+INSERT INTO schema_migrations (version) VALUES (19) ON CONFLICT (version) DO NOTHING;
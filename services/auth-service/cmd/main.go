@@ -1,63 +1,146 @@
 package main
 
 import (
-	"log"
+	"context"
 	"os"
+	"time"
 
 	"recruitment-system/services/auth-service/internal/application"
 	"recruitment-system/services/auth-service/internal/infrastructure"
 	"recruitment-system/services/auth-service/internal/interfaces"
 	"recruitment-system/shared/database"
+	"recruitment-system/shared/events"
+	"recruitment-system/shared/grpcutil"
+	"recruitment-system/shared/health"
+	"recruitment-system/shared/logging"
+	"recruitment-system/shared/metrics"
+	"recruitment-system/shared/middleware"
+	"recruitment-system/shared/pb/authv1"
+	"recruitment-system/shared/tracing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+	envErr := godotenv.Load()
+	logger := logging.Setup("auth-service")
+	if envErr != nil {
+		logger.Info("No .env file found")
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "auth-service", tracing.GetConfigFromEnv())
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	dbConfig := database.GetConfigFromEnv()
 	db, err := database.NewConnection(dbConfig)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		logging.Fatal("Failed to connect to database", err)
 	}
 
-	if err := database.TestConnection(db); err != nil {
-		log.Fatal("Database connection test failed:", err)
+	if err := database.TestConnection(context.Background(), db); err != nil {
+		logging.Fatal("Database connection test failed", err)
+	}
+	logger.Info("Database connection established")
+	if err := metrics.InstrumentDB(db, dbConfig.DBName); err != nil {
+		logging.Fatal("Failed to instrument database", err)
+	}
+	if err := tracing.InstrumentDB(db); err != nil {
+		logging.Fatal("Failed to instrument database", err)
 	}
 
 	userRepo := infrastructure.NewUserRepository(db)
 	refreshTokenRepo := infrastructure.NewRefreshTokenRepository(db)
 
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
-	authService := application.NewAuthService(userRepo, refreshTokenRepo, jwtSecret)
+	outbox := events.NewOutbox(db, "auth-service")
+	serviceClients, err := application.ParseServiceClients(os.Getenv("SERVICE_CLIENTS"))
+	if err != nil {
+		logging.Fatal("Invalid SERVICE_CLIENTS", err)
+	}
+	authService := application.NewAuthService(userRepo, refreshTokenRepo, database.NewUnitOfWork(db), outbox, jwtSecret, serviceClients)
+
+	pollInterval, err := time.ParseDuration(getEnv("EVENT_POLL_INTERVAL", "5s"))
+	if err != nil {
+		logging.Fatal("Invalid EVENT_POLL_INTERVAL", err)
+	}
+	broker, err := events.NewBroker(getEnv("EVENT_BROKER", "postgres"), db, dbConfig.DSN(), pollInterval)
+	if err != nil {
+		logging.Fatal("Failed to create event broker", err)
+	}
+
+	relayInterval, err := time.ParseDuration(getEnv("OUTBOX_RELAY_INTERVAL", "1s"))
+	if err != nil {
+		logging.Fatal("Invalid OUTBOX_RELAY_INTERVAL", err)
+	}
+	relay := events.NewRelay(db, broker, "auth-service", relayInterval)
+	go relay.Start(context.Background())
 
 	authController := interfaces.NewAuthController(authService)
 
-	router := gin.Default()
+	grpcServer := grpcutil.NewServer(jwtSecret)
+	authv1.RegisterAuthServiceServer(grpcServer, interfaces.NewAuthGRPCServer(authService))
+	go func() {
+		grpcPort := getEnv("GRPC_PORT", "9083")
+		logger.Info("Auth Service gRPC server starting", "port", grpcPort)
+		if err := grpcutil.ListenAndServe(grpcServer, ":"+grpcPort); err != nil {
+			logging.Fatal("Failed to start gRPC server", err)
+		}
+	}()
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(middleware.TrustedProxies(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		logging.Fatal("Invalid TRUSTED_PROXIES", err)
+	}
+	router.Use(gin.Recovery(), middleware.RequestID(), tracing.Middleware("auth-service"), middleware.RequestLogger(logger), metrics.Middleware())
 
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
-		
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID")
+
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
 			return
 		}
-		
+
 		c.Next()
 	})
 
-	interfaces.SetupRoutes(router, authController, jwtSecret)
+	rateLimitStore, err := middleware.NewRateLimitStore(getEnv("RATE_LIMIT_STORE", "memory"), db)
+	if err != nil {
+		logging.Fatal("Invalid RATE_LIMIT_STORE", err)
+	}
+	rateLimiter, err := middleware.NewRateLimiter(rateLimitStore, os.Getenv("RATE_LIMITS"),
+		middleware.RateLimitPolicy{Name: "api", Limit: 300, Window: time.Minute, Key: middleware.KeyByUser(jwtSecret)},
+		middleware.RateLimitPolicy{Name: "login", Limit: 10, Window: time.Minute, Key: middleware.KeyByIP},
+		middleware.RateLimitPolicy{Name: "register", Limit: 20, Window: time.Hour, Key: middleware.KeyByIP},
+		middleware.RateLimitPolicy{Name: "refresh", Limit: 30, Window: time.Minute, Key: middleware.KeyByIP},
+		middleware.RateLimitPolicy{Name: "service-token", Limit: 60, Window: time.Minute, Key: middleware.KeyByIP},
+	)
+	if err != nil {
+		logging.Fatal("Invalid RATE_LIMITS", err)
+	}
+
+	healthConfig, err := health.GetConfigFromEnv()
+	if err != nil {
+		logging.Fatal("Invalid health check configuration", err)
+	}
+	healthChecker := health.NewChecker("auth-service", healthConfig).
+		Add("database", health.Database(db)).
+		Add("schema", health.SchemaVersion(db))
+
+	interfaces.SetupRoutes(router, authController, jwtSecret, rateLimiter, healthChecker)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	port := getEnv("PORT", "8083")
-	log.Printf("Auth Service starting on port %s", port)
-	
+	logger.Info("Auth Service starting", "port", port)
+
 	if err := router.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+		logging.Fatal("Failed to start server", err)
 	}
 }
 
@@ -2,10 +2,16 @@ package application
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"recruitment-system/services/auth-service/internal/domain"
+	"recruitment-system/shared/database"
+	"recruitment-system/shared/events"
+	"recruitment-system/shared/serviceauth"
 	"recruitment-system/shared/utils"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,25 +20,36 @@ import (
 )
 
 type AuthService struct {
-	userRepo         domain.UserRepository
-	refreshTokenRepo domain.RefreshTokenRepository
-	jwtSecret        string
-	tokenExpiration  time.Duration
+	userRepo               domain.UserRepository
+	refreshTokenRepo       domain.RefreshTokenRepository
+	uow                    database.UnitOfWork
+	outbox                 domain.EventOutbox
+	jwtSecret              string
+	tokenExpiration        time.Duration
+	serviceClients         map[string]string
+	serviceTokenExpiration time.Duration
 }
 
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TokenType string `json:"token_type,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func NewAuthService(userRepo domain.UserRepository, refreshTokenRepo domain.RefreshTokenRepository, jwtSecret string) *AuthService {
+// NewAuthService creates the service. serviceClients maps the client ID of
+// each service allowed to call internal endpoints to its secret.
+func NewAuthService(userRepo domain.UserRepository, refreshTokenRepo domain.RefreshTokenRepository, uow database.UnitOfWork, outbox domain.EventOutbox, jwtSecret string, serviceClients map[string]string) *AuthService {
 	return &AuthService{
-		userRepo:         userRepo,
-		refreshTokenRepo: refreshTokenRepo,
-		jwtSecret:        jwtSecret,
-		tokenExpiration:  24 * time.Hour,
+		userRepo:               userRepo,
+		refreshTokenRepo:       refreshTokenRepo,
+		uow:                    uow,
+		outbox:                 outbox,
+		jwtSecret:              jwtSecret,
+		tokenExpiration:        24 * time.Hour,
+		serviceClients:         serviceClients,
+		serviceTokenExpiration: 15 * time.Minute,
 	}
 }
 
@@ -72,7 +89,19 @@ func (s *AuthService) Register(ctx context.Context, req domain.RegisterRequest)
 		UpdatedAt:    time.Now(),
 	}
 
-	if err := s.userRepo.Create(ctx, user); err != nil {
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+
+		return s.outbox.Add(ctx, events.UserRegistered, user.ID, events.UserRegisteredPayload{
+			UserID: user.ID,
+			Email:  user.Email,
+			Name:   user.Name,
+			Role:   user.Role,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -82,10 +111,12 @@ func (s *AuthService) Register(ctx context.Context, req domain.RegisterRequest)
 func (s *AuthService) Login(ctx context.Context, req domain.LoginRequest) (*domain.LoginResponse, error) {
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
+		loginFailures.WithLabelValues("unknown_user").Inc()
 		return nil, errors.New("invalid credentials")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		loginFailures.WithLabelValues("wrong_password").Inc()
 		return nil, errors.New("invalid credentials")
 	}
 
@@ -158,6 +189,10 @@ func (s *AuthService) GetUserByID(ctx context.Context, userID uuid.UUID) (*domai
 	return s.userRepo.GetByID(ctx, userID)
 }
 
+func (s *AuthService) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.User, error) {
+	return s.userRepo.GetByIDs(ctx, ids)
+}
+
 func (s *AuthService) ChangePassword(ctx context.Context, userID uuid.UUID, req domain.ChangePasswordRequest) error {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -186,9 +221,10 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID uuid.UUID, req
 func (s *AuthService) generateJWT(user *domain.User) (string, time.Time, error) {
 	expiresAt := time.Now().Add(s.tokenExpiration)
 	claims := JWTClaims{
-		UserID: user.ID.String(),
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:    user.ID.String(),
+		Email:     user.Email,
+		Role:      user.Role,
+		TokenType: serviceauth.TokenTypeUser,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -225,9 +261,60 @@ func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	if claims.TokenType == serviceauth.TokenTypeService {
+		return nil, errors.New("service tokens cannot authenticate users")
+	}
+
+	return claims, nil
+}
+
+// IssueServiceToken implements the client-credentials grant: a service
+// exchanges its client ID and secret for a short-lived service token.
+func (s *AuthService) IssueServiceToken(req domain.ServiceTokenRequest) (*domain.ServiceTokenResponse, error) {
+	secret, ok := s.serviceClients[req.ClientID]
+	if !ok || subtle.ConstantTimeCompare([]byte(secret), []byte(req.ClientSecret)) != 1 {
+		return nil, errors.New("invalid client credentials")
+	}
+
+	expiresAt := time.Now().Add(s.serviceTokenExpiration)
+	claims := JWTClaims{
+		TokenType: serviceauth.TokenTypeService,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   req.ClientID,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(s.jwtSecret))
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, errors.New("invalid token")
+	return &domain.ServiceTokenResponse{Token: tokenString, ExpiresAt: expiresAt}, nil
+}
+
+// ParseServiceClients parses SERVICE_CLIENTS, a comma-separated list of
+// client_id:secret pairs.
+func ParseServiceClients(value string) (map[string]string, error) {
+	clients := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		clientID, secret, ok := strings.Cut(entry, ":")
+		if !ok || clientID == "" || secret == "" {
+			return nil, fmt.Errorf("invalid service client %q, expected client_id:secret", entry)
+		}
+		clients[clientID] = secret
+	}
+	return clients, nil
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"recruitment-system/services/auth-service/internal/domain"
+	"recruitment-system/shared/events"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,11 @@ func (m *MockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.User, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
@@ -79,10 +85,26 @@ func (m *MockRefreshTokenRepository) DeleteByUserID(ctx context.Context, userID
 	return args.Error(0)
 }
 
+type passthroughUnitOfWork struct{}
+
+func (passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type MockEventOutbox struct {
+	mock.Mock
+}
+
+func (m *MockEventOutbox) Add(ctx context.Context, eventType string, aggregateID uuid.UUID, payload interface{}) error {
+	args := m.Called(ctx, eventType, aggregateID, payload)
+	return args.Error(0)
+}
+
 func TestAuthService_Register(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
-	authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, "test-secret")
+	mockOutbox := new(MockEventOutbox)
+	authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, passthroughUnitOfWork{}, mockOutbox, "test-secret", nil)
 
 	ctx := context.Background()
 	req := domain.RegisterRequest{
@@ -94,6 +116,7 @@ func TestAuthService_Register(t *testing.T) {
 
 	mockUserRepo.On("ExistsByEmail", ctx, req.Email).Return(false, nil)
 	mockUserRepo.On("Create", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+	mockOutbox.On("Add", ctx, events.UserRegistered, mock.AnythingOfType("uuid.UUID"), mock.AnythingOfType("events.UserRegisteredPayload")).Return(nil)
 
 	user, err := authService.Register(ctx, req)
 
@@ -103,12 +126,13 @@ func TestAuthService_Register(t *testing.T) {
 	assert.Equal(t, req.Name, user.Name)
 	assert.Equal(t, req.Role, user.Role)
 	mockUserRepo.AssertExpectations(t)
+	mockOutbox.AssertExpectations(t)
 }
 
 func TestAuthService_Login(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
-	authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, "test-secret")
+	authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, passthroughUnitOfWork{}, new(MockEventOutbox), "test-secret", nil)
 
 	ctx := context.Background()
 	password := "password123"
@@ -147,7 +171,7 @@ func TestAuthService_Login(t *testing.T) {
 func TestAuthService_ValidateToken(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
-	authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, "test-secret")
+	authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, passthroughUnitOfWork{}, new(MockEventOutbox), "test-secret", nil)
 
 	user := &domain.User{
 		ID:    uuid.New(),
@@ -166,3 +190,24 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	assert.Equal(t, user.Email, claims.Email)
 	assert.Equal(t, user.Role, claims.Role)
 }
+
+func TestAuthService_IssueServiceToken(t *testing.T) {
+	clients, err := ParseServiceClients("job-service:job-secret, candidate-service:candidate-secret")
+	assert.NoError(t, err)
+	authService := NewAuthService(new(MockUserRepository), new(MockRefreshTokenRepository), passthroughUnitOfWork{}, new(MockEventOutbox), "test-secret", clients)
+
+	_, err = authService.IssueServiceToken(domain.ServiceTokenRequest{ClientID: "job-service", ClientSecret: "candidate-secret"})
+	assert.Error(t, err)
+
+	response, err := authService.IssueServiceToken(domain.ServiceTokenRequest{ClientID: "candidate-service", ClientSecret: "candidate-secret"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.Token)
+
+	_, err = authService.ValidateToken(response.Token)
+	assert.Error(t, err, "service tokens must not pass as user tokens")
+}
+
+func TestParseServiceClients_RejectsMalformedEntries(t *testing.T) {
+	_, err := ParseServiceClients("job-service")
+	assert.Error(t, err)
+}
@@ -0,0 +1,12 @@
+package application
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var loginFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "recruitment",
+	Name:      "login_failures_total",
+	Help:      "Failed login attempts, by reason.",
+}, []string{"reason"})
@@ -9,6 +9,7 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id uuid.UUID) error
@@ -22,3 +23,9 @@ type RefreshTokenRepository interface {
 	Delete(ctx context.Context, token string) error
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
 }
+
+// EventOutbox records domain events in the caller's unit of work; a relay
+// publishes them once the transaction commits.
+type EventOutbox interface {
+	Add(ctx context.Context, eventType string, aggregateID uuid.UUID, payload interface{}) error
+}
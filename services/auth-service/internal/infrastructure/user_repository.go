@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"recruitment-system/services/auth-service/internal/domain"
+	"recruitment-system/shared/database"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -18,21 +19,27 @@ func NewUserRepository(db *gorm.DB) domain.UserRepository {
 }
 
 func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return database.DB(ctx, r.db).Create(user).Error
 }
 
 func (r *UserRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	var user domain.User
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
+func (r *UserRepositoryImpl) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.User, error) {
+	var users []*domain.User
+	err := database.DB(ctx, r.db).Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
 func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := database.DB(ctx, r.db).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -40,27 +47,27 @@ func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*dom
 }
 
 func (r *UserRepositoryImpl) Update(ctx context.Context, user *domain.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return database.DB(ctx, r.db).Save(user).Error
 }
 
 func (r *UserRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.User{}, id).Error
+	return database.DB(ctx, r.db).Delete(&domain.User{}, id).Error
 }
 
 func (r *UserRepositoryImpl) List(ctx context.Context, offset, limit int) ([]*domain.User, int64, error) {
 	var users []*domain.User
 	var total int64
 
-	if err := r.db.WithContext(ctx).Model(&domain.User{}).Count(&total).Error; err != nil {
+	if err := database.DB(ctx, r.db).Model(&domain.User{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := r.db.WithContext(ctx).Offset(offset).Limit(limit).Find(&users).Error
+	err := database.DB(ctx, r.db).Offset(offset).Limit(limit).Find(&users).Error
 	return users, total, err
 }
 
 func (r *UserRepositoryImpl) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("email = ?", email).Count(&count).Error
+	err := database.DB(ctx, r.db).Model(&domain.User{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"net/http"
+	"strings"
 
 	"recruitment-system/services/auth-service/internal/application"
 	"recruitment-system/services/auth-service/internal/domain"
@@ -172,3 +173,87 @@ func (c *AuthController) ValidateToken(ctx *gin.Context) {
 		"role":    claims.Role,
 	})
 }
+
+func (c *AuthController) IssueServiceToken(ctx *gin.Context) {
+	var req domain.ServiceTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	response, err := c.authService.IssueServiceToken(req)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Service authentication failed", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Service token issued successfully", response)
+}
+
+const maxUserLookupIDs = 100
+
+func (c *AuthController) GetInternalUser(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	user, err := c.authService.GetUserByID(ctx.Request.Context(), userID)
+	if err != nil {
+		utils.NotFoundResponse(ctx, "User")
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "User retrieved successfully", domain.UserInfo{
+		ID:    user.ID,
+		Email: user.Email,
+		Name:  user.Name,
+		Role:  user.Role,
+	})
+}
+
+// GetInternalUsers looks up users by ?ids=a,b,c. Unknown IDs are left out
+// of the result.
+func (c *AuthController) GetInternalUsers(ctx *gin.Context) {
+	var ids []uuid.UUID
+	for _, value := range strings.Split(ctx.Query("ids"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid user ID", err)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Query parameter ids is required", nil)
+		return
+	}
+	if len(ids) > maxUserLookupIDs {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Too many user IDs", nil)
+		return
+	}
+
+	users, err := c.authService.GetUsersByIDs(ctx.Request.Context(), ids)
+	if err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]domain.UserInfo, 0, len(users))
+	for _, user := range users {
+		response = append(response, domain.UserInfo{
+			ID:    user.ID,
+			Email: user.Email,
+			Name:  user.Name,
+			Role:  user.Role,
+		})
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Users retrieved successfully", response)
+}
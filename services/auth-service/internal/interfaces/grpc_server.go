@@ -0,0 +1,68 @@
+package interfaces
+
+import (
+	"context"
+
+	"recruitment-system/services/auth-service/internal/application"
+	"recruitment-system/shared/grpcutil"
+	"recruitment-system/shared/pb/authv1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthGRPCServer serves the internal gRPC API defined in
+// proto/auth/v1/auth.proto.
+type AuthGRPCServer struct {
+	authv1.UnimplementedAuthServiceServer
+	authService *application.AuthService
+}
+
+func NewAuthGRPCServer(authService *application.AuthService) *AuthGRPCServer {
+	return &AuthGRPCServer{authService: authService}
+}
+
+func (s *AuthGRPCServer) ValidateToken(ctx context.Context, req *authv1.ValidateTokenRequest) (*authv1.ValidateTokenResponse, error) {
+	claims, err := s.authService.ValidateToken(req.Token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	return &authv1.ValidateTokenResponse{User: &authv1.User{
+		Id:    claims.UserID,
+		Email: claims.Email,
+		Role:  claims.Role,
+	}}, nil
+}
+
+func (s *AuthGRPCServer) GetUsers(ctx context.Context, req *authv1.GetUsersRequest) (*authv1.GetUsersResponse, error) {
+	if len(req.Ids) == 0 || len(req.Ids) > maxUserLookupIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "between 1 and %d user IDs are required", maxUserLookupIDs)
+	}
+
+	ids := make([]uuid.UUID, len(req.Ids))
+	for i, value := range req.Ids {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid user ID %q", value)
+		}
+		ids[i] = id
+	}
+
+	users, err := s.authService.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, grpcutil.Error(err)
+	}
+
+	response := &authv1.GetUsersResponse{Users: make([]*authv1.User, len(users))}
+	for i, user := range users {
+		response.Users[i] = &authv1.User{
+			Id:    user.ID.String(),
+			Email: user.Email,
+			Name:  user.Name,
+			Role:  user.Role,
+		}
+	}
+	return response, nil
+}
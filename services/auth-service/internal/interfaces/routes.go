@@ -1,20 +1,22 @@
 package interfaces
 
 import (
+	"recruitment-system/shared/health"
 	"recruitment-system/shared/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, authController *AuthController, jwtSecret string) {
-	api := router.Group("/api/v1")
-	
+func SetupRoutes(router *gin.Engine, authController *AuthController, jwtSecret string, rateLimiter *middleware.RateLimiter, healthChecker *health.Checker) {
+	api := router.Group("/api/v1", rateLimiter.Limit("api"))
+
 	auth := api.Group("/auth")
 	{
-		auth.POST("/register", authController.Register)
-		auth.POST("/login", authController.Login)
-		auth.POST("/refresh", authController.RefreshToken)
+		auth.POST("/register", rateLimiter.Limit("register"), authController.Register)
+		auth.POST("/login", rateLimiter.Limit("login"), authController.Login)
+		auth.POST("/refresh", rateLimiter.Limit("refresh"), authController.RefreshToken)
 		auth.POST("/validate", authController.ValidateToken)
+		auth.POST("/service-token", rateLimiter.Limit("service-token"), authController.IssueServiceToken)
 	}
 
 	protected := api.Group("/auth")
@@ -25,10 +27,12 @@ func SetupRoutes(router *gin.Engine, authController *AuthController, jwtSecret s
 		protected.PUT("/change-password", authController.ChangePassword)
 	}
 
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"service": "auth-service",
-		})
-	})
+	internal := router.Group("/internal/v1")
+	internal.Use(middleware.ServiceAuthMiddleware(jwtSecret))
+	{
+		internal.GET("/users", authController.GetInternalUsers)
+		internal.GET("/users/:id", authController.GetInternalUser)
+	}
+
+	healthChecker.Register(router)
 }
@@ -1,85 +1,197 @@
 package main
 
 import (
-	"log"
+	"context"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-	"gorm.io/gorm"
 
 	"recruitment-system/services/candidate-service/internal/application"
 	"recruitment-system/services/candidate-service/internal/infrastructure"
 	"recruitment-system/services/candidate-service/internal/interfaces"
 	"recruitment-system/shared/database"
+	"recruitment-system/shared/events"
+	"recruitment-system/shared/grpcutil"
+	"recruitment-system/shared/health"
+	"recruitment-system/shared/httpclient"
+	"recruitment-system/shared/logging"
+	"recruitment-system/shared/metrics"
 	"recruitment-system/shared/middleware"
+	"recruitment-system/shared/pb/candidatev1"
+	"recruitment-system/shared/serviceauth"
+	"recruitment-system/shared/tracing"
+	"recruitment-system/shared/utils"
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+	envErr := godotenv.Load()
+	logger := logging.Setup("candidate-service")
+	if envErr != nil {
+		logger.Info("No .env file found")
 	}
 
-	db, err := database.Connect()
+	shutdownTracing, err := tracing.Setup(context.Background(), "candidate-service", tracing.GetConfigFromEnv())
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		logging.Fatal("Failed to set up tracing", err)
 	}
+	defer shutdownTracing(context.Background())
 
-	if err := runMigrations(db); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+	dbConfig := database.GetConfigFromEnv()
+	db, err := database.NewConnection(dbConfig)
+	if err != nil {
+		logging.Fatal("Failed to connect to database", err)
+	}
+
+	if err := database.TestConnection(context.Background(), db); err != nil {
+		logging.Fatal("Database connection test failed", err)
+	}
+	logger.Info("Database connection established")
+	if err := metrics.InstrumentDB(db, dbConfig.DBName); err != nil {
+		logging.Fatal("Failed to instrument database", err)
+	}
+	if err := tracing.InstrumentDB(db); err != nil {
+		logging.Fatal("Failed to instrument database", err)
 	}
 
 	candidateRepo := infrastructure.NewCandidateRepository(db)
+	candidateSkillRepo := infrastructure.NewCandidateSkillRepository(db)
 	skillRepo := infrastructure.NewSkillRepository(db)
 	workExperienceRepo := infrastructure.NewWorkExperienceRepository(db)
 	educationRepo := infrastructure.NewEducationRepository(db)
 	resumeRepo := infrastructure.NewResumeRepository(db)
 	jobApplicationRepo := infrastructure.NewJobApplicationRepository(db)
+	answerRepo := infrastructure.NewScreeningAnswerRepository(db)
 
-	authClient := infrastructure.NewAuthClient()
-	jobClient := infrastructure.NewJobClient()
-	fileStorageClient := infrastructure.NewFileStorageClient()
-	aiClient := infrastructure.NewAIClient()
+	serviceTokens := serviceauth.NewTokenSource(getEnv("AUTH_SERVICE_URL", "http://localhost:8083"), serviceauth.Credentials{
+		ClientID:     getEnv("SERVICE_CLIENT_ID", "candidate-service"),
+		ClientSecret: os.Getenv("SERVICE_CLIENT_SECRET"),
+	})
+	authConn, err := grpcutil.Dial("auth-service", getEnv("AUTH_SERVICE_GRPC_ADDR", "localhost:9083"), serviceTokens, httpclient.DefaultConfig())
+	if err != nil {
+		logging.Fatal("Failed to connect to auth-service", err)
+	}
+	jobConn, err := grpcutil.Dial("job-service", getEnv("JOB_SERVICE_GRPC_ADDR", "localhost:9081"), serviceTokens, httpclient.DefaultConfig())
+	if err != nil {
+		logging.Fatal("Failed to connect to job-service", err)
+	}
+
+	userCacheTTL, err := time.ParseDuration(getEnv("USER_CACHE_TTL", "1m"))
+	if err != nil {
+		logging.Fatal("Invalid USER_CACHE_TTL", err)
+	}
+	authClient := infrastructure.NewCachedAuthServiceClient(infrastructure.NewAuthServiceClient(authConn), userCacheTTL)
+	jobClient := infrastructure.NewJobServiceClient(jobConn)
+	uploadDir := getEnv("UPLOAD_DIR", "./uploads")
+	uploadMinFreeMB, err := strconv.ParseUint(getEnv("UPLOAD_MIN_FREE_MB", "100"), 10, 64)
+	if err != nil {
+		logging.Fatal("Invalid UPLOAD_MIN_FREE_MB", err)
+	}
+	fileStorage := infrastructure.NewFileStorageService(uploadDir)
+	aiService := infrastructure.NewAIService(getEnv("AI_SERVICE_URL", "http://localhost:8084"), os.Getenv("AI_SERVICE_API_KEY"))
+
+	outbox := events.NewOutbox(db, "candidate-service")
 
 	candidateService := application.NewCandidateService(
 		candidateRepo,
-		skillRepo,
+		candidateSkillRepo,
 		workExperienceRepo,
 		educationRepo,
 		resumeRepo,
 		jobApplicationRepo,
+		answerRepo,
+		skillRepo,
+		database.NewUnitOfWork(db),
+		fileStorage,
+		aiService,
 		authClient,
 		jobClient,
-		fileStorageClient,
-		aiClient,
+		outbox,
 	)
 
-	candidateController := interfaces.NewCandidateController(candidateService)
+	pollInterval, err := time.ParseDuration(getEnv("EVENT_POLL_INTERVAL", "5s"))
+	if err != nil {
+		logging.Fatal("Invalid EVENT_POLL_INTERVAL", err)
+	}
+	broker, err := events.NewBroker(getEnv("EVENT_BROKER", "postgres"), db, dbConfig.DSN(), pollInterval)
+	if err != nil {
+		logging.Fatal("Failed to create event broker", err)
+	}
 
-	r := gin.Default()
+	relayInterval, err := time.ParseDuration(getEnv("OUTBOX_RELAY_INTERVAL", "1s"))
+	if err != nil {
+		logging.Fatal("Invalid OUTBOX_RELAY_INTERVAL", err)
+	}
+	relay := events.NewRelay(db, broker, "candidate-service", relayInterval)
+	go relay.Start(context.Background())
+
+	handler := events.Idempotent(db, "candidate-service", candidateService.HandleDomainEvent)
+	if err := broker.Subscribe(context.Background(), "candidate-service", handler); err != nil {
+		logging.Fatal("Failed to subscribe to domain events", err)
+	}
+
+	cursors := utils.NewCursorCodec(getEnv("CURSOR_SECRET", "your-cursor-secret"))
+	candidateController := interfaces.NewCandidateController(candidateService, cursors)
+
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	grpcServer := grpcutil.NewServer(jwtSecret)
+	candidatev1.RegisterCandidateServiceServer(grpcServer, interfaces.NewCandidateGRPCServer(candidateService))
+	go func() {
+		grpcPort := getEnv("GRPC_PORT", "9082")
+		logger.Info("Candidate Service gRPC server starting", "port", grpcPort)
+		if err := grpcutil.ListenAndServe(grpcServer, ":"+grpcPort); err != nil {
+			logging.Fatal("Failed to start gRPC server", err)
+		}
+	}()
+
+	r := gin.New()
+	if err := r.SetTrustedProxies(middleware.TrustedProxies(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		logging.Fatal("Invalid TRUSTED_PROXIES", err)
+	}
+	r.Use(gin.Recovery(), middleware.RequestID(), tracing.Middleware("candidate-service"), middleware.RequestLogger(logger), metrics.Middleware(), middleware.GatewayIdentity(getEnv("GATEWAY_SECRET", "")))
 
 	r.Use(middleware.CORS())
 
-	interfaces.SetupRoutes(r, candidateController)
+	rateLimitStore, err := middleware.NewRateLimitStore(getEnv("RATE_LIMIT_STORE", "memory"), db)
+	if err != nil {
+		logging.Fatal("Invalid RATE_LIMIT_STORE", err)
+	}
+	rateLimiter, err := middleware.NewRateLimiter(rateLimitStore, os.Getenv("RATE_LIMITS"),
+		middleware.RateLimitPolicy{Name: "api", Limit: 300, Window: time.Minute, Key: middleware.KeyByUser(jwtSecret)},
+		middleware.RateLimitPolicy{Name: "resume-upload", Limit: 10, Window: time.Hour, Key: middleware.KeyByUser(jwtSecret)},
+		middleware.RateLimitPolicy{Name: "apply", Limit: 30, Window: time.Hour, Key: middleware.KeyByUser(jwtSecret)},
+	)
+	if err != nil {
+		logging.Fatal("Invalid RATE_LIMITS", err)
+	}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8082"
+	healthConfig, err := health.GetConfigFromEnv()
+	if err != nil {
+		logging.Fatal("Invalid health check configuration", err)
 	}
+	healthChecker := health.NewChecker("candidate-service", healthConfig).
+		Add("database", health.Database(db)).
+		Add("schema", health.SchemaVersion(db)).
+		Add("auth-service", health.GRPC(authConn)).
+		Add("job-service", health.GRPC(jobConn)).
+		Add("upload_dir", health.DiskSpace(uploadDir, uploadMinFreeMB<<20))
 
-	log.Printf("Candidate Service starting on port %s", port)
+	interfaces.SetupRoutes(r, candidateController, rateLimiter, healthChecker)
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	port := getEnv("PORT", "8082")
+
+	logger.Info("Candidate Service starting", "port", port)
 	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+		logging.Fatal("Failed to start server", err)
 	}
 }
 
-func runMigrations(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&infrastructure.CandidateModel{},
-		&infrastructure.SkillModel{},
-		&infrastructure.WorkExperienceModel{},
-		&infrastructure.EducationModel{},
-		&infrastructure.ResumeModel{},
-		&infrastructure.JobApplicationModel{},
-	)
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
 }
@@ -3,27 +3,36 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"mime/multipart"
 	"time"
 
 	"recruitment-system/services/candidate-service/internal/domain"
+	"recruitment-system/shared/database"
+	"recruitment-system/shared/events"
+	"recruitment-system/shared/gatewayauth"
+	"recruitment-system/shared/httpclient"
 	"recruitment-system/shared/utils"
 
 	"github.com/google/uuid"
 )
 
 type CandidateService struct {
-	candidateRepo     domain.CandidateRepository
+	candidateRepo      domain.CandidateRepository
 	candidateSkillRepo domain.CandidateSkillRepository
-	workExpRepo       domain.WorkExperienceRepository
-	educationRepo     domain.EducationRepository
-	resumeRepo        domain.ResumeRepository
-	applicationRepo   domain.JobApplicationRepository
-	skillRepo         domain.SkillRepository
-	fileStorage       domain.FileStorageService
-	aiService         domain.AIService
-	authClient        domain.AuthServiceClient
-	jobClient         domain.JobServiceClient
+	workExpRepo        domain.WorkExperienceRepository
+	educationRepo      domain.EducationRepository
+	resumeRepo         domain.ResumeRepository
+	applicationRepo    domain.JobApplicationRepository
+	answerRepo         domain.ScreeningAnswerRepository
+	skillRepo          domain.SkillRepository
+	uow                database.UnitOfWork
+	fileStorage        domain.FileStorageService
+	aiService          domain.AIService
+	authClient         domain.AuthServiceClient
+	jobClient          domain.JobServiceClient
+	outbox             domain.EventOutbox
 }
 
 func NewCandidateService(
@@ -33,11 +42,14 @@ func NewCandidateService(
 	educationRepo domain.EducationRepository,
 	resumeRepo domain.ResumeRepository,
 	applicationRepo domain.JobApplicationRepository,
+	answerRepo domain.ScreeningAnswerRepository,
 	skillRepo domain.SkillRepository,
+	uow database.UnitOfWork,
 	fileStorage domain.FileStorageService,
 	aiService domain.AIService,
 	authClient domain.AuthServiceClient,
 	jobClient domain.JobServiceClient,
+	outbox domain.EventOutbox,
 ) *CandidateService {
 	return &CandidateService{
 		candidateRepo:      candidateRepo,
@@ -46,11 +58,14 @@ func NewCandidateService(
 		educationRepo:      educationRepo,
 		resumeRepo:         resumeRepo,
 		applicationRepo:    applicationRepo,
+		answerRepo:         answerRepo,
 		skillRepo:          skillRepo,
+		uow:                uow,
 		fileStorage:        fileStorage,
 		aiService:          aiService,
 		authClient:         authClient,
 		jobClient:          jobClient,
+		outbox:             outbox,
 	}
 }
 
@@ -91,6 +106,7 @@ func (s *CandidateService) GetCandidateByID(ctx context.Context, id uuid.UUID) (
 	if err := s.loadCandidateRelations(ctx, candidate); err != nil {
 		return nil, err
 	}
+	s.attachUsersOrLog(ctx, candidate)
 
 	return candidate, nil
 }
@@ -104,6 +120,7 @@ func (s *CandidateService) GetCandidateByUserID(ctx context.Context, userID uuid
 	if err := s.loadCandidateRelations(ctx, candidate); err != nil {
 		return nil, err
 	}
+	s.attachUsersOrLog(ctx, candidate)
 
 	return candidate, nil
 }
@@ -142,8 +159,7 @@ func (s *CandidateService) AddSkill(ctx context.Context, candidateID uuid.UUID,
 		return errors.New("you can only add skills to your own profile")
 	}
 
-	skill, err := s.skillRepo.GetByID(ctx, req.SkillID)
-	if err != nil {
+	if _, err := s.skillRepo.GetByID(ctx, req.SkillID); err != nil {
 		return errors.New("skill not found")
 	}
 
@@ -282,6 +298,7 @@ func (s *CandidateService) ApplyToJob(ctx context.Context, candidateID uuid.UUID
 	if candidate.UserID != userID {
 		return nil, errors.New("you can only apply to jobs with your own profile")
 	}
+	s.attachUsersOrLog(ctx, candidate)
 
 	exists, err := s.applicationRepo.ExistsByCandidateAndJob(ctx, candidateID, req.JobID)
 	if err != nil {
@@ -291,14 +308,25 @@ func (s *CandidateService) ApplyToJob(ctx context.Context, candidateID uuid.UUID
 		return nil, errors.New("you have already applied to this job")
 	}
 
-	isOpen, err := s.jobClient.IsJobOpen(ctx, req.JobID)
+	job, err := s.jobClient.GetJobByID(ctx, req.JobID)
+	if errors.Is(err, httpclient.ErrNotFound) {
+		return nil, errors.New("job not found")
+	}
+	if errors.Is(err, httpclient.ErrUnavailable) {
+		return nil, errors.New("job service unavailable, please try again later")
+	}
 	if err != nil {
 		return nil, errors.New("failed to verify job status")
 	}
-	if !isOpen {
+	if job.Status != "open" {
 		return nil, errors.New("job is not open for applications")
 	}
 
+	answers, knockedOut, err := evaluateScreeningAnswers(job.ScreeningQuestions, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+
 	application := &domain.JobApplication{
 		ID:          uuid.New(),
 		JobID:       req.JobID,
@@ -309,30 +337,134 @@ func (s *CandidateService) ApplyToJob(ctx context.Context, candidateID uuid.UUID
 		UpdatedAt:   time.Now(),
 	}
 
-	if err := s.applicationRepo.Create(ctx, application); err != nil {
+	if knockedOut {
+		application.Status = "rejected"
+		application.KnockedOut = true
+	}
+
+	for i := range answers {
+		answers[i].ApplicationID = application.ID
+	}
+
+	candidateName := ""
+	if candidate.User != nil {
+		candidateName = candidate.User.Name
+	}
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		// Count under a per-job lock so concurrent applications can't
+		// overshoot the cap.
+		if job.MaxApplications != nil {
+			if err := s.applicationRepo.LockJob(ctx, job.ID); err != nil {
+				return err
+			}
+			count, err := s.applicationRepo.CountByJobID(ctx, job.ID)
+			if err != nil {
+				return err
+			}
+			if count >= int64(*job.MaxApplications) {
+				return errors.New("job is no longer accepting applications")
+			}
+		}
+
+		if err := s.applicationRepo.Create(ctx, application); err != nil {
+			return err
+		}
+
+		if err := s.answerRepo.CreateBatch(ctx, answers); err != nil {
+			return err
+		}
+
+		return s.outbox.Add(ctx, events.ApplicationSubmitted, application.ID, events.ApplicationSubmittedPayload{
+			ApplicationID:   application.ID,
+			JobID:           job.ID,
+			JobTitle:        job.Title,
+			RecruiterID:     job.CreatedBy,
+			CandidateID:     candidateID,
+			CandidateUserID: candidate.UserID,
+			CandidateName:   candidateName,
+			Status:          application.Status,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
+	applicationsSubmitted.Inc()
 
+	application.Answers = answers
 	return application, nil
 }
 
-func (s *CandidateService) GetApplications(ctx context.Context, candidateID uuid.UUID, userID uuid.UUID) ([]domain.JobApplication, error) {
-	candidate, err := s.candidateRepo.GetByID(ctx, candidateID)
+func (s *CandidateService) GetJobApplications(ctx context.Context, jobID uuid.UUID, userID uuid.UUID, page utils.PageRequest) ([]domain.JobApplication, int64, error) {
+	job, err := s.jobClient.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if job.CreatedBy != userID {
+		return nil, 0, errors.New("you can only view applications of jobs you created")
+	}
+
+	applications, total, err := s.applicationRepo.ListByJobID(ctx, jobID, page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	candidates := make([]*domain.Candidate, 0, len(applications))
+	for i := range applications {
+		if applications[i].Candidate != nil {
+			candidates = append(candidates, applications[i].Candidate)
+		}
+	}
+	s.attachUsersOrLog(ctx, candidates...)
+
+	return applications, total, nil
+}
+
+func (s *CandidateService) GetJobApplication(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.JobApplication, error) {
+	application, err := s.applicationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobClient.GetJobByID(ctx, application.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.CreatedBy != userID {
+		return nil, errors.New("you can only view applications of jobs you created")
+	}
+
+	candidate, err := s.candidateRepo.GetByID(ctx, application.CandidateID)
 	if err != nil {
 		return nil, err
 	}
+	s.attachUsersOrLog(ctx, candidate)
+	application.Candidate = candidate
+
+	return application, nil
+}
+
+func (s *CandidateService) GetApplications(ctx context.Context, candidateID uuid.UUID, userID uuid.UUID, page utils.PageRequest) ([]domain.JobApplication, int64, error) {
+	candidate, err := s.candidateRepo.GetByID(ctx, candidateID)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	if candidate.UserID != userID {
-		return nil, errors.New("you can only view your own applications")
+		return nil, 0, errors.New("you can only view your own applications")
 	}
 
-	return s.applicationRepo.GetByCandidateID(ctx, candidateID)
+	return s.applicationRepo.ListByCandidateID(ctx, candidateID, page)
 }
 
+// ValidateUserPermissions returns the caller's user, taken from the gateway
+// when the request came through it and from auth-service otherwise.
 func (s *CandidateService) ValidateUserPermissions(ctx context.Context, token string, requiredRole string) (*domain.UserInfo, error) {
-	userInfo, err := s.authClient.ValidateToken(ctx, token)
+	userInfo, err := s.authenticate(ctx, token)
 	if err != nil {
-		return nil, errors.New("invalid token")
+		return nil, err
 	}
 
 	if requiredRole != "" && userInfo.Role != requiredRole {
@@ -342,6 +474,56 @@ func (s *CandidateService) ValidateUserPermissions(ctx context.Context, token st
 	return userInfo, nil
 }
 
+func (s *CandidateService) authenticate(ctx context.Context, token string) (*domain.UserInfo, error) {
+	if user, ok := gatewayauth.FromContext(ctx); ok {
+		return &domain.UserInfo{ID: user.ID, Email: user.Email, Role: user.Role, Name: user.Email}, nil
+	}
+
+	userInfo, err := s.authClient.ValidateToken(ctx, token)
+	if errors.Is(err, httpclient.ErrUnavailable) {
+		return nil, errors.New("authentication service unavailable")
+	}
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+	return userInfo, nil
+}
+
+// attachUsers fills in the auth-service user of each candidate with one
+// batch lookup. Candidates whose user no longer exists are left without one.
+func (s *CandidateService) attachUsers(ctx context.Context, candidates ...*domain.Candidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uuid.UUID, len(candidates))
+	for i, candidate := range candidates {
+		userIDs[i] = candidate.UserID
+	}
+
+	users, err := s.authClient.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uuid.UUID]*domain.UserInfo, len(users))
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+	for _, candidate := range candidates {
+		candidate.User = byID[candidate.UserID]
+	}
+	return nil
+}
+
+// attachUsersOrLog is attachUsers for reads that are still useful without
+// names and e-mails, such as while auth-service is unavailable.
+func (s *CandidateService) attachUsersOrLog(ctx context.Context, candidates ...*domain.Candidate) {
+	if err := s.attachUsers(ctx, candidates...); err != nil {
+		slog.WarnContext(ctx, "Failed to load candidate users", "error", err)
+	}
+}
+
 func (s *CandidateService) loadCandidateRelations(ctx context.Context, candidate *domain.Candidate) error {
 	skills, err := s.candidateSkillRepo.GetByCandidateID(ctx, candidate.ID)
 	if err != nil {
@@ -379,6 +561,7 @@ func (s *CandidateService) loadCandidateRelations(ctx context.Context, candidate
 func (s *CandidateService) processResumeWithAI(ctx context.Context, resume *domain.Resume) {
 	extractedText, err := s.aiService.ExtractTextFromResume(ctx, resume.FilePath)
 	if err != nil {
+		s.notifyResume(ctx, resume, events.ResumeFailed)
 		return
 	}
 
@@ -390,10 +573,86 @@ func (s *CandidateService) processResumeWithAI(ctx context.Context, resume *doma
 
 	processedData, err := s.aiService.ProcessResumeData(ctx, extractedText)
 	if err != nil {
+		s.notifyResume(ctx, resume, events.ResumeFailed)
 		return
 	}
 
 	s.autoFillCandidateData(ctx, resume.CandidateID, processedData)
+	s.notifyResume(ctx, resume, events.ResumeProcessed)
+}
+
+func (s *CandidateService) notifyResume(ctx context.Context, resume *domain.Resume, eventType string) {
+	if eventType == events.ResumeFailed {
+		resumesProcessed.WithLabelValues("failed").Inc()
+	} else {
+		resumesProcessed.WithLabelValues("processed").Inc()
+	}
+
+	candidate, err := s.candidateRepo.GetByID(ctx, resume.CandidateID)
+	if err != nil {
+		return
+	}
+
+	err = s.outbox.Add(ctx, eventType, resume.ID, events.ResumePayload{
+		ResumeID:        resume.ID,
+		CandidateID:     candidate.ID,
+		CandidateUserID: candidate.UserID,
+		Filename:        resume.Filename,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record event", "event_type", eventType, "error", err)
+	}
+}
+
+func evaluateScreeningAnswers(questions []domain.ScreeningQuestion, reqs []domain.ScreeningAnswerRequest) ([]domain.ScreeningAnswer, bool, error) {
+	byQuestion := make(map[uuid.UUID]domain.ScreeningAnswerRequest, len(reqs))
+	for _, req := range reqs {
+		if _, exists := byQuestion[req.QuestionID]; exists {
+			return nil, false, errors.New("each screening question can only be answered once")
+		}
+		byQuestion[req.QuestionID] = req
+	}
+
+	answers := make([]domain.ScreeningAnswer, 0, len(reqs))
+	knockedOut := false
+	for _, question := range questions {
+		req, answered := byQuestion[question.ID]
+		delete(byQuestion, question.ID)
+
+		answer := utils.SanitizeString(req.Answer)
+		if question.QuestionType == "multi_choice" {
+			answer = ""
+		}
+		if !answered || !question.IsAnswered(answer, req.Choices) {
+			if question.IsRequired {
+				return nil, false, fmt.Errorf("%q is required", question.Question)
+			}
+			continue
+		}
+
+		knockout, err := question.Evaluate(answer, req.Choices)
+		if err != nil {
+			return nil, false, err
+		}
+		knockedOut = knockedOut || knockout
+
+		answers = append(answers, domain.ScreeningAnswer{
+			ID:           uuid.New(),
+			QuestionID:   question.ID,
+			Question:     question.Question,
+			QuestionType: question.QuestionType,
+			Answer:       answer,
+			Choices:      req.Choices,
+			IsKnockout:   knockout,
+			CreatedAt:    time.Now(),
+		})
+	}
+
+	if len(byQuestion) > 0 {
+		return nil, false, errors.New("answers reference questions that do not belong to this job")
+	}
+
+	return answers, knockedOut, nil
 }
 
 func (s *CandidateService) autoFillCandidateData(ctx context.Context, candidateID uuid.UUID, data *domain.ProcessedResumeData) {
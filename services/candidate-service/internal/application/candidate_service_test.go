@@ -0,0 +1,103 @@
+package application
+
+import (
+	"testing"
+
+	"recruitment-system/services/candidate-service/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateScreeningAnswers(t *testing.T) {
+	minYears := 2.0
+	workPermit := domain.ScreeningQuestion{
+		ID:              uuid.New(),
+		Question:        "Do you have a work permit?",
+		QuestionType:    "yes_no",
+		IsRequired:      true,
+		KnockoutAnswers: []string{"no"},
+	}
+	experience := domain.ScreeningQuestion{
+		ID:           uuid.New(),
+		Question:     "Years of experience with Go",
+		QuestionType: "numeric",
+		MinValue:     &minYears,
+	}
+	stack := domain.ScreeningQuestion{
+		ID:           uuid.New(),
+		Question:     "Which databases have you used?",
+		QuestionType: "multi_choice",
+		Options:      []string{"PostgreSQL", "MySQL", "MongoDB"},
+	}
+	questions := []domain.ScreeningQuestion{workPermit, experience, stack}
+
+	answers, knockedOut, err := evaluateScreeningAnswers(questions, []domain.ScreeningAnswerRequest{
+		{QuestionID: workPermit.ID, Answer: "yes"},
+		{QuestionID: experience.ID, Answer: "3"},
+		{QuestionID: stack.ID, Choices: []string{"PostgreSQL", "MongoDB"}},
+	})
+	assert.NoError(t, err)
+	assert.False(t, knockedOut)
+	assert.Len(t, answers, 3)
+
+	_, knockedOut, err = evaluateScreeningAnswers(questions, []domain.ScreeningAnswerRequest{
+		{QuestionID: workPermit.ID, Answer: "no"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, knockedOut)
+
+	_, knockedOut, err = evaluateScreeningAnswers(questions, []domain.ScreeningAnswerRequest{
+		{QuestionID: workPermit.ID, Answer: "yes"},
+		{QuestionID: experience.ID, Answer: "1"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, knockedOut)
+
+	_, _, err = evaluateScreeningAnswers(questions, []domain.ScreeningAnswerRequest{
+		{QuestionID: experience.ID, Answer: "3"},
+	})
+	assert.Error(t, err)
+
+	_, _, err = evaluateScreeningAnswers(questions, []domain.ScreeningAnswerRequest{
+		{QuestionID: workPermit.ID, Answer: "yes"},
+		{QuestionID: stack.ID, Choices: []string{"Oracle"}},
+	})
+	assert.Error(t, err)
+
+	_, _, err = evaluateScreeningAnswers(questions, []domain.ScreeningAnswerRequest{
+		{QuestionID: workPermit.ID, Answer: "yes"},
+		{QuestionID: uuid.New(), Answer: "anything"},
+	})
+	assert.Error(t, err)
+
+	_, _, err = evaluateScreeningAnswers(questions, []domain.ScreeningAnswerRequest{
+		{QuestionID: workPermit.ID, Answer: "yes"},
+		{QuestionID: stack.ID, Choices: []string{"MySQL", "MySQL"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestRequiredMultiChoiceNeedsChoices(t *testing.T) {
+	certifications := domain.ScreeningQuestion{
+		ID:              uuid.New(),
+		Question:        "Which certifications do you hold?",
+		QuestionType:    "multi_choice",
+		Options:         []string{"CKA", "AWS SAA", "None"},
+		IsRequired:      true,
+		KnockoutAnswers: []string{"None"},
+	}
+	questions := []domain.ScreeningQuestion{certifications}
+
+	_, _, err := evaluateScreeningAnswers(questions, []domain.ScreeningAnswerRequest{
+		{QuestionID: certifications.ID, Answer: "x", Choices: []string{}},
+	})
+	assert.Error(t, err)
+
+	answers, knockedOut, err := evaluateScreeningAnswers(questions, []domain.ScreeningAnswerRequest{
+		{QuestionID: certifications.ID, Answer: "ignored", Choices: []string{"None"}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, knockedOut)
+	assert.Empty(t, answers[0].Answer)
+}
@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"recruitment-system/services/candidate-service/internal/domain"
+	"recruitment-system/shared/events"
+)
+
+// HandleDomainEvent reacts to events published by the other services.
+func (s *CandidateService) HandleDomainEvent(ctx context.Context, msg events.Message) error {
+	switch msg.Type {
+	case events.JobClosed:
+		var payload events.JobClosedPayload
+		if err := msg.Decode(&payload); err != nil {
+			return err
+		}
+		return s.applyClosePolicy(ctx, payload)
+	}
+	return nil
+}
+
+// applyClosePolicy settles the open applications of a closed job. Under the
+// reject policy they move to rejected with the recruiter's message;
+// otherwise they stay as they are and each candidate is told the job closed.
+func (s *CandidateService) applyClosePolicy(ctx context.Context, job events.JobClosedPayload) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		applications, err := s.applicationRepo.ListOpenByJobID(ctx, job.JobID)
+		if err != nil {
+			return err
+		}
+
+		if job.ClosePolicy == events.ClosePolicyReject {
+			candidates := make([]*domain.Candidate, 0, len(applications))
+			for i := range applications {
+				if applications[i].Candidate != nil {
+					candidates = append(candidates, applications[i].Candidate)
+				}
+			}
+			// The rejection message names the candidate, so a failed lookup
+			// fails the event and it is redelivered.
+			if err := s.attachUsers(ctx, candidates...); err != nil {
+				return err
+			}
+		}
+
+		for i := range applications {
+			application := &applications[i]
+			candidate := application.Candidate
+			application.Candidate = nil
+			if candidate == nil {
+				return fmt.Errorf("application %s has no candidate", application.ID)
+			}
+
+			if job.ClosePolicy != events.ClosePolicyReject {
+				err := s.outbox.Add(ctx, events.ApplicationJobClosed, application.ID, events.ApplicationJobClosedPayload{
+					ApplicationID:   application.ID,
+					JobID:           job.JobID,
+					JobTitle:        job.Title,
+					CandidateUserID: candidate.UserID,
+					Status:          application.Status,
+				})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			candidateName := ""
+			if candidate.User != nil {
+				candidateName = candidate.User.Name
+			}
+			message, err := domain.RenderRejectionMessage(job.RejectionMessage, candidateName, job.Title)
+			if err != nil {
+				return err
+			}
+
+			from := application.Status
+			application.Status = domain.ApplicationStatusRejected
+			application.UpdatedAt = time.Now()
+			if err := s.applicationRepo.Update(ctx, application); err != nil {
+				return err
+			}
+
+			err = s.outbox.Add(ctx, events.ApplicationStatusChanged, application.ID, events.ApplicationStatusChangedPayload{
+				ApplicationID:   application.ID,
+				JobID:           job.JobID,
+				JobTitle:        job.Title,
+				CandidateUserID: candidate.UserID,
+				From:            from,
+				To:              application.Status,
+				Message:         message,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
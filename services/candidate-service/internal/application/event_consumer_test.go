@@ -0,0 +1,106 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"recruitment-system/services/candidate-service/internal/domain"
+	"recruitment-system/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type passthroughUnitOfWork struct{}
+
+func (passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeApplicationRepository struct {
+	domain.JobApplicationRepository
+	open    []domain.JobApplication
+	updated []domain.JobApplication
+}
+
+func (r *fakeApplicationRepository) ListOpenByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.JobApplication, error) {
+	return r.open, nil
+}
+
+func (r *fakeApplicationRepository) Update(ctx context.Context, application *domain.JobApplication) error {
+	r.updated = append(r.updated, *application)
+	return nil
+}
+
+type recordedEvent struct {
+	eventType string
+	payload   interface{}
+}
+
+type fakeOutbox struct {
+	added []recordedEvent
+}
+
+func (o *fakeOutbox) Add(ctx context.Context, eventType string, aggregateID uuid.UUID, payload interface{}) error {
+	o.added = append(o.added, recordedEvent{eventType: eventType, payload: payload})
+	return nil
+}
+
+type fakeAuthServiceClient struct {
+	domain.AuthServiceClient
+	users []domain.UserInfo
+}
+
+func (c *fakeAuthServiceClient) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]domain.UserInfo, error) {
+	return c.users, nil
+}
+
+func TestApplyClosePolicy(t *testing.T) {
+	candidate := &domain.Candidate{ID: uuid.New(), UserID: uuid.New()}
+	job := events.JobClosedPayload{
+		JobID:            uuid.New(),
+		Title:            "Go Developer",
+		RejectionMessage: "Obrigado, {{.candidate_name}}.",
+	}
+	newService := func() (*CandidateService, *fakeApplicationRepository, *fakeOutbox) {
+		repo := &fakeApplicationRepository{open: []domain.JobApplication{
+			{ID: uuid.New(), JobID: job.JobID, CandidateID: candidate.ID, Status: "reviewing", Candidate: candidate},
+		}}
+		outbox := &fakeOutbox{}
+		authClient := &fakeAuthServiceClient{users: []domain.UserInfo{{ID: candidate.UserID, Name: "Ana"}}}
+		service := &CandidateService{applicationRepo: repo, uow: passthroughUnitOfWork{}, outbox: outbox, authClient: authClient}
+		return service, repo, outbox
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		service, repo, outbox := newService()
+		job.ClosePolicy = events.ClosePolicyReject
+
+		require.NoError(t, service.applyClosePolicy(context.Background(), job))
+
+		require.Len(t, repo.updated, 1)
+		assert.Equal(t, domain.ApplicationStatusRejected, repo.updated[0].Status)
+		assert.Nil(t, repo.updated[0].Candidate)
+
+		require.Len(t, outbox.added, 1)
+		assert.Equal(t, events.ApplicationStatusChanged, outbox.added[0].eventType)
+		payload := outbox.added[0].payload.(events.ApplicationStatusChangedPayload)
+		assert.Equal(t, "reviewing", payload.From)
+		assert.Equal(t, candidate.UserID, payload.CandidateUserID)
+		assert.Equal(t, "Obrigado, Ana.", payload.Message)
+	})
+
+	t.Run("notify", func(t *testing.T) {
+		service, repo, outbox := newService()
+		job.ClosePolicy = events.ClosePolicyNotify
+
+		require.NoError(t, service.applyClosePolicy(context.Background(), job))
+
+		assert.Empty(t, repo.updated)
+		require.Len(t, outbox.added, 1)
+		assert.Equal(t, events.ApplicationJobClosed, outbox.added[0].eventType)
+		payload := outbox.added[0].payload.(events.ApplicationJobClosedPayload)
+		assert.Equal(t, "reviewing", payload.Status)
+	})
+}
@@ -0,0 +1,20 @@
+package application
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	applicationsSubmitted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "recruitment",
+		Name:      "applications_submitted_total",
+		Help:      "Job applications submitted.",
+	})
+
+	resumesProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "recruitment",
+		Name:      "resumes_processed_total",
+		Help:      "Resumes run through text extraction and parsing, by result.",
+	}, []string{"result"})
+)
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"strings"
+	"text/template"
+)
+
+const (
+	ApplicationStatusApplied   = "applied"
+	ApplicationStatusReviewing = "reviewing"
+	ApplicationStatusInterview = "interview"
+	ApplicationStatusRejected  = "rejected"
+	ApplicationStatusAccepted  = "accepted"
+)
+
+// OpenApplicationStatuses are the statuses of applications still in the
+// hiring process, the ones a job close policy applies to.
+var OpenApplicationStatuses = []string{
+	ApplicationStatusApplied,
+	ApplicationStatusReviewing,
+	ApplicationStatusInterview,
+}
+
+// RenderRejectionMessage renders the recruiter's rejection message for one
+// application. The template sees .candidate_name and .job_title.
+func RenderRejectionMessage(message, candidateName, jobTitle string) (string, error) {
+	if message == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New("rejection").Option("missingkey=zero").Parse(message)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	err = tmpl.Execute(&out, map[string]string{
+		"candidate_name": candidateName,
+		"job_title":      jobTitle,
+	})
+	return out.String(), err
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderRejectionMessage(t *testing.T) {
+	message, err := RenderRejectionMessage("Olá {{.candidate_name}}, a vaga {{.job_title}} foi encerrada.{{.unknown}}", "Ana", "Go Developer")
+	assert.NoError(t, err)
+	assert.Equal(t, "Olá Ana, a vaga Go Developer foi encerrada.", message)
+
+	message, err = RenderRejectionMessage("", "Ana", "Go Developer")
+	assert.NoError(t, err)
+	assert.Empty(t, message)
+
+	_, err = RenderRejectionMessage("{{.candidate_name", "Ana", "Go Developer")
+	assert.Error(t, err)
+}
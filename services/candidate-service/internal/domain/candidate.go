@@ -7,28 +7,21 @@ import (
 )
 
 type Candidate struct {
-	ID              uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	UserID          uuid.UUID         `json:"user_id" gorm:"type:uuid;uniqueIndex;not null"`
-	Phone           string            `json:"phone"`
-	Address         string            `json:"address"`
-	DateOfBirth     *time.Time        `json:"date_of_birth" gorm:"type:date"`
-	LinkedinURL     string            `json:"linkedin_url"`
-	GithubURL       string            `json:"github_url"`
-	CreatedAt       time.Time         `json:"created_at"`
-	UpdatedAt       time.Time         `json:"updated_at"`
-	User            *User             `json:"user,omitempty" gorm:"foreignKey:UserID"`
-	Skills          []CandidateSkill  `json:"skills,omitempty" gorm:"foreignKey:CandidateID"`
-	WorkExperiences []WorkExperience  `json:"work_experiences,omitempty" gorm:"foreignKey:CandidateID"`
-	Education       []Education       `json:"education,omitempty" gorm:"foreignKey:CandidateID"`
-	Resumes         []Resume          `json:"resumes,omitempty" gorm:"foreignKey:CandidateID"`
-	Applications    []JobApplication  `json:"applications,omitempty" gorm:"foreignKey:CandidateID"`
-}
-
-type User struct {
-	ID    uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
-	Email string    `json:"email"`
-	Name  string    `json:"name"`
-	Role  string    `json:"role"`
+	ID              uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID          uuid.UUID        `json:"user_id" gorm:"type:uuid;uniqueIndex;not null"`
+	Phone           string           `json:"phone"`
+	Address         string           `json:"address"`
+	DateOfBirth     *time.Time       `json:"date_of_birth" gorm:"type:date"`
+	LinkedinURL     string           `json:"linkedin_url"`
+	GithubURL       string           `json:"github_url"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	User            *UserInfo        `json:"user,omitempty" gorm:"-"`
+	Skills          []CandidateSkill `json:"skills,omitempty" gorm:"foreignKey:CandidateID"`
+	WorkExperiences []WorkExperience `json:"work_experiences,omitempty" gorm:"foreignKey:CandidateID"`
+	Education       []Education      `json:"education,omitempty" gorm:"foreignKey:CandidateID"`
+	Resumes         []Resume         `json:"resumes,omitempty" gorm:"foreignKey:CandidateID"`
+	Applications    []JobApplication `json:"applications,omitempty" gorm:"foreignKey:CandidateID"`
 }
 
 type CandidateSkill struct {
@@ -61,17 +54,17 @@ type WorkExperience struct {
 }
 
 type Education struct {
-	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	CandidateID   uuid.UUID  `json:"candidate_id" gorm:"type:uuid;not null"`
-	Institution   string     `json:"institution" gorm:"not null"`
-	Degree        string     `json:"degree" gorm:"not null"`
-	FieldOfStudy  string     `json:"field_of_study"`
-	StartDate     time.Time  `json:"start_date" gorm:"type:date;not null"`
-	EndDate       *time.Time `json:"end_date" gorm:"type:date"`
-	IsCurrent     bool       `json:"is_current" gorm:"default:false"`
-	GPA           *float64   `json:"gpa" gorm:"type:decimal(3,2)"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CandidateID  uuid.UUID  `json:"candidate_id" gorm:"type:uuid;not null"`
+	Institution  string     `json:"institution" gorm:"not null"`
+	Degree       string     `json:"degree" gorm:"not null"`
+	FieldOfStudy string     `json:"field_of_study"`
+	StartDate    time.Time  `json:"start_date" gorm:"type:date;not null"`
+	EndDate      *time.Time `json:"end_date" gorm:"type:date"`
+	IsCurrent    bool       `json:"is_current" gorm:"default:false"`
+	GPA          *float64   `json:"gpa" gorm:"type:decimal(3,2)"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 }
 
 type Resume struct {
@@ -88,14 +81,17 @@ type Resume struct {
 }
 
 type JobApplication struct {
-	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	JobID       uuid.UUID `json:"job_id" gorm:"type:uuid;not null"`
-	CandidateID uuid.UUID `json:"candidate_id" gorm:"type:uuid;not null"`
-	Status      string    `json:"status" gorm:"not null;default:'applied'"`
-	CoverLetter string    `json:"cover_letter" gorm:"type:text"`
-	AppliedAt   time.Time `json:"applied_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	Job         *Job      `json:"job,omitempty" gorm:"foreignKey:JobID"`
+	ID          uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	JobID       uuid.UUID         `json:"job_id" gorm:"type:uuid;not null"`
+	CandidateID uuid.UUID         `json:"candidate_id" gorm:"type:uuid;not null"`
+	Status      string            `json:"status" gorm:"not null;default:'applied'"`
+	CoverLetter string            `json:"cover_letter" gorm:"type:text"`
+	KnockedOut  bool              `json:"knocked_out" gorm:"default:false"`
+	AppliedAt   time.Time         `json:"applied_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Job         *Job              `json:"job,omitempty" gorm:"foreignKey:JobID"`
+	Candidate   *Candidate        `json:"candidate,omitempty" gorm:"foreignKey:CandidateID"`
+	Answers     []ScreeningAnswer `json:"answers,omitempty" gorm:"foreignKey:ApplicationID"`
 }
 
 type Job struct {
@@ -110,10 +106,6 @@ func (c *Candidate) TableName() string {
 	return "candidates"
 }
 
-func (u *User) TableName() string {
-	return "users"
-}
-
 func (cs *CandidateSkill) TableName() string {
 	return "candidate_skills"
 }
@@ -184,24 +176,25 @@ type AddEducationRequest struct {
 }
 
 type CreateJobApplicationRequest struct {
-	JobID       uuid.UUID `json:"job_id" binding:"required"`
-	CoverLetter string    `json:"cover_letter"`
+	JobID       uuid.UUID                `json:"job_id" binding:"required"`
+	CoverLetter string                   `json:"cover_letter"`
+	Answers     []ScreeningAnswerRequest `json:"answers" binding:"dive"`
 }
 
 type CandidateResponse struct {
-	ID              uuid.UUID                   `json:"id"`
-	User            UserResponse                `json:"user"`
-	Phone           string                      `json:"phone"`
-	Address         string                      `json:"address"`
-	DateOfBirth     *time.Time                  `json:"date_of_birth"`
-	LinkedinURL     string                      `json:"linkedin_url"`
-	GithubURL       string                      `json:"github_url"`
-	Skills          []CandidateSkillResponse    `json:"skills,omitempty"`
-	WorkExperiences []WorkExperienceResponse    `json:"work_experiences,omitempty"`
-	Education       []EducationResponse         `json:"education,omitempty"`
-	Resumes         []ResumeResponse            `json:"resumes,omitempty"`
-	Applications    []JobApplicationResponse    `json:"applications,omitempty"`
-	CreatedAt       time.Time                   `json:"created_at"`
+	ID              uuid.UUID                `json:"id"`
+	User            UserResponse             `json:"user"`
+	Phone           string                   `json:"phone"`
+	Address         string                   `json:"address"`
+	DateOfBirth     *time.Time               `json:"date_of_birth"`
+	LinkedinURL     string                   `json:"linkedin_url"`
+	GithubURL       string                   `json:"github_url"`
+	Skills          []CandidateSkillResponse `json:"skills,omitempty"`
+	WorkExperiences []WorkExperienceResponse `json:"work_experiences,omitempty"`
+	Education       []EducationResponse      `json:"education,omitempty"`
+	Resumes         []ResumeResponse         `json:"resumes,omitempty"`
+	Applications    []JobApplicationResponse `json:"applications,omitempty"`
+	CreatedAt       time.Time                `json:"created_at"`
 }
 
 type UserResponse struct {
@@ -255,11 +248,16 @@ type ResumeResponse struct {
 }
 
 type JobApplicationResponse struct {
-	ID          uuid.UUID   `json:"id"`
-	Job         JobResponse `json:"job"`
-	Status      string      `json:"status"`
-	CoverLetter string      `json:"cover_letter"`
-	AppliedAt   time.Time   `json:"applied_at"`
+	ID          uuid.UUID                 `json:"id"`
+	JobID       uuid.UUID                 `json:"job_id"`
+	CandidateID uuid.UUID                 `json:"candidate_id"`
+	Job         JobResponse               `json:"job"`
+	Status      string                    `json:"status"`
+	CoverLetter string                    `json:"cover_letter"`
+	KnockedOut  bool                      `json:"knocked_out"`
+	Applicant   *UserResponse             `json:"applicant,omitempty"`
+	Answers     []ScreeningAnswerResponse `json:"answers,omitempty"`
+	AppliedAt   time.Time                 `json:"applied_at"`
 }
 
 type JobResponse struct {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"mime/multipart"
 
+	"recruitment-system/shared/utils"
+
 	"github.com/google/uuid"
 )
 
@@ -52,17 +54,29 @@ type ResumeRepository interface {
 type JobApplicationRepository interface {
 	Create(ctx context.Context, application *JobApplication) error
 	GetByCandidateID(ctx context.Context, candidateID uuid.UUID) ([]JobApplication, error)
-	GetByJobID(ctx context.Context, jobID uuid.UUID) ([]JobApplication, error)
+	ListByCandidateID(ctx context.Context, candidateID uuid.UUID, page utils.PageRequest) ([]JobApplication, int64, error)
+	ListByJobID(ctx context.Context, jobID uuid.UUID, page utils.PageRequest) ([]JobApplication, int64, error)
+	// ListOpenByJobID locks the job's open applications for the rest of
+	// the unit of work.
+	ListOpenByJobID(ctx context.Context, jobID uuid.UUID) ([]JobApplication, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*JobApplication, error)
 	Update(ctx context.Context, application *JobApplication) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	ExistsByCandidateAndJob(ctx context.Context, candidateID, jobID uuid.UUID) (bool, error)
+	CountByJobID(ctx context.Context, jobID uuid.UUID) (int64, error)
+	// LockJob serializes applications to the job until the surrounding
+	// transaction ends.
+	LockJob(ctx context.Context, jobID uuid.UUID) error
+}
+
+type ScreeningAnswerRepository interface {
+	CreateBatch(ctx context.Context, answers []ScreeningAnswer) error
 }
 
 type SkillRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*Skill, error)
 	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]Skill, error)
-	List(ctx context.Context, category, search string, offset, limit int) ([]*Skill, int64, error)
+	List(ctx context.Context, category, search string, page utils.PageRequest) ([]*Skill, int64, error)
 }
 
 type FileStorageService interface {
@@ -97,9 +111,17 @@ type ProcessedResumeData struct {
 	} `json:"education"`
 }
 
+// EventOutbox records domain events in the caller's unit of work; a relay
+// publishes them once the transaction commits.
+type EventOutbox interface {
+	Add(ctx context.Context, eventType string, aggregateID uuid.UUID, payload interface{}) error
+}
+
 type AuthServiceClient interface {
 	ValidateToken(ctx context.Context, token string) (*UserInfo, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*UserInfo, error)
+	// GetUsersByIDs returns the users found; unknown IDs are left out.
+	GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]UserInfo, error)
 }
 
 type JobServiceClient interface {
@@ -115,9 +137,13 @@ type UserInfo struct {
 }
 
 type JobInfo struct {
-	ID          uuid.UUID `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Location    string    `json:"location"`
-	Status      string    `json:"status"`
+	ID              uuid.UUID `json:"id"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	Location        string    `json:"location"`
+	Status          string    `json:"status"`
+	MaxApplications *int      `json:"max_applications"`
+	CreatedBy       uuid.UUID `json:"created_by"`
+	// ScreeningQuestions are owned by job-service and come with the job.
+	ScreeningQuestions []ScreeningQuestion `json:"screening_questions"`
 }
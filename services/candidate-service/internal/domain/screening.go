@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScreeningQuestion is a question job-service attached to a job. It is read
+// through job-service's API, never from its tables.
+type ScreeningQuestion struct {
+	ID              uuid.UUID `json:"id"`
+	JobID           uuid.UUID `json:"job_id"`
+	Question        string    `json:"question"`
+	QuestionType    string    `json:"question_type"`
+	Options         []string  `json:"options"`
+	IsRequired      bool      `json:"is_required"`
+	KnockoutAnswers []string  `json:"knockout_answers"`
+	MinValue        *float64  `json:"min_value"`
+	MaxValue        *float64  `json:"max_value"`
+	Position        int       `json:"position"`
+}
+
+type ScreeningAnswer struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ApplicationID uuid.UUID `json:"application_id" gorm:"type:uuid;not null"`
+	QuestionID    uuid.UUID `json:"question_id" gorm:"type:uuid;not null"`
+	Question      string    `json:"question" gorm:"type:text;not null"`
+	QuestionType  string    `json:"question_type" gorm:"not null"`
+	Answer        string    `json:"answer" gorm:"type:text"`
+	Choices       []string  `json:"choices" gorm:"type:jsonb;serializer:json"`
+	IsKnockout    bool      `json:"is_knockout" gorm:"default:false"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (a *ScreeningAnswer) TableName() string {
+	return "application_screening_answers"
+}
+
+// IsAnswered reports whether the candidate answered the question: multi_choice
+// questions by selecting at least one choice, every other type with a
+// non-empty answer.
+func (q *ScreeningQuestion) IsAnswered(answer string, choices []string) bool {
+	if q.QuestionType == "multi_choice" {
+		return len(choices) > 0
+	}
+	return answer != ""
+}
+
+// Evaluate checks an answer against the question definition and reports
+// whether it triggers a knockout. Choices is only used by multi_choice
+// questions; every other type reads Answer.
+func (q *ScreeningQuestion) Evaluate(answer string, choices []string) (bool, error) {
+	switch q.QuestionType {
+	case "text":
+		return false, nil
+	case "yes_no":
+		if answer != "yes" && answer != "no" {
+			return false, fmt.Errorf("%q must be answered with yes or no", q.Question)
+		}
+		return q.isKnockoutAnswer(answer), nil
+	case "single_choice":
+		if !q.hasOption(answer) {
+			return false, fmt.Errorf("%q has no option %q", q.Question, answer)
+		}
+		return q.isKnockoutAnswer(answer), nil
+	case "multi_choice":
+		knockout := false
+		seen := make(map[string]bool, len(choices))
+		for _, choice := range choices {
+			if !q.hasOption(choice) {
+				return false, fmt.Errorf("%q has no option %q", q.Question, choice)
+			}
+			if seen[choice] {
+				return false, fmt.Errorf("%q has option %q selected more than once", q.Question, choice)
+			}
+			seen[choice] = true
+			if q.isKnockoutAnswer(choice) {
+				knockout = true
+			}
+		}
+		return knockout, nil
+	case "numeric":
+		value, err := strconv.ParseFloat(strings.TrimSpace(answer), 64)
+		if err != nil {
+			return false, fmt.Errorf("%q must be answered with a number", q.Question)
+		}
+		if q.MinValue != nil && value < *q.MinValue {
+			return true, nil
+		}
+		if q.MaxValue != nil && value > *q.MaxValue {
+			return true, nil
+		}
+		return false, nil
+	default:
+		return false, errors.New("unsupported screening question type")
+	}
+}
+
+func (q *ScreeningQuestion) hasOption(value string) bool {
+	for _, option := range q.Options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *ScreeningQuestion) isKnockoutAnswer(value string) bool {
+	for _, knockout := range q.KnockoutAnswers {
+		if knockout == value {
+			return true
+		}
+	}
+	return false
+}
+
+type ScreeningAnswerRequest struct {
+	QuestionID uuid.UUID `json:"question_id" binding:"required"`
+	Answer     string    `json:"answer"`
+	Choices    []string  `json:"choices"`
+}
+
+type ScreeningAnswerResponse struct {
+	QuestionID   uuid.UUID `json:"question_id"`
+	Question     string    `json:"question"`
+	QuestionType string    `json:"question_type"`
+	Answer       string    `json:"answer,omitempty"`
+	Choices      []string  `json:"choices,omitempty"`
+	IsKnockout   bool      `json:"is_knockout"`
+}
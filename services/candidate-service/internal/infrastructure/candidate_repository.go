@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"recruitment-system/services/candidate-service/internal/domain"
+	"recruitment-system/shared/database"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -18,13 +19,12 @@ func NewCandidateRepository(db *gorm.DB) domain.CandidateRepository {
 }
 
 func (r *CandidateRepositoryImpl) Create(ctx context.Context, candidate *domain.Candidate) error {
-	return r.db.WithContext(ctx).Create(candidate).Error
+	return database.DB(ctx, r.db).Create(candidate).Error
 }
 
 func (r *CandidateRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Candidate, error) {
 	var candidate domain.Candidate
-	err := r.db.WithContext(ctx).
-		Preload("User").
+	err := database.DB(ctx, r.db).
 		Where("id = ?", id).
 		First(&candidate).Error
 	if err != nil {
@@ -35,8 +35,7 @@ func (r *CandidateRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*d
 
 func (r *CandidateRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Candidate, error) {
 	var candidate domain.Candidate
-	err := r.db.WithContext(ctx).
-		Preload("User").
+	err := database.DB(ctx, r.db).
 		Where("user_id = ?", userID).
 		First(&candidate).Error
 	if err != nil {
@@ -46,23 +45,22 @@ func (r *CandidateRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.U
 }
 
 func (r *CandidateRepositoryImpl) Update(ctx context.Context, candidate *domain.Candidate) error {
-	return r.db.WithContext(ctx).Save(candidate).Error
+	return database.DB(ctx, r.db).Save(candidate).Error
 }
 
 func (r *CandidateRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.Candidate{}, id).Error
+	return database.DB(ctx, r.db).Delete(&domain.Candidate{}, id).Error
 }
 
 func (r *CandidateRepositoryImpl) List(ctx context.Context, offset, limit int) ([]*domain.Candidate, int64, error) {
 	var candidates []*domain.Candidate
 	var total int64
 
-	if err := r.db.WithContext(ctx).Model(&domain.Candidate{}).Count(&total).Error; err != nil {
+	if err := database.DB(ctx, r.db).Model(&domain.Candidate{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := r.db.WithContext(ctx).
-		Preload("User").
+	err := database.DB(ctx, r.db).
 		Offset(offset).
 		Limit(limit).
 		Find(&candidates).Error
@@ -72,7 +70,7 @@ func (r *CandidateRepositoryImpl) List(ctx context.Context, offset, limit int) (
 
 func (r *CandidateRepositoryImpl) ExistsByUserID(ctx context.Context, userID uuid.UUID) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&domain.Candidate{}).Where("user_id = ?", userID).Count(&count).Error
+	err := database.DB(ctx, r.db).Model(&domain.Candidate{}).Where("user_id = ?", userID).Count(&count).Error
 	return count > 0, err
 }
 
@@ -85,12 +83,12 @@ func NewCandidateSkillRepository(db *gorm.DB) domain.CandidateSkillRepository {
 }
 
 func (r *CandidateSkillRepositoryImpl) Create(ctx context.Context, candidateSkill *domain.CandidateSkill) error {
-	return r.db.WithContext(ctx).Create(candidateSkill).Error
+	return database.DB(ctx, r.db).Create(candidateSkill).Error
 }
 
 func (r *CandidateSkillRepositoryImpl) GetByCandidateID(ctx context.Context, candidateID uuid.UUID) ([]domain.CandidateSkill, error) {
 	var candidateSkills []domain.CandidateSkill
-	err := r.db.WithContext(ctx).
+	err := database.DB(ctx, r.db).
 		Preload("Skill").
 		Where("candidate_id = ?", candidateID).
 		Find(&candidateSkills).Error
@@ -98,15 +96,15 @@ func (r *CandidateSkillRepositoryImpl) GetByCandidateID(ctx context.Context, can
 }
 
 func (r *CandidateSkillRepositoryImpl) Update(ctx context.Context, candidateSkill *domain.CandidateSkill) error {
-	return r.db.WithContext(ctx).Save(candidateSkill).Error
+	return database.DB(ctx, r.db).Save(candidateSkill).Error
 }
 
 func (r *CandidateSkillRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.CandidateSkill{}, id).Error
+	return database.DB(ctx, r.db).Delete(&domain.CandidateSkill{}, id).Error
 }
 
 func (r *CandidateSkillRepositoryImpl) DeleteByCandidateIDAndSkillID(ctx context.Context, candidateID, skillID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return database.DB(ctx, r.db).
 		Where("candidate_id = ? AND skill_id = ?", candidateID, skillID).
 		Delete(&domain.CandidateSkill{}).Error
 }
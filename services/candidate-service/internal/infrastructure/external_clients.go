@@ -2,156 +2,173 @@ package infrastructure
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
-	"net/http"
 	"os"
 	"path/filepath"
 	"time"
 
 	"recruitment-system/services/candidate-service/internal/domain"
+	"recruitment-system/shared/httpclient"
+	"recruitment-system/shared/pb/authv1"
+	"recruitment-system/shared/pb/jobv1"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc"
 )
 
 type AuthServiceClientImpl struct {
-	baseURL    string
-	httpClient *http.Client
+	client authv1.AuthServiceClient
 }
 
-func NewAuthServiceClient(baseURL string) domain.AuthServiceClient {
+func NewAuthServiceClient(conn grpc.ClientConnInterface) domain.AuthServiceClient {
 	return &AuthServiceClientImpl{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		client: authv1.NewAuthServiceClient(conn),
 	}
 }
 
 func (c *AuthServiceClientImpl) ValidateToken(ctx context.Context, token string) (*domain.UserInfo, error) {
-	url := fmt.Sprintf("%s/api/v1/auth/validate", c.baseURL)
-
-	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	response, err := c.client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: token})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("token validation failed: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
+	user, err := toUserInfo(response.User)
+	if err != nil {
+		return nil, err
+	}
+	user.Name = user.Email
+	return user, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+func (c *AuthServiceClientImpl) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.UserInfo, error) {
+	users, err := c.GetUsersByIDs(ctx, []uuid.UUID{userID})
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var response struct {
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-		Data    struct {
-			UserID string `json:"user_id"`
-			Email  string `json:"email"`
-			Role   string `json:"role"`
-		} `json:"data"`
-		Error string `json:"error,omitempty"`
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %s: %w", userID, httpclient.ErrNotFound)
 	}
+	return &users[0], nil
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// maxUsersPerLookup is the most IDs auth-service accepts in one lookup.
+const maxUsersPerLookup = 100
+
+func (c *AuthServiceClientImpl) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]domain.UserInfo, error) {
+	var users []domain.UserInfo
+	for start := 0; start < len(userIDs); start += maxUsersPerLookup {
+		end := start + maxUsersPerLookup
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+
+		ids := make([]string, 0, end-start)
+		for _, id := range userIDs[start:end] {
+			ids = append(ids, id.String())
+		}
+
+		response, err := c.client.GetUsers(ctx, &authv1.GetUsersRequest{Ids: ids})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get users: %w", err)
+		}
+		for _, user := range response.Users {
+			info, err := toUserInfo(user)
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, *info)
+		}
 	}
 
-	if !response.Success {
-		return nil, fmt.Errorf("token validation failed: %s", response.Error)
-	}
+	return users, nil
+}
 
-	userID, err := uuid.Parse(response.Data.UserID)
+func toUserInfo(user *authv1.User) (*domain.UserInfo, error) {
+	userID, err := uuid.Parse(user.GetId())
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID format: %w", err)
 	}
 
 	return &domain.UserInfo{
 		ID:    userID,
-		Email: response.Data.Email,
-		Role:  response.Data.Role,
-		Name:  response.Data.Email,
+		Email: user.GetEmail(),
+		Role:  user.GetRole(),
+		Name:  user.GetName(),
 	}, nil
 }
 
-func (c *AuthServiceClientImpl) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.UserInfo, error) {
-	return nil, fmt.Errorf("not implemented")
-}
-
 type JobServiceClientImpl struct {
-	baseURL    string
-	httpClient *http.Client
+	client jobv1.JobServiceClient
 }
 
-func NewJobServiceClient(baseURL string) domain.JobServiceClient {
+func NewJobServiceClient(conn grpc.ClientConnInterface) domain.JobServiceClient {
 	return &JobServiceClientImpl{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		client: jobv1.NewJobServiceClient(conn),
 	}
 }
 
 func (c *JobServiceClientImpl) GetJobByID(ctx context.Context, jobID uuid.UUID) (*domain.JobInfo, error) {
-	url := fmt.Sprintf("%s/api/v1/jobs/%s", c.baseURL, jobID.String())
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	job, err := c.client.GetJob(ctx, &jobv1.GetJobRequest{Id: jobID.String()})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to get job: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	jobUUID, err := uuid.Parse(job.Id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var response struct {
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-		Data    struct {
-			ID          string `json:"id"`
-			Title       string `json:"title"`
-			Description string `json:"description"`
-			Location    string `json:"location"`
-			Status      string `json:"status"`
-		} `json:"data"`
-		Error string `json:"error,omitempty"`
+		return nil, fmt.Errorf("invalid job ID format: %w", err)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	createdBy, err := uuid.Parse(job.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid creator ID format: %w", err)
 	}
 
-	if !response.Success {
-		return nil, fmt.Errorf("failed to get job: %s", response.Error)
+	var maxApplications *int
+	if job.MaxApplications != nil {
+		value := int(*job.MaxApplications)
+		maxApplications = &value
 	}
 
-	jobUUID, err := uuid.Parse(response.Data.ID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid job ID format: %w", err)
+	questions := make([]domain.ScreeningQuestion, len(job.ScreeningQuestions))
+	for i, question := range job.ScreeningQuestions {
+		questionID, err := uuid.Parse(question.Id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid screening question ID format: %w", err)
+		}
+		questions[i] = domain.ScreeningQuestion{
+			ID:              questionID,
+			JobID:           jobUUID,
+			Question:        question.Question,
+			QuestionType:    question.QuestionType,
+			Options:         question.Options,
+			IsRequired:      question.IsRequired,
+			KnockoutAnswers: question.KnockoutAnswers,
+			MinValue:        question.MinValue,
+			MaxValue:        question.MaxValue,
+			Position:        int(question.Position),
+		}
 	}
 
 	return &domain.JobInfo{
-		ID:          jobUUID,
-		Title:       response.Data.Title,
-		Description: response.Data.Description,
-		Location:    response.Data.Location,
-		Status:      response.Data.Status,
+		ID:                 jobUUID,
+		Title:              job.Title,
+		Description:        job.Description,
+		Location:           job.Location,
+		Status:             job.Status,
+		MaxApplications:    maxApplications,
+		CreatedBy:          createdBy,
+		ScreeningQuestions: questions,
 	}, nil
 }
 
 func (c *JobServiceClientImpl) IsJobOpen(ctx context.Context, jobID uuid.UUID) (bool, error) {
-	job, err := c.GetJobByID(ctx, jobID)
+	response, err := c.client.IsJobOpen(ctx, &jobv1.IsJobOpenRequest{Id: jobID.String()})
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("failed to check job status: %w", err)
 	}
-	return job.Status == "open", nil
+	return response.Open, nil
 }
 
 type FileStorageServiceImpl struct {
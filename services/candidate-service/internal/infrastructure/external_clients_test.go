@@ -0,0 +1,73 @@
+package infrastructure
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"recruitment-system/shared/pb/jobv1"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeJobServer struct {
+	jobv1.UnimplementedJobServiceServer
+	job *jobv1.Job
+}
+
+func (s *fakeJobServer) GetJob(ctx context.Context, req *jobv1.GetJobRequest) (*jobv1.Job, error) {
+	return s.job, nil
+}
+
+func TestJobServiceClientReadsScreeningQuestionsFromTheJob(t *testing.T) {
+	jobID, questionID := uuid.New(), uuid.New()
+	minYears := 2.0
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	jobv1.RegisterJobServiceServer(server, &fakeJobServer{job: &jobv1.Job{
+		Id:        jobID.String(),
+		Title:     "Backend Engineer",
+		Status:    "open",
+		CreatedBy: uuid.NewString(),
+		ScreeningQuestions: []*jobv1.ScreeningQuestion{{
+			Id:              questionID.String(),
+			Question:        "Years of Go experience?",
+			QuestionType:    "numeric",
+			IsRequired:      true,
+			KnockoutAnswers: []string{},
+			MinValue:        &minYears,
+			Position:        1,
+		}},
+	}})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	job, err := NewJobServiceClient(conn).GetJobByID(context.Background(), jobID)
+	require.NoError(t, err)
+
+	require.Len(t, job.ScreeningQuestions, 1)
+	question := job.ScreeningQuestions[0]
+	assert.Equal(t, questionID, question.ID)
+	assert.Equal(t, jobID, question.JobID)
+	assert.Equal(t, "numeric", question.QuestionType)
+	assert.True(t, question.IsRequired)
+	require.NotNil(t, question.MinValue)
+	assert.Equal(t, minYears, *question.MinValue)
+	assert.Nil(t, question.MaxValue)
+	assert.Equal(t, 1, question.Position)
+}
@@ -5,9 +5,12 @@ import (
 	"strings"
 
 	"recruitment-system/services/candidate-service/internal/domain"
+	"recruitment-system/shared/database"
+	"recruitment-system/shared/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WorkExperienceRepositoryImpl struct {
@@ -19,12 +22,12 @@ func NewWorkExperienceRepository(db *gorm.DB) domain.WorkExperienceRepository {
 }
 
 func (r *WorkExperienceRepositoryImpl) Create(ctx context.Context, workExperience *domain.WorkExperience) error {
-	return r.db.WithContext(ctx).Create(workExperience).Error
+	return database.DB(ctx, r.db).Create(workExperience).Error
 }
 
 func (r *WorkExperienceRepositoryImpl) GetByCandidateID(ctx context.Context, candidateID uuid.UUID) ([]domain.WorkExperience, error) {
 	var workExperiences []domain.WorkExperience
-	err := r.db.WithContext(ctx).
+	err := database.DB(ctx, r.db).
 		Where("candidate_id = ?", candidateID).
 		Order("start_date DESC").
 		Find(&workExperiences).Error
@@ -33,7 +36,7 @@ func (r *WorkExperienceRepositoryImpl) GetByCandidateID(ctx context.Context, can
 
 func (r *WorkExperienceRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.WorkExperience, error) {
 	var workExperience domain.WorkExperience
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&workExperience).Error
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&workExperience).Error
 	if err != nil {
 		return nil, err
 	}
@@ -41,11 +44,11 @@ func (r *WorkExperienceRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID
 }
 
 func (r *WorkExperienceRepositoryImpl) Update(ctx context.Context, workExperience *domain.WorkExperience) error {
-	return r.db.WithContext(ctx).Save(workExperience).Error
+	return database.DB(ctx, r.db).Save(workExperience).Error
 }
 
 func (r *WorkExperienceRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.WorkExperience{}, id).Error
+	return database.DB(ctx, r.db).Delete(&domain.WorkExperience{}, id).Error
 }
 
 type EducationRepositoryImpl struct {
@@ -57,12 +60,12 @@ func NewEducationRepository(db *gorm.DB) domain.EducationRepository {
 }
 
 func (r *EducationRepositoryImpl) Create(ctx context.Context, education *domain.Education) error {
-	return r.db.WithContext(ctx).Create(education).Error
+	return database.DB(ctx, r.db).Create(education).Error
 }
 
 func (r *EducationRepositoryImpl) GetByCandidateID(ctx context.Context, candidateID uuid.UUID) ([]domain.Education, error) {
 	var education []domain.Education
-	err := r.db.WithContext(ctx).
+	err := database.DB(ctx, r.db).
 		Where("candidate_id = ?", candidateID).
 		Order("start_date DESC").
 		Find(&education).Error
@@ -71,7 +74,7 @@ func (r *EducationRepositoryImpl) GetByCandidateID(ctx context.Context, candidat
 
 func (r *EducationRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Education, error) {
 	var education domain.Education
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&education).Error
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&education).Error
 	if err != nil {
 		return nil, err
 	}
@@ -79,11 +82,11 @@ func (r *EducationRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*d
 }
 
 func (r *EducationRepositoryImpl) Update(ctx context.Context, education *domain.Education) error {
-	return r.db.WithContext(ctx).Save(education).Error
+	return database.DB(ctx, r.db).Save(education).Error
 }
 
 func (r *EducationRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.Education{}, id).Error
+	return database.DB(ctx, r.db).Delete(&domain.Education{}, id).Error
 }
 
 type ResumeRepositoryImpl struct {
@@ -95,12 +98,12 @@ func NewResumeRepository(db *gorm.DB) domain.ResumeRepository {
 }
 
 func (r *ResumeRepositoryImpl) Create(ctx context.Context, resume *domain.Resume) error {
-	return r.db.WithContext(ctx).Create(resume).Error
+	return database.DB(ctx, r.db).Create(resume).Error
 }
 
 func (r *ResumeRepositoryImpl) GetByCandidateID(ctx context.Context, candidateID uuid.UUID) ([]domain.Resume, error) {
 	var resumes []domain.Resume
-	err := r.db.WithContext(ctx).
+	err := database.DB(ctx, r.db).
 		Where("candidate_id = ?", candidateID).
 		Order("created_at DESC").
 		Find(&resumes).Error
@@ -109,7 +112,7 @@ func (r *ResumeRepositoryImpl) GetByCandidateID(ctx context.Context, candidateID
 
 func (r *ResumeRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Resume, error) {
 	var resume domain.Resume
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&resume).Error
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&resume).Error
 	if err != nil {
 		return nil, err
 	}
@@ -117,11 +120,11 @@ func (r *ResumeRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*doma
 }
 
 func (r *ResumeRepositoryImpl) Update(ctx context.Context, resume *domain.Resume) error {
-	return r.db.WithContext(ctx).Save(resume).Error
+	return database.DB(ctx, r.db).Save(resume).Error
 }
 
 func (r *ResumeRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.Resume{}, id).Error
+	return database.DB(ctx, r.db).Delete(&domain.Resume{}, id).Error
 }
 
 type JobApplicationRepositoryImpl struct {
@@ -133,12 +136,12 @@ func NewJobApplicationRepository(db *gorm.DB) domain.JobApplicationRepository {
 }
 
 func (r *JobApplicationRepositoryImpl) Create(ctx context.Context, application *domain.JobApplication) error {
-	return r.db.WithContext(ctx).Create(application).Error
+	return database.DB(ctx, r.db).Create(application).Error
 }
 
 func (r *JobApplicationRepositoryImpl) GetByCandidateID(ctx context.Context, candidateID uuid.UUID) ([]domain.JobApplication, error) {
 	var applications []domain.JobApplication
-	err := r.db.WithContext(ctx).
+	err := database.DB(ctx, r.db).
 		Preload("Job").
 		Where("candidate_id = ?", candidateID).
 		Order("applied_at DESC").
@@ -146,19 +149,50 @@ func (r *JobApplicationRepositoryImpl) GetByCandidateID(ctx context.Context, can
 	return applications, err
 }
 
-func (r *JobApplicationRepositoryImpl) GetByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.JobApplication, error) {
+func (r *JobApplicationRepositoryImpl) ListByCandidateID(ctx context.Context, candidateID uuid.UUID, page utils.PageRequest) ([]domain.JobApplication, int64, error) {
+	query := database.DB(ctx, r.db).Model(&domain.JobApplication{}).Where("candidate_id = ?", candidateID)
+	return r.list(query.Preload("Job"), page)
+}
+
+func (r *JobApplicationRepositoryImpl) ListByJobID(ctx context.Context, jobID uuid.UUID, page utils.PageRequest) ([]domain.JobApplication, int64, error) {
+	query := database.DB(ctx, r.db).Model(&domain.JobApplication{}).Where("job_id = ?", jobID)
+	return r.list(query.Preload("Answers").Preload("Candidate"), page)
+}
+
+// ListOpenByJobID returns the applications still in the hiring process,
+// with their candidate loaded, and locks them until the surrounding
+// transaction ends so a concurrent status change is not overwritten.
+func (r *JobApplicationRepositoryImpl) ListOpenByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.JobApplication, error) {
 	var applications []domain.JobApplication
-	err := r.db.WithContext(ctx).
-		Where("job_id = ?", jobID).
-		Order("applied_at DESC").
+	err := database.DB(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Candidate").
+		Where("job_id = ? AND status IN ?", jobID, domain.OpenApplicationStatuses).
+		Order("applied_at ASC").
 		Find(&applications).Error
 	return applications, err
 }
 
+func (r *JobApplicationRepositoryImpl) list(query *gorm.DB, page utils.PageRequest) ([]domain.JobApplication, int64, error) {
+	var applications []domain.JobApplication
+	var total int64
+
+	if !page.Keyset {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	page = page.WithDefaultSort(utils.SortField{Column: "job_applications.applied_at", Desc: true})
+	err := query.Scopes(database.Paginate(page, "job_applications.applied_at", "job_applications.id")).Find(&applications).Error
+	return applications, total, err
+}
+
 func (r *JobApplicationRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.JobApplication, error) {
 	var application domain.JobApplication
-	err := r.db.WithContext(ctx).
+	err := database.DB(ctx, r.db).
 		Preload("Job").
+		Preload("Answers").
 		Where("id = ?", id).
 		First(&application).Error
 	if err != nil {
@@ -168,22 +202,51 @@ func (r *JobApplicationRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID
 }
 
 func (r *JobApplicationRepositoryImpl) Update(ctx context.Context, application *domain.JobApplication) error {
-	return r.db.WithContext(ctx).Save(application).Error
+	return database.DB(ctx, r.db).Save(application).Error
 }
 
 func (r *JobApplicationRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.JobApplication{}, id).Error
+	return database.DB(ctx, r.db).Delete(&domain.JobApplication{}, id).Error
 }
 
 func (r *JobApplicationRepositoryImpl) ExistsByCandidateAndJob(ctx context.Context, candidateID, jobID uuid.UUID) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := database.DB(ctx, r.db).
 		Model(&domain.JobApplication{}).
 		Where("candidate_id = ? AND job_id = ?", candidateID, jobID).
 		Count(&count).Error
 	return count > 0, err
 }
 
+func (r *JobApplicationRepositoryImpl) CountByJobID(ctx context.Context, jobID uuid.UUID) (int64, error) {
+	var count int64
+	err := database.DB(ctx, r.db).
+		Model(&domain.JobApplication{}).
+		Where("job_id = ?", jobID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *JobApplicationRepositoryImpl) LockJob(ctx context.Context, jobID uuid.UUID) error {
+	return database.DB(ctx, r.db).
+		Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", jobID.String()).Error
+}
+
+type ScreeningAnswerRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewScreeningAnswerRepository(db *gorm.DB) domain.ScreeningAnswerRepository {
+	return &ScreeningAnswerRepositoryImpl{db: db}
+}
+
+func (r *ScreeningAnswerRepositoryImpl) CreateBatch(ctx context.Context, answers []domain.ScreeningAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+	return database.DB(ctx, r.db).Create(&answers).Error
+}
+
 type SkillRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -194,7 +257,7 @@ func NewSkillRepository(db *gorm.DB) domain.SkillRepository {
 
 func (r *SkillRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Skill, error) {
 	var skill domain.Skill
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&skill).Error
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&skill).Error
 	if err != nil {
 		return nil, err
 	}
@@ -203,15 +266,15 @@ func (r *SkillRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domai
 
 func (r *SkillRepositoryImpl) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.Skill, error) {
 	var skills []domain.Skill
-	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&skills).Error
+	err := database.DB(ctx, r.db).Where("id IN ?", ids).Find(&skills).Error
 	return skills, err
 }
 
-func (r *SkillRepositoryImpl) List(ctx context.Context, category, search string, offset, limit int) ([]*domain.Skill, int64, error) {
+func (r *SkillRepositoryImpl) List(ctx context.Context, category, search string, page utils.PageRequest) ([]*domain.Skill, int64, error) {
 	var skills []*domain.Skill
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Skill{})
+	query := database.DB(ctx, r.db).Model(&domain.Skill{})
 
 	if category != "" {
 		query = query.Where("LOWER(category) = ?", strings.ToLower(category))
@@ -221,10 +284,13 @@ func (r *SkillRepositoryImpl) List(ctx context.Context, category, search string,
 		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(search)+"%")
 	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if !page.Keyset {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
 	}
 
-	err := query.Order("name ASC").Offset(offset).Limit(limit).Find(&skills).Error
+	page = page.WithDefaultSort(utils.SortField{Column: "skills.name"})
+	err := query.Scopes(database.Paginate(page, "skills.created_at", "skills.id")).Find(&skills).Error
 	return skills, total, err
 }
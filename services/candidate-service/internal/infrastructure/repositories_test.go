@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestListOpenByJobIDLocksApplications(t *testing.T) {
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+
+	var queries []string
+	err = db.Callback().Query().After("gorm:query").Register("test:capture", func(db *gorm.DB) {
+		queries = append(queries, db.Statement.SQL.String())
+	})
+	require.NoError(t, err)
+
+	_, err = NewJobApplicationRepository(db).ListOpenByJobID(context.Background(), uuid.New())
+	require.NoError(t, err)
+	require.NotEmpty(t, queries)
+	assert.Contains(t, queries[0], `FROM "job_applications"`)
+	assert.Contains(t, queries[0], "status IN")
+	assert.Contains(t, queries[0], "FOR UPDATE")
+}
@@ -0,0 +1,117 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"recruitment-system/services/candidate-service/internal/domain"
+	"recruitment-system/shared/httpclient"
+
+	"github.com/google/uuid"
+)
+
+// maxCachedUsers bounds the cache; expired entries are dropped when it is
+// reached, and everything if none has expired.
+const maxCachedUsers = 10000
+
+type cachedUser struct {
+	user      domain.UserInfo
+	expiresAt time.Time
+}
+
+// CachedAuthServiceClient keeps users looked up in auth-service for a short
+// time, so listing candidates and applicants does not hit auth-service on
+// every request. Token validation is passed through uncached.
+type CachedAuthServiceClient struct {
+	domain.AuthServiceClient
+	ttl time.Duration
+
+	mu    sync.Mutex
+	users map[uuid.UUID]cachedUser
+	now   func() time.Time
+}
+
+func NewCachedAuthServiceClient(client domain.AuthServiceClient, ttl time.Duration) domain.AuthServiceClient {
+	return &CachedAuthServiceClient{
+		AuthServiceClient: client,
+		ttl:               ttl,
+		users:             make(map[uuid.UUID]cachedUser),
+		now:               time.Now,
+	}
+}
+
+func (c *CachedAuthServiceClient) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.UserInfo, error) {
+	users, err := c.GetUsersByIDs(ctx, []uuid.UUID{userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %s: %w", userID, httpclient.ErrNotFound)
+	}
+	return &users[0], nil
+}
+
+func (c *CachedAuthServiceClient) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]domain.UserInfo, error) {
+	found := make(map[uuid.UUID]domain.UserInfo, len(userIDs))
+	requested := make(map[uuid.UUID]bool, len(userIDs))
+	var missing []uuid.UUID
+
+	c.mu.Lock()
+	now := c.now()
+	for _, id := range userIDs {
+		if requested[id] {
+			continue
+		}
+		requested[id] = true
+
+		if entry, ok := c.users[id]; ok && now.Before(entry.expiresAt) {
+			found[id] = entry.user
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(missing) > 0 {
+		fetched, err := c.AuthServiceClient.GetUsersByIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		c.store(fetched)
+		for _, user := range fetched {
+			found[user.ID] = user
+		}
+	}
+
+	users := make([]domain.UserInfo, 0, len(found))
+	for _, id := range userIDs {
+		if user, ok := found[id]; ok {
+			users = append(users, user)
+			delete(found, id)
+		}
+	}
+	return users, nil
+}
+
+func (c *CachedAuthServiceClient) store(users []domain.UserInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.users)+len(users) > maxCachedUsers {
+		for id, entry := range c.users {
+			if !now.Before(entry.expiresAt) {
+				delete(c.users, id)
+			}
+		}
+		if len(c.users)+len(users) > maxCachedUsers {
+			c.users = make(map[uuid.UUID]cachedUser)
+		}
+	}
+
+	for _, user := range users {
+		c.users[user.ID] = cachedUser{user: user, expiresAt: now.Add(c.ttl)}
+	}
+}
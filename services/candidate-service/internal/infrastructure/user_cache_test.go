@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"recruitment-system/services/candidate-service/internal/domain"
+	"recruitment-system/shared/httpclient"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAuthServiceClient struct {
+	domain.AuthServiceClient
+	users   map[uuid.UUID]domain.UserInfo
+	lookups [][]uuid.UUID
+}
+
+func (c *fakeAuthServiceClient) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]domain.UserInfo, error) {
+	c.lookups = append(c.lookups, userIDs)
+	var users []domain.UserInfo
+	for _, id := range userIDs {
+		if user, ok := c.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func TestCachedAuthServiceClient(t *testing.T) {
+	ana := domain.UserInfo{ID: uuid.New(), Name: "Ana", Email: "ana@example.com"}
+	bruno := domain.UserInfo{ID: uuid.New(), Name: "Bruno", Email: "bruno@example.com"}
+	unknown := uuid.New()
+	fake := &fakeAuthServiceClient{users: map[uuid.UUID]domain.UserInfo{ana.ID: ana, bruno.ID: bruno}}
+
+	now := time.Now()
+	client := NewCachedAuthServiceClient(fake, time.Minute).(*CachedAuthServiceClient)
+	client.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	users, err := client.GetUsersByIDs(ctx, []uuid.UUID{bruno.ID, unknown, ana.ID, bruno.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserInfo{bruno, ana}, users)
+	assert.Equal(t, [][]uuid.UUID{{bruno.ID, unknown, ana.ID}}, fake.lookups)
+
+	user, err := client.GetUserByID(ctx, ana.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ana, *user)
+	assert.Len(t, fake.lookups, 1, "cached users are not looked up again")
+
+	_, err = client.GetUserByID(ctx, unknown)
+	assert.ErrorIs(t, err, httpclient.ErrNotFound)
+
+	now = now.Add(time.Minute)
+	_, err = client.GetUsersByIDs(ctx, []uuid.UUID{ana.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{ana.ID}, fake.lookups[len(fake.lookups)-1], "expired users are looked up again")
+}
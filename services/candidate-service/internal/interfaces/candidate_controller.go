@@ -11,13 +11,21 @@ import (
 	"github.com/google/uuid"
 )
 
+var applicationSortFields = utils.SortableFields{
+	"applied_at": "job_applications.applied_at",
+	"updated_at": "job_applications.updated_at",
+	"status":     "job_applications.status",
+}
+
 type CandidateController struct {
 	candidateService *application.CandidateService
+	cursors          *utils.CursorCodec
 }
 
-func NewCandidateController(candidateService *application.CandidateService) *CandidateController {
+func NewCandidateController(candidateService *application.CandidateService, cursors *utils.CursorCodec) *CandidateController {
 	return &CandidateController{
 		candidateService: candidateService,
+		cursors:          cursors,
 	}
 }
 
@@ -363,13 +371,7 @@ func (c *CandidateController) ApplyToJob(ctx *gin.Context) {
 		return
 	}
 
-	response := domain.JobApplicationResponse{
-		ID:          application.ID,
-		Status:      application.Status,
-		CoverLetter: application.CoverLetter,
-		AppliedAt:   application.AppliedAt,
-	}
-
+	response := c.mapApplicationToResponse(application)
 	utils.SuccessResponse(ctx, http.StatusCreated, "Application submitted successfully", response)
 }
 
@@ -393,32 +395,107 @@ func (c *CandidateController) GetApplications(ctx *gin.Context) {
 		return
 	}
 
-	applications, err := c.candidateService.GetApplications(ctx.Request.Context(), candidateID, userInfo.ID)
+	pagination := utils.GetPaginationParams(ctx)
+	page, err := c.cursors.PageRequest(pagination, applicationSortFields)
+	if err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	applications, total, err := c.candidateService.GetApplications(ctx.Request.Context(), candidateID, userInfo.ID, page)
 	if err != nil {
 		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to get applications", err)
 		return
 	}
 
+	c.respondWithApplications(ctx, applications, total, pagination, page)
+}
+
+func (c *CandidateController) GetJobApplications(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.candidateService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	jobIDStr := ctx.Param("jobId")
+	jobID, err := uuid.Parse(jobIDStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	pagination := utils.GetPaginationParams(ctx)
+	page, err := c.cursors.PageRequest(pagination, applicationSortFields)
+	if err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	applications, total, err := c.candidateService.GetJobApplications(ctx.Request.Context(), jobID, userInfo.ID, page)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to get applications", err)
+		return
+	}
+
+	c.respondWithApplications(ctx, applications, total, pagination, page)
+}
+
+func (c *CandidateController) respondWithApplications(ctx *gin.Context, applications []domain.JobApplication, total int64, pagination utils.PaginationParams, page utils.PageRequest) {
+	var cursorInfo utils.CursorPagination
+	if page.Keyset {
+		applications, cursorInfo = utils.KeysetPage(c.cursors, applications, page, func(application domain.JobApplication) utils.Cursor {
+			return utils.Cursor{CreatedAt: application.AppliedAt, ID: application.ID}
+		})
+	}
+
 	responses := make([]domain.JobApplicationResponse, len(applications))
-	for i, app := range applications {
-		responses[i] = domain.JobApplicationResponse{
-			ID:          app.ID,
-			Status:      app.Status,
-			CoverLetter: app.CoverLetter,
-			AppliedAt:   app.AppliedAt,
-		}
-		if app.Job != nil {
-			responses[i].Job = domain.JobResponse{
-				ID:          app.Job.ID,
-				Title:       app.Job.Title,
-				Description: app.Job.Description,
-				Location:    app.Job.Location,
-				Status:      app.Job.Status,
-			}
-		}
+	for i := range applications {
+		responses[i] = c.mapApplicationToResponse(&applications[i])
+	}
+
+	if page.Keyset {
+		utils.CursorPaginatedSuccessResponse(ctx, http.StatusOK, "Applications retrieved successfully", responses, cursorInfo)
+		return
 	}
 
-	utils.SuccessResponse(ctx, http.StatusOK, "Applications retrieved successfully", responses)
+	paginationInfo := utils.CreatePagination(pagination.Page, pagination.Limit, total)
+	utils.PaginatedSuccessResponse(ctx, http.StatusOK, "Applications retrieved successfully", responses, paginationInfo)
+}
+
+func (c *CandidateController) GetJobApplication(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.candidateService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid application ID", err)
+		return
+	}
+
+	application, err := c.candidateService.GetJobApplication(ctx.Request.Context(), id, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to get application", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Application retrieved successfully", c.mapApplicationToResponse(application))
 }
 
 func (c *CandidateController) extractToken(ctx *gin.Context) string {
@@ -519,3 +596,50 @@ func (c *CandidateController) mapCandidateToResponse(candidate *domain.Candidate
 
 	return response
 }
+
+func (c *CandidateController) mapApplicationToResponse(application *domain.JobApplication) domain.JobApplicationResponse {
+	response := domain.JobApplicationResponse{
+		ID:          application.ID,
+		JobID:       application.JobID,
+		CandidateID: application.CandidateID,
+		Status:      application.Status,
+		CoverLetter: application.CoverLetter,
+		KnockedOut:  application.KnockedOut,
+		AppliedAt:   application.AppliedAt,
+	}
+
+	if application.Candidate != nil && application.Candidate.User != nil {
+		response.Applicant = &domain.UserResponse{
+			ID:    application.Candidate.User.ID,
+			Email: application.Candidate.User.Email,
+			Name:  application.Candidate.User.Name,
+			Role:  application.Candidate.User.Role,
+		}
+	}
+
+	if application.Job != nil {
+		response.Job = domain.JobResponse{
+			ID:          application.Job.ID,
+			Title:       application.Job.Title,
+			Description: application.Job.Description,
+			Location:    application.Job.Location,
+			Status:      application.Job.Status,
+		}
+	}
+
+	if len(application.Answers) > 0 {
+		response.Answers = make([]domain.ScreeningAnswerResponse, len(application.Answers))
+		for i, answer := range application.Answers {
+			response.Answers[i] = domain.ScreeningAnswerResponse{
+				QuestionID:   answer.QuestionID,
+				Question:     answer.Question,
+				QuestionType: answer.QuestionType,
+				Answer:       answer.Answer,
+				Choices:      answer.Choices,
+				IsKnockout:   answer.IsKnockout,
+			}
+		}
+	}
+
+	return response
+}
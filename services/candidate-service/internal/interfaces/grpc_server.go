@@ -0,0 +1,64 @@
+package interfaces
+
+import (
+	"context"
+
+	"recruitment-system/services/candidate-service/internal/application"
+	"recruitment-system/services/candidate-service/internal/domain"
+	"recruitment-system/shared/grpcutil"
+	"recruitment-system/shared/pb/candidatev1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CandidateGRPCServer serves the internal gRPC API defined in
+// proto/candidate/v1/candidate.proto.
+type CandidateGRPCServer struct {
+	candidatev1.UnimplementedCandidateServiceServer
+	candidateService *application.CandidateService
+}
+
+func NewCandidateGRPCServer(candidateService *application.CandidateService) *CandidateGRPCServer {
+	return &CandidateGRPCServer{candidateService: candidateService}
+}
+
+func (s *CandidateGRPCServer) GetCandidate(ctx context.Context, req *candidatev1.GetCandidateRequest) (*candidatev1.Candidate, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid candidate ID %q", req.Id)
+	}
+
+	candidate, err := s.candidateService.GetCandidateByID(ctx, id)
+	if err != nil {
+		return nil, grpcutil.Error(err)
+	}
+	return toProtoCandidate(candidate), nil
+}
+
+func (s *CandidateGRPCServer) GetCandidateByUser(ctx context.Context, req *candidatev1.GetCandidateByUserRequest) (*candidatev1.Candidate, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID %q", req.UserId)
+	}
+
+	candidate, err := s.candidateService.GetCandidateByUserID(ctx, userID)
+	if err != nil {
+		return nil, grpcutil.Error(err)
+	}
+	return toProtoCandidate(candidate), nil
+}
+
+func toProtoCandidate(candidate *domain.Candidate) *candidatev1.Candidate {
+	return &candidatev1.Candidate{
+		Id:          candidate.ID.String(),
+		UserId:      candidate.UserID.String(),
+		Phone:       candidate.Phone,
+		Address:     candidate.Address,
+		LinkedinUrl: candidate.LinkedinURL,
+		GithubUrl:   candidate.GithubURL,
+		CreatedAt:   timestamppb.New(candidate.CreatedAt),
+	}
+}
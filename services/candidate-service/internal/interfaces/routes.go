@@ -1,11 +1,14 @@
 package interfaces
 
 import (
+	"recruitment-system/shared/health"
+	"recruitment-system/shared/middleware"
+
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, candidateController *CandidateController) {
-	api := router.Group("/api/v1")
+func SetupRoutes(router *gin.Engine, candidateController *CandidateController, rateLimiter *middleware.RateLimiter, healthChecker *health.Checker) {
+	api := router.Group("/api/v1", rateLimiter.Limit("api"))
 
 	candidates := api.Group("/candidates")
 	{
@@ -13,23 +16,24 @@ func SetupRoutes(router *gin.Engine, candidateController *CandidateController) {
 		candidates.GET("/profile", candidateController.GetMyProfile)
 		candidates.GET("/:id", candidateController.GetCandidate)
 		candidates.PUT("/:id", candidateController.UpdateCandidate)
-		
+
 		candidates.POST("/:id/skills", candidateController.AddSkill)
 		candidates.DELETE("/:id/skills/:skillId", candidateController.RemoveSkill)
-		
+
 		candidates.POST("/:id/work-experiences", candidateController.AddWorkExperience)
 		candidates.POST("/:id/education", candidateController.AddEducation)
-		
-		candidates.POST("/:id/resume", candidateController.UploadResume)
-		
-		candidates.POST("/:id/applications", candidateController.ApplyToJob)
+
+		candidates.POST("/:id/resume", rateLimiter.Limit("resume-upload"), candidateController.UploadResume)
+
+		candidates.POST("/:id/applications", rateLimiter.Limit("apply"), candidateController.ApplyToJob)
 		candidates.GET("/:id/applications", candidateController.GetApplications)
 	}
 
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"service": "candidate-service",
-		})
-	})
+	applications := api.Group("/applications")
+	{
+		applications.GET("/job/:jobId", candidateController.GetJobApplications)
+		applications.GET("/:id", candidateController.GetJobApplication)
+	}
+
+	healthChecker.Register(router)
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+
+	"recruitment-system/services/gateway/internal/gateway"
+	"recruitment-system/shared/health"
+	"recruitment-system/shared/logging"
+	"recruitment-system/shared/metrics"
+	"recruitment-system/shared/middleware"
+	"recruitment-system/shared/tracing"
+)
+
+func main() {
+	envErr := godotenv.Load()
+	logger := logging.Setup("gateway")
+	if envErr != nil {
+		logger.Info("No .env file found")
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "gateway", tracing.GetConfigFromEnv())
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	configPath := getEnv("GATEWAY_CONFIG", "config/gateway.json")
+	config, err := gateway.LoadConfig(configPath)
+	if err != nil {
+		logging.Fatal("Failed to load gateway config", err)
+	}
+
+	gw, err := gateway.New(config, getEnv("JWT_SECRET", "your-secret-key"), os.Getenv("GATEWAY_SECRET"))
+	if err != nil {
+		logging.Fatal("Failed to create gateway", err)
+	}
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		logging.Fatal("Invalid trusted_proxies", err)
+	}
+	router.Use(gin.Recovery(), middleware.RequestID(), tracing.Middleware("gateway"), middleware.RequestLogger(logger), metrics.Middleware())
+
+	gw.Register(router)
+
+	healthConfig, err := health.GetConfigFromEnv()
+	if err != nil {
+		logging.Fatal("Invalid health check configuration", err)
+	}
+	healthChecker := health.NewChecker("gateway", healthConfig)
+	probeClient := &http.Client{}
+	for name, service := range config.Services {
+		healthChecker.Add(name, health.HTTP(probeClient, service.URL+"/livez"))
+	}
+	router.GET("/livez", healthChecker.Live)
+	router.GET("/readyz", healthChecker.Ready)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	port := getEnv("PORT", "8080")
+	logger.Info("API Gateway starting", "port", port)
+	if err := router.Run(":" + port); err != nil {
+		logging.Fatal("Failed to start server", err)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
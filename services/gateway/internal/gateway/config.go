@@ -0,0 +1,209 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+type AuthMode string
+
+const (
+	// AuthRequired rejects requests without a valid user token.
+	AuthRequired AuthMode = "required"
+	// AuthOptional verifies the token when one is sent, so services can
+	// still serve anonymous callers (e.g. public job listings).
+	AuthOptional AuthMode = "optional"
+	// AuthNone forwards the request without looking at the token (login,
+	// register, refresh).
+	AuthNone AuthMode = "none"
+)
+
+const defaultRateLimitPolicy = "default"
+
+type Config struct {
+	Services       map[string]ServiceConfig   `json:"services"`
+	Routes         []RouteConfig              `json:"routes"`
+	RateLimits     map[string]RateLimitConfig `json:"rate_limits"`
+	CORS           CORSConfig                 `json:"cors"`
+	TrustedProxies []string                   `json:"trusted_proxies"`
+}
+
+type ServiceConfig struct {
+	URL        string `json:"url"`
+	HealthPath string `json:"health_path"`
+}
+
+type RouteConfig struct {
+	Prefix    string   `json:"prefix"`
+	Methods   []string `json:"methods"`
+	Service   string   `json:"service"`
+	Auth      AuthMode `json:"auth"`
+	RateLimit string   `json:"rate_limit"`
+}
+
+// RateLimitConfig allows Requests per Window for each client, refilled
+// continuously (token bucket with a burst of Requests).
+type RateLimitConfig struct {
+	Requests int      `json:"requests"`
+	Window   Duration `json:"window"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `json:"allowed_origins"`
+	AllowedMethods []string `json:"allowed_methods"`
+	AllowedHeaders []string `json:"allowed_headers"`
+	ExposedHeaders []string `json:"exposed_headers"`
+	MaxAge         Duration `json:"max_age"`
+}
+
+// Duration reads durations written as strings ("1m", "30s").
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// LoadConfig reads the routing file. ${VAR} and ${VAR:-default} references
+// are replaced with environment variables, so the same file works locally
+// and in docker-compose.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gateway config: %w", err)
+	}
+	return ParseConfig(data)
+}
+
+func ParseConfig(data []byte) (*Config, error) {
+	decoder := json.NewDecoder(strings.NewReader(expandEnv(string(data))))
+	decoder.DisallowUnknownFields()
+
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid gateway config: %w", err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid gateway config: %w", err)
+	}
+
+	// Longest prefix wins, and for the same prefix a method-specific route
+	// wins over one accepting every method.
+	sort.SliceStable(config.Routes, func(i, j int) bool {
+		a, b := config.Routes[i], config.Routes[j]
+		if len(a.Prefix) != len(b.Prefix) {
+			return len(a.Prefix) > len(b.Prefix)
+		}
+		return len(a.Methods) > 0 && len(b.Methods) == 0
+	})
+
+	return &config, nil
+}
+
+func (c *Config) validate() error {
+	if len(c.Services) == 0 {
+		return fmt.Errorf("no services configured")
+	}
+	for name, service := range c.Services {
+		u, err := url.Parse(service.URL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("service %q has an invalid url %q", name, service.URL)
+		}
+	}
+
+	for name, limit := range c.RateLimits {
+		if limit.Requests <= 0 || limit.Window <= 0 {
+			return fmt.Errorf("rate limit %q must have positive requests and window", name)
+		}
+	}
+
+	for i := range c.Routes {
+		route := &c.Routes[i]
+		if !strings.HasPrefix(route.Prefix, "/") {
+			return fmt.Errorf("route prefix %q must start with /", route.Prefix)
+		}
+		route.Prefix = strings.TrimSuffix(route.Prefix, "/")
+		if _, ok := c.Services[route.Service]; !ok {
+			return fmt.Errorf("route %s references unknown service %q", route.Prefix, route.Service)
+		}
+
+		switch route.Auth {
+		case "":
+			route.Auth = AuthRequired
+		case AuthRequired, AuthOptional, AuthNone:
+		default:
+			return fmt.Errorf("route %s has unknown auth mode %q", route.Prefix, route.Auth)
+		}
+
+		if route.RateLimit != "" {
+			if _, ok := c.RateLimits[route.RateLimit]; !ok {
+				return fmt.Errorf("route %s references unknown rate limit %q", route.Prefix, route.RateLimit)
+			}
+		}
+
+		for j, method := range route.Methods {
+			route.Methods[j] = strings.ToUpper(method)
+		}
+	}
+
+	return nil
+}
+
+// match returns the route serving method and path, or nil.
+func (c *Config) match(method, path string) *RouteConfig {
+	for i := range c.Routes {
+		route := &c.Routes[i]
+		if path != route.Prefix && !strings.HasPrefix(path, route.Prefix+"/") {
+			continue
+		}
+		if len(route.Methods) > 0 && !containsString(route.Methods, method) {
+			continue
+		}
+		return route
+	}
+	return nil
+}
+
+// rateLimit returns the policy applied to route, falling back to the
+// "default" policy when the route names none.
+func (c *Config) rateLimit(route *RouteConfig) string {
+	if route.RateLimit == "" {
+		return defaultRateLimitPolicy
+	}
+	return route.RateLimit
+}
+
+func expandEnv(s string) string {
+	return os.Expand(s, func(key string) string {
+		name, fallback, hasDefault := strings.Cut(key, ":-")
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+		if hasDefault {
+			return fallback
+		}
+		return ""
+	})
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,244 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"recruitment-system/shared/gatewayauth"
+	"recruitment-system/shared/metrics"
+	"recruitment-system/shared/middleware"
+	"recruitment-system/shared/requestid"
+	"recruitment-system/shared/tracing"
+	"recruitment-system/shared/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const upstreamResponseTimeout = 30 * time.Second
+
+type principalKey struct{}
+
+type clientIPKey struct{}
+
+type responseHeaderKey struct{}
+
+var rateLimitHeaders = []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"}
+
+type Gateway struct {
+	config    *Config
+	jwtSecret string
+	proxies   map[string]*httputil.ReverseProxy
+	limiter   *middleware.RateLimiter
+	health    *http.Client
+}
+
+// New builds the gateway. gatewaySecret is sent to the services with the
+// verified principal so they can trust it (see gatewayauth).
+func New(config *Config, jwtSecret, gatewaySecret string) (*Gateway, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = upstreamResponseTimeout
+
+	proxies := make(map[string]*httputil.ReverseProxy, len(config.Services))
+	for name, service := range config.Services {
+		target, err := url.Parse(service.URL)
+		if err != nil {
+			return nil, err
+		}
+		proxies[name] = newProxy(name, target, tracing.Transport(name, transport), gatewaySecret)
+	}
+
+	policies := make([]middleware.RateLimitPolicy, 0, len(config.RateLimits))
+	for name, limit := range config.RateLimits {
+		policies = append(policies, middleware.RateLimitPolicy{
+			Name:   name,
+			Limit:  limit.Requests,
+			Window: time.Duration(limit.Window),
+			Key:    middleware.KeyByUser(jwtSecret),
+		})
+	}
+	limiter, err := middleware.NewRateLimiter(middleware.NewMemoryRateLimitStore(), "", policies...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Gateway{
+		config:    config,
+		jwtSecret: jwtSecret,
+		proxies:   proxies,
+		limiter:   limiter,
+		health:    &http.Client{Timeout: healthCheckTimeout},
+	}, nil
+}
+
+// Register mounts the gateway on router: CORS for every request, the
+// aggregated health check and the proxied /api/v1 tree.
+func (g *Gateway) Register(router *gin.Engine) {
+	router.Use(g.CORS())
+	router.GET("/health", g.Health)
+	router.Any("/api/v1/*path", g.Proxy)
+}
+
+func (g *Gateway) Proxy(c *gin.Context) {
+	route := g.config.match(c.Request.Method, c.Request.URL.Path)
+	if route == nil {
+		utils.NotFoundResponse(c, "Route")
+		return
+	}
+	c.Set(metrics.RouteKey, route.Prefix)
+
+	claims, ok := g.authenticate(c, route)
+	if !ok {
+		return
+	}
+
+	ctx := context.WithValue(c.Request.Context(), responseHeaderKey{}, c.Writer.Header())
+	ctx = context.WithValue(ctx, clientIPKey{}, c.ClientIP())
+	if claims != nil {
+		c.Set("user_id", claims.UserID)
+		ctx = context.WithValue(ctx, principalKey{}, claims)
+	}
+
+	if !g.limiter.Allow(c, g.config.rateLimit(route)) {
+		return
+	}
+
+	g.proxies[route.Service].ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+}
+
+func (g *Gateway) authenticate(c *gin.Context, route *RouteConfig) (*middleware.Claims, bool) {
+	if route.Auth == AuthNone {
+		return nil, true
+	}
+
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		if route.Auth == AuthRequired {
+			utils.UnauthorizedResponse(c)
+			return nil, false
+		}
+		return nil, true
+	}
+
+	token, found := strings.CutPrefix(authHeader, "Bearer ")
+	if !found || token == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid authorization header format", nil)
+		return nil, false
+	}
+
+	claims, err := middleware.ParseToken(g.jwtSecret, token)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid token", nil)
+		return nil, false
+	}
+	if claims.IsService() {
+		utils.ErrorResponse(c, http.StatusForbidden, "Service tokens cannot access user endpoints", nil)
+		return nil, false
+	}
+
+	return claims, true
+}
+
+func (g *Gateway) CORS() gin.HandlerFunc {
+	cors := g.config.CORS
+	allowAll := containsString(cors.AllowedOrigins, "*")
+	methods := strings.Join(cors.AllowedMethods, ", ")
+	headers := strings.Join(cors.AllowedHeaders, ", ")
+	exposed := strings.Join(cors.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(time.Duration(cors.MaxAge).Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		switch {
+		case allowAll:
+			c.Header("Access-Control-Allow-Origin", "*")
+		case origin != "" && containsString(cors.AllowedOrigins, origin):
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+		}
+		c.Header("Access-Control-Allow-Methods", methods)
+		c.Header("Access-Control-Allow-Headers", headers)
+		if exposed != "" {
+			c.Header("Access-Control-Expose-Headers", exposed)
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			if cors.MaxAge > 0 {
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func newProxy(service string, target *url.URL, transport http.RoundTripper, gatewaySecret string) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Transport: transport,
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(target)
+			// X-Forwarded-For is replaced, not appended to: services trust
+			// the gateway, so it must only carry the client IP the gateway
+			// resolved through its own trusted_proxies.
+			r.SetXForwarded()
+			if ip, ok := r.In.Context().Value(clientIPKey{}).(string); ok && ip != "" {
+				r.Out.Header.Set("X-Forwarded-For", ip)
+			}
+
+			// Client-supplied principal headers are always dropped.
+			for _, header := range gatewayauth.Headers {
+				r.Out.Header.Del(header)
+			}
+			if claims, ok := r.In.Context().Value(principalKey{}).(*middleware.Claims); ok {
+				r.Out.Header.Set(gatewayauth.HeaderUserID, claims.UserID)
+				r.Out.Header.Set(gatewayauth.HeaderUserEmail, claims.Email)
+				r.Out.Header.Set(gatewayauth.HeaderUserRole, claims.Role)
+				if gatewaySecret != "" {
+					r.Out.Header.Set(gatewayauth.HeaderSecret, gatewaySecret)
+				}
+			}
+
+			if id := requestid.FromContext(r.In.Context()); id != "" {
+				r.Out.Header.Set(requestid.Header, id)
+			}
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			// The gateway owns CORS and the request ID; drop the copies the
+			// services add so clients don't see duplicated headers.
+			for name := range resp.Header {
+				if strings.HasPrefix(name, "Access-Control-") {
+					resp.Header.Del(name)
+				}
+			}
+			resp.Header.Del(requestid.Header)
+
+			// A service enforcing its own, route-specific limit reports it
+			// instead of the gateway's.
+			if resp.Header.Get("RateLimit-Limit") != "" {
+				if header, ok := resp.Request.Context().Value(responseHeaderKey{}).(http.Header); ok {
+					for _, name := range rateLimitHeaders {
+						header.Del(name)
+					}
+				}
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			slog.ErrorContext(r.Context(), "Upstream request failed", "service", service, "method", r.Method, "path", r.URL.Path, "error", err)
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode(utils.Response{
+				Success: false,
+				Message: service + " unavailable",
+			})
+		},
+	}
+}
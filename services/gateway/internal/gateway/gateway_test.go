@@ -0,0 +1,254 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"recruitment-system/shared/gatewayauth"
+	"recruitment-system/shared/serviceauth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSecret        = "test-secret"
+	testGatewaySecret = "test-gateway-secret"
+)
+
+func newTestGateway(t *testing.T, upstream string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	config, err := ParseConfig([]byte(fmt.Sprintf(`{
+		"services": {"job-service": {"url": %q}},
+		"rate_limits": {"default": {"requests": 100, "window": "1m"}, "strict": {"requests": 2, "window": "1m"}},
+		"routes": [
+			{"prefix": "/api/v1/jobs", "service": "job-service"},
+			{"prefix": "/api/v1/jobs", "methods": ["get"], "service": "job-service", "auth": "optional"},
+			{"prefix": "/api/v1/jobs/search", "service": "job-service", "auth": "none", "rate_limit": "strict"}
+		],
+		"cors": {"allowed_origins": ["*"], "allowed_methods": ["GET"]}
+	}`, upstream)))
+	require.NoError(t, err)
+
+	gw, err := New(config, testSecret, testGatewaySecret)
+	require.NoError(t, err)
+
+	router := gin.New()
+	require.NoError(t, router.SetTrustedProxies(config.TrustedProxies))
+	gw.Register(router)
+	return router
+}
+
+// recorder adds CloseNotify, which gin's writer forwards to and
+// httputil.ReverseProxy calls.
+type recorder struct {
+	*httptest.ResponseRecorder
+}
+
+func newRecorder() *recorder {
+	return &recorder{httptest.NewRecorder()}
+}
+
+func (r *recorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+func signToken(t *testing.T, tokenType string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":    "user-1",
+		"email":      "user@example.com",
+		"role":       "admin",
+		"token_type": tokenType,
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return signed
+}
+
+func echoHeaders() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"path":    r.URL.Path,
+			"user_id": r.Header.Get(gatewayauth.HeaderUserID),
+			"role":    r.Header.Get(gatewayauth.HeaderUserRole),
+			"secret":  r.Header.Get(gatewayauth.HeaderSecret),
+			"xff":     r.Header.Get("X-Forwarded-For"),
+		})
+	}))
+}
+
+func TestParseConfig_ExpandsEnvAndOrdersRoutes(t *testing.T) {
+	t.Setenv("JOB_URL", "http://job:8081")
+
+	config, err := ParseConfig([]byte(`{
+		"services": {
+			"job-service": {"url": "${JOB_URL:-http://localhost:8081}"},
+			"auth-service": {"url": "${AUTH_URL:-http://localhost:8083}"}
+		},
+		"routes": [
+			{"prefix": "/api/v1/jobs", "service": "job-service"},
+			{"prefix": "/api/v1/jobs", "methods": ["GET"], "service": "job-service", "auth": "optional"},
+			{"prefix": "/api/v1/jobs/facets/", "service": "job-service", "auth": "none"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://job:8081", config.Services["job-service"].URL)
+	assert.Equal(t, "http://localhost:8083", config.Services["auth-service"].URL)
+
+	assert.Equal(t, AuthNone, config.match("GET", "/api/v1/jobs/facets").Auth)
+	assert.Equal(t, AuthOptional, config.match("GET", "/api/v1/jobs/123").Auth)
+	assert.Equal(t, AuthRequired, config.match("POST", "/api/v1/jobs").Auth)
+	assert.Nil(t, config.match("GET", "/api/v1/jobsearch"))
+}
+
+func TestParseConfig_RejectsUnknownService(t *testing.T) {
+	_, err := ParseConfig([]byte(`{
+		"services": {"job-service": {"url": "http://localhost:8081"}},
+		"routes": [{"prefix": "/api/v1/candidates", "service": "candidate-service"}]
+	}`))
+	assert.Error(t, err)
+}
+
+func TestProxy_InjectsVerifiedPrincipal(t *testing.T) {
+	upstream := echoHeaders()
+	defer upstream.Close()
+	router := newTestGateway(t, upstream.URL)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, serviceauth.TokenTypeUser))
+	req.Header.Set(gatewayauth.HeaderUserRole, "superuser")
+	w := newRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "/api/v1/jobs", body["path"])
+	assert.Equal(t, "user-1", body["user_id"])
+	assert.Equal(t, "admin", body["role"])
+	assert.Equal(t, testGatewaySecret, body["secret"])
+	assert.Len(t, w.Header().Values("Access-Control-Allow-Origin"), 1)
+}
+
+func TestProxy_StripsSpoofedPrincipalOnAnonymousRequests(t *testing.T) {
+	upstream := echoHeaders()
+	defer upstream.Close()
+	router := newTestGateway(t, upstream.URL)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil)
+	req.Header.Set(gatewayauth.HeaderUserID, "someone-else")
+	req.Header.Set(gatewayauth.HeaderSecret, "guessed")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	w := newRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Empty(t, body["user_id"])
+	assert.Empty(t, body["secret"])
+	assert.Equal(t, "192.0.2.1", body["xff"], "the client's X-Forwarded-For is replaced")
+}
+
+func TestProxy_EnforcesAuth(t *testing.T) {
+	upstream := echoHeaders()
+	defer upstream.Close()
+	router := newTestGateway(t, upstream.URL)
+
+	w := newRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/jobs", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil)
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	w = newRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/jobs", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, serviceauth.TokenTypeService))
+	w = newRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = newRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestProxy_RateLimitsPerClient(t *testing.T) {
+	upstream := echoHeaders()
+	defer upstream.Close()
+	router := newTestGateway(t, upstream.URL)
+
+	var codes []int
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/search", nil)
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i))
+		w := newRecorder()
+		router.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+		if w.Code == http.StatusTooManyRequests {
+			assert.Equal(t, "30", w.Header().Get("Retry-After"))
+		}
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+}
+
+func TestProxy_UnreachableServiceReturnsBadGateway(t *testing.T) {
+	upstream := echoHeaders()
+	upstream.Close()
+	router := newTestGateway(t, upstream.URL)
+
+	w := newRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil))
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+}
+
+func TestHealth_AggregatesServices(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	config, err := ParseConfig([]byte(fmt.Sprintf(`{
+		"services": {"job-service": {"url": %q}, "auth-service": {"url": %q}}
+	}`, up.URL, down.URL)))
+	require.NoError(t, err)
+	gw, err := New(config, testSecret, testGatewaySecret)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	gw.Register(router)
+
+	w := newRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var body struct {
+		Status   string                   `json:"status"`
+		Services map[string]serviceHealth `json:"services"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "degraded", body.Status)
+	assert.Equal(t, "ok", body.Services["job-service"].Status)
+	assert.Equal(t, "down", body.Services["auth-service"].Status)
+}
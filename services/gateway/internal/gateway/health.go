@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+type serviceHealth struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Health checks every configured service concurrently and reports 503 when
+// any of them is down.
+func (g *Gateway) Health(c *gin.Context) {
+	names := make([]string, 0, len(g.config.Services))
+	for name := range g.config.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]serviceHealth, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, service ServiceConfig) {
+			defer wg.Done()
+			results[i] = g.checkService(c.Request.Context(), service)
+		}(i, g.config.Services[name])
+	}
+	wg.Wait()
+
+	status, code := "ok", http.StatusOK
+	services := make(map[string]serviceHealth, len(names))
+	for i, name := range names {
+		services[name] = results[i]
+		if results[i].Status != "ok" {
+			status, code = "degraded", http.StatusServiceUnavailable
+		}
+	}
+
+	c.JSON(code, gin.H{
+		"status":   status,
+		"service":  "gateway",
+		"services": services,
+	})
+}
+
+func (g *Gateway) checkService(ctx context.Context, service ServiceConfig) serviceHealth {
+	path := service.HealthPath
+	if path == "" {
+		path = "/health"
+	}
+
+	start := time.Now()
+	result := serviceHealth{Status: "down"}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, service.URL+path, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	resp, err := g.health.Do(req)
+	result.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		result.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+		return result
+	}
+
+	result.Status = "ok"
+	return result
+}
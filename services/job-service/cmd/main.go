@@ -1,67 +1,198 @@
 package main
 
 import (
-	"log"
+	"context"
 	"os"
+	"time"
 
 	"recruitment-system/services/job-service/internal/application"
 	"recruitment-system/services/job-service/internal/infrastructure"
 	"recruitment-system/services/job-service/internal/interfaces"
 	"recruitment-system/shared/database"
+	"recruitment-system/shared/events"
+	"recruitment-system/shared/grpcutil"
+	"recruitment-system/shared/health"
+	"recruitment-system/shared/httpclient"
+	"recruitment-system/shared/logging"
+	"recruitment-system/shared/metrics"
+	"recruitment-system/shared/middleware"
+	"recruitment-system/shared/pb/jobv1"
+	"recruitment-system/shared/serviceauth"
+	"recruitment-system/shared/tracing"
+	"recruitment-system/shared/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+	envErr := godotenv.Load()
+	logger := logging.Setup("job-service")
+	if envErr != nil {
+		logger.Info("No .env file found")
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "job-service", tracing.GetConfigFromEnv())
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	dbConfig := database.GetConfigFromEnv()
 	db, err := database.NewConnection(dbConfig)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		logging.Fatal("Failed to connect to database", err)
 	}
 
-	if err := database.TestConnection(db); err != nil {
-		log.Fatal("Database connection test failed:", err)
+	if err := database.TestConnection(context.Background(), db); err != nil {
+		logging.Fatal("Database connection test failed", err)
+	}
+	logger.Info("Database connection established")
+	if err := metrics.InstrumentDB(db, dbConfig.DBName); err != nil {
+		logging.Fatal("Failed to instrument database", err)
+	}
+	if err := tracing.InstrumentDB(db); err != nil {
+		logging.Fatal("Failed to instrument database", err)
 	}
 
 	jobRepo := infrastructure.NewJobRepository(db)
 	skillRepo := infrastructure.NewSkillRepository(db)
 	jobSkillRepo := infrastructure.NewJobSkillRepository(db)
+	screeningRepo := infrastructure.NewScreeningQuestionRepository(db)
+	exchangeRateRepo := infrastructure.NewExchangeRateRepository(db)
+	savedSearchRepo := infrastructure.NewSavedSearchRepository(db)
+
+	gazetteer, err := infrastructure.NewGazetteer(os.Getenv("GAZETTEER_FILE"))
+	if err != nil {
+		logging.Fatal("Failed to load gazetteer", err)
+	}
 
-	authServiceURL := getEnv("AUTH_SERVICE_URL", "http://localhost:8083")
-	authClient := infrastructure.NewAuthServiceClient(authServiceURL)
+	serviceTokens := serviceauth.NewTokenSource(getEnv("AUTH_SERVICE_URL", "http://localhost:8083"), serviceauth.Credentials{
+		ClientID:     getEnv("SERVICE_CLIENT_ID", "job-service"),
+		ClientSecret: os.Getenv("SERVICE_CLIENT_SECRET"),
+	})
+	authConn, err := grpcutil.Dial("auth-service", getEnv("AUTH_SERVICE_GRPC_ADDR", "localhost:9083"), serviceTokens, httpclient.DefaultConfig())
+	if err != nil {
+		logging.Fatal("Failed to connect to auth-service", err)
+	}
+	authClient := infrastructure.NewAuthServiceClient(authConn)
 
-	jobService := application.NewJobService(jobRepo, skillRepo, jobSkillRepo, authClient)
+	outbox := events.NewOutbox(db, "job-service")
 
-	jobController := interfaces.NewJobController(jobService)
-	skillController := interfaces.NewSkillController(jobService)
+	jobService := application.NewJobService(jobRepo, skillRepo, jobSkillRepo, screeningRepo, exchangeRateRepo, gazetteer, database.NewUnitOfWork(db), authClient, outbox)
 
-	router := gin.Default()
+	pollInterval, err := time.ParseDuration(getEnv("EVENT_POLL_INTERVAL", "5s"))
+	if err != nil {
+		logging.Fatal("Invalid EVENT_POLL_INTERVAL", err)
+	}
+	broker, err := events.NewBroker(getEnv("EVENT_BROKER", "postgres"), db, dbConfig.DSN(), pollInterval)
+	if err != nil {
+		logging.Fatal("Failed to create event broker", err)
+	}
+
+	relayInterval, err := time.ParseDuration(getEnv("OUTBOX_RELAY_INTERVAL", "1s"))
+	if err != nil {
+		logging.Fatal("Invalid OUTBOX_RELAY_INTERVAL", err)
+	}
+	relay := events.NewRelay(db, broker, "job-service", relayInterval)
+	go relay.Start(context.Background())
+
+	handler := events.Idempotent(db, "job-service", jobService.HandleDomainEvent)
+	if err := broker.Subscribe(context.Background(), "job-service", handler); err != nil {
+		logging.Fatal("Failed to subscribe to domain events", err)
+	}
+
+	schedulerInterval, err := time.ParseDuration(getEnv("JOB_SCHEDULER_INTERVAL", "1m"))
+	if err != nil {
+		logging.Fatal("Invalid JOB_SCHEDULER_INTERVAL", err)
+	}
+	jobScheduler := application.NewJobScheduler(jobService, schedulerInterval)
+	go jobScheduler.Start(context.Background())
+
+	alertNotifier := infrastructure.NewLogAlertNotifier()
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		alertNotifier = infrastructure.NewSMTPAlertNotifier(infrastructure.SMTPConfig{
+			Host:     smtpHost,
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnv("SMTP_FROM", "no-reply@recruitment.local"),
+		})
+	}
+
+	savedSearchService := application.NewSavedSearchService(savedSearchRepo, jobService, alertNotifier, getEnv("PUBLIC_BASE_URL", "http://localhost:8081"))
+
+	alertInterval, err := time.ParseDuration(getEnv("ALERT_SCHEDULER_INTERVAL", "1m"))
+	if err != nil {
+		logging.Fatal("Invalid ALERT_SCHEDULER_INTERVAL", err)
+	}
+	alertScheduler := application.NewAlertScheduler(savedSearchService, alertInterval)
+	go alertScheduler.Start(context.Background())
+
+	cursors := utils.NewCursorCodec(getEnv("CURSOR_SECRET", "your-cursor-secret"))
+	jobController := interfaces.NewJobController(jobService, cursors)
+	skillController := interfaces.NewSkillController(jobService, cursors)
+	exchangeRateController := interfaces.NewExchangeRateController(jobService)
+	savedSearchController := interfaces.NewSavedSearchController(savedSearchService)
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(middleware.TrustedProxies(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		logging.Fatal("Invalid TRUSTED_PROXIES", err)
+	}
+	router.Use(gin.Recovery(), middleware.RequestID(), tracing.Middleware("job-service"), middleware.RequestLogger(logger), metrics.Middleware(), middleware.GatewayIdentity(getEnv("GATEWAY_SECRET", "")))
 
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
-		
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID")
+
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
 			return
 		}
-		
+
 		c.Next()
 	})
 
-	interfaces.SetupRoutes(router, jobController, skillController)
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	grpcServer := grpcutil.NewServer(jwtSecret)
+	jobv1.RegisterJobServiceServer(grpcServer, interfaces.NewJobGRPCServer(jobService))
+	go func() {
+		grpcPort := getEnv("GRPC_PORT", "9081")
+		logger.Info("Job Service gRPC server starting", "port", grpcPort)
+		if err := grpcutil.ListenAndServe(grpcServer, ":"+grpcPort); err != nil {
+			logging.Fatal("Failed to start gRPC server", err)
+		}
+	}()
+
+	rateLimitStore, err := middleware.NewRateLimitStore(getEnv("RATE_LIMIT_STORE", "memory"), db)
+	if err != nil {
+		logging.Fatal("Invalid RATE_LIMIT_STORE", err)
+	}
+	rateLimiter, err := middleware.NewRateLimiter(rateLimitStore, os.Getenv("RATE_LIMITS"),
+		middleware.RateLimitPolicy{Name: "api", Limit: 300, Window: time.Minute, Key: middleware.KeyByUser(jwtSecret)},
+	)
+	if err != nil {
+		logging.Fatal("Invalid RATE_LIMITS", err)
+	}
+
+	healthConfig, err := health.GetConfigFromEnv()
+	if err != nil {
+		logging.Fatal("Invalid health check configuration", err)
+	}
+	healthChecker := health.NewChecker("job-service", healthConfig).
+		Add("database", health.Database(db)).
+		Add("schema", health.SchemaVersion(db)).
+		Add("auth-service", health.GRPC(authConn))
+
+	interfaces.SetupRoutes(router, jobController, skillController, exchangeRateController, savedSearchController, jwtSecret, rateLimiter, healthChecker)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	port := getEnv("PORT", "8081")
-	log.Printf("Job Service starting on port %s", port)
-	
+	logger.Info("Job Service starting", "port", port)
+
 	if err := router.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+		logging.Fatal("Failed to start server", err)
 	}
 }
 
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type AlertScheduler struct {
+	savedSearchService *SavedSearchService
+	interval           time.Duration
+}
+
+func NewAlertScheduler(savedSearchService *SavedSearchService, interval time.Duration) *AlertScheduler {
+	return &AlertScheduler{
+		savedSearchService: savedSearchService,
+		interval:           interval,
+	}
+}
+
+func (s *AlertScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx)
+		}
+	}
+}
+
+func (s *AlertScheduler) run(ctx context.Context) {
+	if err := s.savedSearchService.RunAlerts(ctx); err != nil {
+		slog.ErrorContext(ctx, "Alert scheduler run failed", "error", err)
+	}
+}
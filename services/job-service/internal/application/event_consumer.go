@@ -0,0 +1,50 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/events"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// HandleDomainEvent reacts to events published by the other services.
+func (s *JobService) HandleDomainEvent(ctx context.Context, msg events.Message) error {
+	switch msg.Type {
+	case events.ApplicationSubmitted:
+		var payload events.ApplicationSubmittedPayload
+		if err := msg.Decode(&payload); err != nil {
+			return err
+		}
+		return s.recordApplication(ctx, payload.JobID)
+	}
+	return nil
+}
+
+// recordApplication counts an application to the job and closes the job
+// once it reaches its application cap. Candidate-service enforces the cap
+// when applying; this only stops the job from staying open once it is full.
+// Applications to a job deleted since are ignored.
+func (s *JobService) recordApplication(ctx context.Context, jobID uuid.UUID) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		count, err := s.jobRepo.IncrementApplicationCount(ctx, jobID)
+		if err != nil {
+			return err
+		}
+
+		job, err := s.jobRepo.GetByID(ctx, jobID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if job.Status != string(domain.JobStatusOpen) || job.MaxApplications == nil || count < *job.MaxApplications {
+			return nil
+		}
+		return s.changeStatus(ctx, job, domain.JobStatusClosed, true)
+	})
+}
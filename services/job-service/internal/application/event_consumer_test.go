@@ -0,0 +1,178 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type passthroughUnitOfWork struct{}
+
+func (passthroughUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeJobRepository holds a single job. Setting changedBy simulates another
+// writer changing the job's status after it was read.
+type fakeJobRepository struct {
+	domain.JobRepository
+	job       domain.Job
+	count     int
+	changedBy string
+	locks     int
+	deleted   bool
+}
+
+func (r *fakeJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	if r.deleted {
+		return nil, gorm.ErrRecordNotFound
+	}
+	job := r.job
+	return &job, nil
+}
+
+func (r *fakeJobRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	r.locks++
+	job := r.job
+	job.ApplicationCount = r.count
+	return &job, nil
+}
+
+func (r *fakeJobRepository) Update(ctx context.Context, job *domain.Job) error {
+	status := r.job.Status
+	r.job = *job
+	r.job.Status = status
+	return nil
+}
+
+func (r *fakeJobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+func (r *fakeJobRepository) IncrementApplicationCount(ctx context.Context, id uuid.UUID) (int, error) {
+	r.count++
+	return r.count, nil
+}
+
+func (r *fakeJobRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from, to string) (bool, error) {
+	if r.changedBy != "" {
+		r.job.Status = r.changedBy
+	}
+	if r.job.Status != from {
+		return false, nil
+	}
+	r.job.Status = to
+	return true, nil
+}
+
+type emptyJobSkillRepository struct {
+	domain.JobSkillRepository
+}
+
+func (emptyJobSkillRepository) GetByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.JobSkill, error) {
+	return nil, nil
+}
+
+type emptyScreeningRepository struct {
+	domain.ScreeningQuestionRepository
+}
+
+func (emptyScreeningRepository) GetByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.ScreeningQuestion, error) {
+	return nil, nil
+}
+
+func (emptyScreeningRepository) DeleteByJobID(ctx context.Context, jobID uuid.UUID) error {
+	return nil
+}
+
+type fakeOutbox struct {
+	added []string
+}
+
+func (o *fakeOutbox) Add(ctx context.Context, eventType string, aggregateID uuid.UUID, payload interface{}) error {
+	o.added = append(o.added, eventType)
+	return nil
+}
+
+func applicationSubmitted(t *testing.T, jobID uuid.UUID) events.Message {
+	msg, err := events.NewMessage("candidate-service", events.ApplicationSubmitted, uuid.New(), events.ApplicationSubmittedPayload{JobID: jobID})
+	require.NoError(t, err)
+	return msg
+}
+
+func TestApplicationsCloseJobAtCap(t *testing.T) {
+	max := 2
+	repo := &fakeJobRepository{job: domain.Job{ID: uuid.New(), Status: string(domain.JobStatusOpen), MaxApplications: &max}}
+	outbox := &fakeOutbox{}
+	service := &JobService{jobRepo: repo, uow: passthroughUnitOfWork{}, outbox: outbox}
+
+	require.NoError(t, service.HandleDomainEvent(context.Background(), applicationSubmitted(t, repo.job.ID)))
+	assert.Equal(t, string(domain.JobStatusOpen), repo.job.Status)
+	assert.Empty(t, outbox.added)
+
+	require.NoError(t, service.HandleDomainEvent(context.Background(), applicationSubmitted(t, repo.job.ID)))
+	assert.Equal(t, string(domain.JobStatusClosed), repo.job.Status)
+	assert.Equal(t, []string{events.JobStatusChanged, events.JobClosed}, outbox.added)
+}
+
+func TestLostStatusRaceWritesNoEvents(t *testing.T) {
+	repo := &fakeJobRepository{job: domain.Job{ID: uuid.New(), Status: string(domain.JobStatusOpen)}, changedBy: string(domain.JobStatusArchived)}
+	outbox := &fakeOutbox{}
+	service := &JobService{jobRepo: repo, uow: passthroughUnitOfWork{}, outbox: outbox}
+	job := repo.job
+
+	require.NoError(t, service.changeStatus(context.Background(), &job, domain.JobStatusClosed, true))
+	assert.Equal(t, string(domain.JobStatusArchived), repo.job.Status)
+	assert.Empty(t, outbox.added)
+
+	assert.Error(t, service.changeStatus(context.Background(), &job, domain.JobStatusClosed, false))
+	assert.Empty(t, outbox.added)
+}
+
+func TestLoweringCapBelowApplicationsClosesJob(t *testing.T) {
+	owner := uuid.New()
+	repo := &fakeJobRepository{job: domain.Job{ID: uuid.New(), Status: string(domain.JobStatusOpen), CreatedBy: owner}, count: 3}
+	outbox := &fakeOutbox{}
+	service := &JobService{jobRepo: repo, jobSkillRepo: emptyJobSkillRepository{}, screeningRepo: emptyScreeningRepository{}, uow: passthroughUnitOfWork{}, outbox: outbox}
+
+	max := 5
+	job, err := service.UpdateJob(context.Background(), repo.job.ID, domain.UpdateJobRequest{MaxApplications: &max}, owner)
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.JobStatusOpen), job.Status)
+	assert.Empty(t, outbox.added)
+
+	max = 3
+	job, err = service.UpdateJob(context.Background(), repo.job.ID, domain.UpdateJobRequest{MaxApplications: &max}, owner)
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.JobStatusClosed), job.Status)
+	assert.Equal(t, []string{events.JobStatusChanged, events.JobClosed}, outbox.added)
+}
+
+func TestDeletingOpenJobClosesItsApplications(t *testing.T) {
+	for status, want := range map[domain.JobStatus][]string{
+		domain.JobStatusOpen:   {events.JobClosed},
+		domain.JobStatusPaused: {events.JobClosed},
+		domain.JobStatusDraft:  nil,
+		domain.JobStatusClosed: nil,
+	} {
+		owner := uuid.New()
+		repo := &fakeJobRepository{job: domain.Job{ID: uuid.New(), Status: string(status), CreatedBy: owner}}
+		outbox := &fakeOutbox{}
+		service := &JobService{jobRepo: repo, jobSkillRepo: &fakeJobSkillRepository{}, screeningRepo: emptyScreeningRepository{}, uow: passthroughUnitOfWork{}, outbox: outbox}
+
+		require.NoError(t, service.DeleteJob(context.Background(), repo.job.ID, owner))
+		assert.True(t, repo.deleted)
+		assert.Equal(t, want, outbox.added, status)
+
+		// An application that was still in flight is ignored.
+		require.NoError(t, service.HandleDomainEvent(context.Background(), applicationSubmitted(t, repo.job.ID)))
+	}
+}
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type JobScheduler struct {
+	jobService *JobService
+	interval   time.Duration
+}
+
+func NewJobScheduler(jobService *JobService, interval time.Duration) *JobScheduler {
+	return &JobScheduler{
+		jobService: jobService,
+		interval:   interval,
+	}
+}
+
+func (s *JobScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx)
+		}
+	}
+}
+
+func (s *JobScheduler) run(ctx context.Context) {
+	if err := s.jobService.RunScheduledTransitions(ctx); err != nil {
+		slog.ErrorContext(ctx, "Job scheduler run failed", "error", err)
+	}
+}
@@ -3,32 +3,55 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"text/template"
 	"time"
 
 	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/database"
+	"recruitment-system/shared/events"
+	"recruitment-system/shared/gatewayauth"
+	"recruitment-system/shared/httpclient"
 	"recruitment-system/shared/utils"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type JobService struct {
-	jobRepo      domain.JobRepository
-	skillRepo    domain.SkillRepository
-	jobSkillRepo domain.JobSkillRepository
-	authClient   domain.AuthServiceClient
+	jobRepo       domain.JobRepository
+	skillRepo     domain.SkillRepository
+	jobSkillRepo  domain.JobSkillRepository
+	screeningRepo domain.ScreeningQuestionRepository
+	rateRepo      domain.ExchangeRateRepository
+	geocoder      domain.Geocoder
+	uow           database.UnitOfWork
+	authClient    domain.AuthServiceClient
+	outbox        domain.EventOutbox
 }
 
 func NewJobService(
 	jobRepo domain.JobRepository,
 	skillRepo domain.SkillRepository,
 	jobSkillRepo domain.JobSkillRepository,
+	screeningRepo domain.ScreeningQuestionRepository,
+	rateRepo domain.ExchangeRateRepository,
+	geocoder domain.Geocoder,
+	uow database.UnitOfWork,
 	authClient domain.AuthServiceClient,
+	outbox domain.EventOutbox,
 ) *JobService {
 	return &JobService{
-		jobRepo:      jobRepo,
-		skillRepo:    skillRepo,
-		jobSkillRepo: jobSkillRepo,
-		authClient:   authClient,
+		jobRepo:       jobRepo,
+		skillRepo:     skillRepo,
+		jobSkillRepo:  jobSkillRepo,
+		screeningRepo: screeningRepo,
+		rateRepo:      rateRepo,
+		geocoder:      geocoder,
+		uow:           uow,
+		authClient:    authClient,
+		outbox:        outbox,
 	}
 }
 
@@ -45,61 +68,128 @@ func (s *JobService) CreateJob(ctx context.Context, req domain.CreateJobRequest,
 		return nil, errors.New("minimum salary cannot be greater than maximum salary")
 	}
 
-	if len(req.Skills) > 0 {
-		skillIDs := make([]uuid.UUID, len(req.Skills))
-		for i, skill := range req.Skills {
-			skillIDs[i] = skill.SkillID
-		}
+	if err := s.validateJobSkills(ctx, req.Skills); err != nil {
+		return nil, err
+	}
 
-		skills, err := s.skillRepo.GetByIDs(ctx, skillIDs)
-		if err != nil {
-			return nil, err
-		}
+	if err := validateSchedule(req.PublishAt, req.CloseAt); err != nil {
+		return nil, err
+	}
 
-		if len(skills) != len(skillIDs) {
-			return nil, errors.New("one or more skills not found")
-		}
+	if err := validateRejectionMessage(req.RejectionMessage); err != nil {
+		return nil, err
 	}
 
-	job := &domain.Job{
-		ID:           uuid.New(),
-		Title:        utils.SanitizeString(req.Title),
-		Description:  utils.SanitizeString(req.Description),
-		Requirements: utils.SanitizeString(req.Requirements),
-		Location:     utils.SanitizeString(req.Location),
-		SalaryMin:    req.SalaryMin,
-		SalaryMax:    req.SalaryMax,
-		Status:       string(domain.JobStatusOpen),
-		CreatedBy:    createdBy,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return nil, errors.New("latitude and longitude must be provided together")
 	}
 
-	if err := s.jobRepo.Create(ctx, job); err != nil {
+	status := domain.JobStatusOpen
+	if req.Status != "" {
+		status = domain.JobStatus(req.Status)
+	}
+	if status == domain.JobStatusOpen && req.PublishAt != nil && req.PublishAt.After(time.Now()) {
+		status = domain.JobStatusScheduled
+	}
+
+	jobID := uuid.New()
+	questions, err := buildScreeningQuestions(jobID, req.ScreeningQuestions)
+	if err != nil {
 		return nil, err
 	}
 
-	if len(req.Skills) > 0 {
-		jobSkills := make([]domain.JobSkill, len(req.Skills))
-		for i, skillReq := range req.Skills {
-			jobSkills[i] = domain.JobSkill{
-				ID:            uuid.New(),
-				JobID:         job.ID,
-				SkillID:       skillReq.SkillID,
-				RequiredLevel: skillReq.RequiredLevel,
-				IsRequired:    skillReq.IsRequired,
-				CreatedAt:     time.Now(),
-			}
+	job := &domain.Job{
+		ID:              jobID,
+		Title:           utils.SanitizeString(req.Title),
+		Description:     utils.SanitizeString(req.Description),
+		Requirements:    utils.SanitizeString(req.Requirements),
+		Location:        utils.SanitizeString(req.Location),
+		Category:        utils.SanitizeString(req.Category),
+		WorkMode:        req.WorkMode,
+		EmploymentType:  req.EmploymentType,
+		Seniority:       req.Seniority,
+		SalaryMin:       req.SalaryMin,
+		SalaryMax:       req.SalaryMax,
+		SalaryCurrency:  domain.DefaultSalaryCurrency,
+		SalaryPeriod:    domain.DefaultSalaryPeriod,
+		SalaryType:      domain.DefaultSalaryType,
+		Status:          string(status),
+		PublishAt:       req.PublishAt,
+		CloseAt:         req.CloseAt,
+		MaxApplications: req.MaxApplications,
+		ClosePolicy:     events.ClosePolicyNotify,
+		CreatedBy:       createdBy,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	if req.SalaryCurrency != "" {
+		job.SalaryCurrency = strings.ToUpper(req.SalaryCurrency)
+	}
+	if req.SalaryPeriod != "" {
+		job.SalaryPeriod = req.SalaryPeriod
+	}
+	if req.SalaryType != "" {
+		job.SalaryType = req.SalaryType
+	}
+	if req.SalaryHidden != nil {
+		job.SalaryHidden = *req.SalaryHidden
+	}
+	if req.ClosePolicy != "" {
+		job.ClosePolicy = req.ClosePolicy
+	}
+	job.RejectionMessage = strings.TrimSpace(req.RejectionMessage)
+
+	if status == domain.JobStatusOpen {
+		openedAt := job.CreatedAt
+		job.OpenedAt = &openedAt
+	}
+
+	job.Country = strings.ToUpper(req.Country)
+	job.State = utils.SanitizeString(req.State)
+	job.City = utils.SanitizeString(req.City)
+	job.Latitude = req.Latitude
+	job.Longitude = req.Longitude
+	job.IsRemote = req.WorkMode == domain.WorkModeRemote
+	if req.IsRemote != nil {
+		job.IsRemote = *req.IsRemote
+	}
+	s.resolveLocation(job)
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.jobRepo.Create(ctx, job); err != nil {
+			return err
 		}
 
-		if err := s.jobSkillRepo.CreateBatch(ctx, jobSkills); err != nil {
-			return nil, err
+		if err := s.jobSkillRepo.CreateBatch(ctx, buildJobSkills(job.ID, req.Skills)); err != nil {
+			return err
 		}
+
+		if err := s.screeningRepo.CreateBatch(ctx, questions); err != nil {
+			return err
+		}
+
+		return s.outbox.Add(ctx, events.JobCreated, job.ID, events.JobPayload{
+			JobID:     job.ID,
+			Title:     job.Title,
+			Status:    job.Status,
+			CreatedBy: job.CreatedBy,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
+	countStatusChange(status)
 
 	return s.GetJobByID(ctx, job.ID)
 }
 
+// GetJobsByIDs returns the jobs found, without skills or screening
+// questions.
+func (s *JobService) GetJobsByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Job, error) {
+	return s.jobRepo.GetByIDs(ctx, ids)
+}
+
 func (s *JobService) GetJobByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
 	job, err := s.jobRepo.GetByID(ctx, id)
 	if err != nil {
@@ -112,19 +202,39 @@ func (s *JobService) GetJobByID(ctx context.Context, id uuid.UUID) (*domain.Job,
 	}
 
 	job.Skills = jobSkills
+
+	questions, err := s.screeningRepo.GetByJobID(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	job.ScreeningQuestions = questions
 	return job, nil
 }
 
 func (s *JobService) UpdateJob(ctx context.Context, id uuid.UUID, req domain.UpdateJobRequest, userID uuid.UUID) (*domain.Job, error) {
-	job, err := s.jobRepo.GetByID(ctx, id)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		job, err := s.jobRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if job.CreatedBy != userID {
+			return errors.New("you can only update jobs you created")
+		}
+		return s.applyJobUpdate(ctx, job, req)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if job.CreatedBy != userID {
-		return nil, errors.New("you can only update jobs you created")
-	}
+	return s.GetJobByID(ctx, id)
+}
 
+// applyJobUpdate writes req over the locked job. Lowering the application
+// cap to or below the applications already received closes the job, since
+// no further application.submitted event would.
+func (s *JobService) applyJobUpdate(ctx context.Context, job *domain.Job, req domain.UpdateJobRequest) error {
 	if req.Title != "" {
 		job.Title = utils.SanitizeString(req.Title)
 	}
@@ -137,24 +247,102 @@ func (s *JobService) UpdateJob(ctx context.Context, id uuid.UUID, req domain.Upd
 	if req.Location != "" {
 		job.Location = utils.SanitizeString(req.Location)
 	}
+	if req.Category != "" {
+		job.Category = utils.SanitizeString(req.Category)
+	}
+	if req.WorkMode != "" {
+		job.WorkMode = req.WorkMode
+	}
+	if req.EmploymentType != "" {
+		job.EmploymentType = req.EmploymentType
+	}
+	if req.Seniority != "" {
+		job.Seniority = req.Seniority
+	}
 	if req.SalaryMin != nil {
 		job.SalaryMin = req.SalaryMin
 	}
 	if req.SalaryMax != nil {
 		job.SalaryMax = req.SalaryMax
 	}
+	if req.SalaryCurrency != "" {
+		job.SalaryCurrency = strings.ToUpper(req.SalaryCurrency)
+	}
+	if req.SalaryPeriod != "" {
+		job.SalaryPeriod = req.SalaryPeriod
+	}
+	if req.SalaryType != "" {
+		job.SalaryType = req.SalaryType
+	}
+	if req.SalaryHidden != nil {
+		job.SalaryHidden = *req.SalaryHidden
+	}
+	if req.PublishAt != nil {
+		job.PublishAt = req.PublishAt
+	}
+	if req.CloseAt != nil {
+		job.CloseAt = req.CloseAt
+	}
+	if req.MaxApplications != nil {
+		job.MaxApplications = req.MaxApplications
+	}
+	if req.ClosePolicy != "" {
+		job.ClosePolicy = req.ClosePolicy
+	}
+	if req.RejectionMessage != nil {
+		if err := validateRejectionMessage(*req.RejectionMessage); err != nil {
+			return err
+		}
+		job.RejectionMessage = strings.TrimSpace(*req.RejectionMessage)
+	}
+
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return errors.New("latitude and longitude must be provided together")
+	}
+	if req.Country != "" || req.State != "" || req.City != "" {
+		// A new place invalidates coordinates geocoded for the old one.
+		job.Latitude, job.Longitude = nil, nil
+	}
+	if req.Country != "" {
+		job.Country = strings.ToUpper(req.Country)
+	}
+	if req.State != "" {
+		job.State = utils.SanitizeString(req.State)
+	}
+	if req.City != "" {
+		job.City = utils.SanitizeString(req.City)
+	}
+	if req.Latitude != nil {
+		job.Latitude, job.Longitude = req.Latitude, req.Longitude
+	}
+	if req.WorkMode != "" {
+		job.IsRemote = req.WorkMode == domain.WorkModeRemote
+	}
+	if req.IsRemote != nil {
+		job.IsRemote = *req.IsRemote
+	}
+	s.resolveLocation(job)
+
+	if req.PublishAt != nil || req.CloseAt != nil {
+		if err := validateSchedule(job.PublishAt, job.CloseAt); err != nil {
+			return err
+		}
+	}
 
 	if job.SalaryMin != nil && job.SalaryMax != nil && *job.SalaryMin > *job.SalaryMax {
-		return nil, errors.New("minimum salary cannot be greater than maximum salary")
+		return errors.New("minimum salary cannot be greater than maximum salary")
 	}
 
 	job.UpdatedAt = time.Now()
 
 	if err := s.jobRepo.Update(ctx, job); err != nil {
-		return nil, err
+		return err
 	}
 
-	return s.GetJobByID(ctx, job.ID)
+	if req.MaxApplications != nil && job.IsOpen() && job.ApplicationCount >= *job.MaxApplications {
+		return s.changeStatus(ctx, job, domain.JobStatusClosed, true)
+	}
+	return nil
 }
 
 func (s *JobService) UpdateJobStatus(ctx context.Context, id uuid.UUID, status string, userID uuid.UUID) error {
@@ -171,36 +359,288 @@ func (s *JobService) UpdateJobStatus(ctx context.Context, id uuid.UUID, status s
 		return errors.New("invalid job status")
 	}
 
-	return s.jobRepo.UpdateStatus(ctx, id, status)
+	if job.Status == status {
+		return nil
+	}
+
+	target := domain.JobStatus(status)
+	if !job.CanTransitionTo(target) {
+		return fmt.Errorf("cannot change job status from %s to %s", job.Status, status)
+	}
+
+	if target == domain.JobStatusScheduled && (job.PublishAt == nil || !job.PublishAt.After(time.Now())) {
+		return errors.New("a future publish date is required to schedule a job")
+	}
+
+	return s.changeStatus(ctx, job, target, false)
 }
 
-func (s *JobService) DeleteJob(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
-	job, err := s.jobRepo.GetByID(ctx, id)
+// RunScheduledTransitions publishes scheduled jobs whose publish date has
+// passed and closes jobs that reached their close date. Jobs reaching their
+// application cap are closed as applications arrive (see HandleDomainEvent).
+func (s *JobService) RunScheduledTransitions(ctx context.Context) error {
+	now := time.Now()
+
+	toPublish, err := s.jobRepo.GetDueForPublish(ctx, now)
 	if err != nil {
 		return err
 	}
+	if err := s.setStatus(ctx, toPublish, domain.JobStatusOpen); err != nil {
+		return err
+	}
 
-	if job.CreatedBy != userID {
-		return errors.New("you can only delete jobs you created")
+	toClose, err := s.jobRepo.GetDueForClose(ctx, now)
+	if err != nil {
+		return err
 	}
+	return s.setStatus(ctx, toClose, domain.JobStatusClosed)
+}
 
-	if err := s.jobSkillRepo.DeleteByJobID(ctx, id); err != nil {
+// setStatus applies a scheduled transition to each job.
+func (s *JobService) setStatus(ctx context.Context, jobs []*domain.Job, status domain.JobStatus) error {
+	for _, job := range jobs {
+		if err := s.changeStatus(ctx, job, status, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeStatus updates the job status and records the change in the outbox.
+// Scheduled marks transitions the owner did not trigger themselves. The
+// update only applies if the job still has the status it was read with: a
+// scheduled transition that lost the race to the owner or to another
+// replica is dropped, while the owner gets an error.
+func (s *JobService) changeStatus(ctx context.Context, job *domain.Job, status domain.JobStatus, scheduled bool) error {
+	changed := false
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		updated, err := s.jobRepo.UpdateStatus(ctx, job.ID, job.Status, string(status))
+		if err != nil {
+			return err
+		}
+		if !updated {
+			if scheduled {
+				return nil
+			}
+			return errors.New("job status was changed in the meantime, reload the job and try again")
+		}
+		changed = true
+
+		err = s.outbox.Add(ctx, events.JobStatusChanged, job.ID, events.JobStatusChangedPayload{
+			JobID:     job.ID,
+			Title:     job.Title,
+			CreatedBy: job.CreatedBy,
+			From:      job.Status,
+			To:        string(status),
+			Scheduled: scheduled,
+		})
+		if err != nil {
+			return err
+		}
+
+		if status != domain.JobStatusClosed {
+			return nil
+		}
+		return s.addJobClosed(ctx, job)
+	})
+	if err != nil || !changed {
 		return err
 	}
 
-	return s.jobRepo.Delete(ctx, id)
+	countStatusChange(status)
+	return nil
+}
+
+// addJobClosed records the event that makes candidate-service apply the
+// job's close policy to its open applications.
+func (s *JobService) addJobClosed(ctx context.Context, job *domain.Job) error {
+	return s.outbox.Add(ctx, events.JobClosed, job.ID, events.JobClosedPayload{
+		JobID:            job.ID,
+		Title:            job.Title,
+		CreatedBy:        job.CreatedBy,
+		ClosePolicy:      job.ClosePolicy,
+		RejectionMessage: job.RejectionMessage,
+	})
+}
+
+// DeleteJob removes the job with its skills and screening questions. An
+// open or paused job is closed on the way out so its applications get the
+// close policy like any other closed job's.
+func (s *JobService) DeleteJob(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		job, err := s.jobRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if job.CreatedBy != userID {
+			return errors.New("you can only delete jobs you created")
+		}
+
+		if job.IsOpen() || job.Status == string(domain.JobStatusPaused) {
+			if err := s.addJobClosed(ctx, job); err != nil {
+				return err
+			}
+		}
+
+		if err := s.jobSkillRepo.DeleteByJobID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.screeningRepo.DeleteByJobID(ctx, id); err != nil {
+			return err
+		}
+
+		return s.jobRepo.Delete(ctx, id)
+	})
+}
+
+func (s *JobService) AddJobSkill(ctx context.Context, jobID uuid.UUID, req domain.CreateJobSkillRequest, userID uuid.UUID) (*domain.Job, error) {
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.lockOwnedJob(ctx, jobID, userID); err != nil {
+			return err
+		}
+
+		if err := s.validateJobSkills(ctx, []domain.CreateJobSkillRequest{req}); err != nil {
+			return err
+		}
+
+		if _, err := s.jobSkillRepo.GetByJobIDAndSkillID(ctx, jobID, req.SkillID); err == nil {
+			return errors.New("skill is already required by this job")
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		return s.jobSkillRepo.CreateBatch(ctx, buildJobSkills(jobID, []domain.CreateJobSkillRequest{req}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetJobByID(ctx, jobID)
 }
 
-func (s *JobService) ListJobs(ctx context.Context, filter domain.JobListFilter, page, limit int) ([]*domain.Job, int64, error) {
-	if page < 1 {
-		page = 1
+func (s *JobService) UpdateJobSkill(ctx context.Context, jobID, skillID uuid.UUID, req domain.UpdateJobSkillRequest, userID uuid.UUID) (*domain.Job, error) {
+	if req.RequiredLevel != "" && !utils.IsValidProficiencyLevel(req.RequiredLevel) {
+		return nil, errors.New("invalid required level")
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.lockOwnedJob(ctx, jobID, userID); err != nil {
+			return err
+		}
+
+		jobSkill, err := s.jobSkillRepo.GetByJobIDAndSkillID(ctx, jobID, skillID)
+		if err != nil {
+			return errors.New("skill is not required by this job")
+		}
+
+		if req.RequiredLevel != "" {
+			jobSkill.RequiredLevel = req.RequiredLevel
+		}
+		if req.IsRequired != nil {
+			jobSkill.IsRequired = *req.IsRequired
+		}
+
+		return s.jobSkillRepo.Update(ctx, jobSkill)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetJobByID(ctx, jobID)
+}
+
+func (s *JobService) RemoveJobSkill(ctx context.Context, jobID, skillID uuid.UUID, userID uuid.UUID) (*domain.Job, error) {
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.lockOwnedJob(ctx, jobID, userID); err != nil {
+			return err
+		}
+
+		if _, err := s.jobSkillRepo.GetByJobIDAndSkillID(ctx, jobID, skillID); err != nil {
+			return errors.New("skill is not required by this job")
+		}
+
+		return s.jobSkillRepo.DeleteByJobIDAndSkillID(ctx, jobID, skillID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetJobByID(ctx, jobID)
+}
+
+func (s *JobService) ReplaceJobSkills(ctx context.Context, jobID uuid.UUID, req domain.ReplaceJobSkillsRequest, userID uuid.UUID) (*domain.Job, error) {
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.lockOwnedJob(ctx, jobID, userID); err != nil {
+			return err
+		}
+
+		if err := s.validateJobSkills(ctx, req.Skills); err != nil {
+			return err
+		}
+
+		if err := s.jobSkillRepo.DeleteByJobID(ctx, jobID); err != nil {
+			return err
+		}
+
+		return s.jobSkillRepo.CreateBatch(ctx, buildJobSkills(jobID, req.Skills))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetJobByID(ctx, jobID)
+}
+
+func (s *JobService) GetScreeningQuestions(ctx context.Context, jobID uuid.UUID, userID uuid.UUID) ([]domain.ScreeningQuestion, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
 	}
 
-	offset := utils.CalculateOffset(page, limit)
-	jobs, total, err := s.jobRepo.List(ctx, filter, offset, limit)
+	if job.CreatedBy != userID {
+		return nil, errors.New("you can only view screening questions of jobs you created")
+	}
+
+	return s.screeningRepo.GetByJobID(ctx, jobID)
+}
+
+func (s *JobService) ReplaceScreeningQuestions(ctx context.Context, jobID uuid.UUID, req domain.ReplaceScreeningQuestionsRequest, userID uuid.UUID) ([]domain.ScreeningQuestion, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.CreatedBy != userID {
+		return nil, errors.New("you can only update jobs you created")
+	}
+
+	questions, err := buildScreeningQuestions(jobID, req.Questions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.screeningRepo.DeleteByJobID(ctx, jobID); err != nil {
+			return err
+		}
+
+		return s.screeningRepo.CreateBatch(ctx, questions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.screeningRepo.GetByJobID(ctx, jobID)
+}
+
+func (s *JobService) ListJobs(ctx context.Context, filter domain.JobListFilter, page utils.PageRequest) ([]*domain.Job, int64, error) {
+	if page.Limit < 1 || page.Limit > 100 {
+		page.Limit = 10
+	}
+
+	jobs, total, err := s.jobRepo.List(ctx, filter, page)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -216,16 +656,20 @@ func (s *JobService) ListJobs(ctx context.Context, filter domain.JobListFilter,
 	return jobs, total, nil
 }
 
-func (s *JobService) GetJobsByCreatedBy(ctx context.Context, createdBy uuid.UUID, page, limit int) ([]*domain.Job, int64, error) {
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
+func (s *JobService) GetJobFacets(ctx context.Context, filter domain.JobListFilter, limit int) (*domain.JobFacets, error) {
+	if limit < 1 || limit > 50 {
 		limit = 10
 	}
 
-	offset := utils.CalculateOffset(page, limit)
-	jobs, total, err := s.jobRepo.GetByCreatedBy(ctx, createdBy, offset, limit)
+	return s.jobRepo.Facets(ctx, filter, limit)
+}
+
+func (s *JobService) GetJobsByCreatedBy(ctx context.Context, createdBy uuid.UUID, status string, page utils.PageRequest) ([]*domain.Job, int64, error) {
+	if page.Limit < 1 || page.Limit > 100 {
+		page.Limit = 10
+	}
+
+	jobs, total, err := s.jobRepo.GetByCreatedBy(ctx, createdBy, status, page)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -241,16 +685,12 @@ func (s *JobService) GetJobsByCreatedBy(ctx context.Context, createdBy uuid.UUID
 	return jobs, total, nil
 }
 
-func (s *JobService) ListSkills(ctx context.Context, category, search string, page, limit int) ([]*domain.Skill, int64, error) {
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+func (s *JobService) ListSkills(ctx context.Context, category, search string, page utils.PageRequest) ([]*domain.Skill, int64, error) {
+	if page.Limit < 1 || page.Limit > 100 {
+		page.Limit = 10
 	}
 
-	offset := utils.CalculateOffset(page, limit)
-	return s.skillRepo.List(ctx, category, search, offset, limit)
+	return s.skillRepo.List(ctx, category, search, page)
 }
 
 func (s *JobService) CreateSkill(ctx context.Context, name, category string) (*domain.Skill, error) {
@@ -280,10 +720,50 @@ func (s *JobService) CreateSkill(ctx context.Context, name, category string) (*d
 	return skill, nil
 }
 
+func (s *JobService) ListExchangeRates(ctx context.Context) ([]domain.ExchangeRate, error) {
+	return s.rateRepo.List(ctx)
+}
+
+func (s *JobService) UpsertExchangeRate(ctx context.Context, req domain.UpsertExchangeRateRequest, userID uuid.UUID) (*domain.ExchangeRate, error) {
+	rate := &domain.ExchangeRate{
+		ID:           uuid.New(),
+		FromCurrency: strings.ToUpper(req.FromCurrency),
+		ToCurrency:   strings.ToUpper(req.ToCurrency),
+		Rate:         req.Rate,
+		UpdatedBy:    userID,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	if rate.FromCurrency == rate.ToCurrency {
+		return nil, errors.New("exchange rate currencies must differ")
+	}
+
+	if err := s.rateRepo.Upsert(ctx, rate); err != nil {
+		return nil, err
+	}
+
+	return s.rateRepo.GetByPair(ctx, rate.FromCurrency, rate.ToCurrency)
+}
+
+func (s *JobService) DeleteExchangeRate(ctx context.Context, fromCurrency, toCurrency string) error {
+	fromCurrency, toCurrency = strings.ToUpper(fromCurrency), strings.ToUpper(toCurrency)
+	if _, err := s.rateRepo.GetByPair(ctx, fromCurrency, toCurrency); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("exchange rate not found")
+		}
+		return err
+	}
+
+	return s.rateRepo.Delete(ctx, fromCurrency, toCurrency)
+}
+
+// ValidateUserPermissions returns the caller's user, taken from the gateway
+// when the request came through it and from auth-service otherwise.
 func (s *JobService) ValidateUserPermissions(ctx context.Context, token string, requiredRole string) (*domain.UserInfo, error) {
-	userInfo, err := s.authClient.ValidateToken(ctx, token)
+	userInfo, err := s.authenticate(ctx, token)
 	if err != nil {
-		return nil, errors.New("invalid token")
+		return nil, err
 	}
 
 	if requiredRole != "" && userInfo.Role != requiredRole {
@@ -292,3 +772,197 @@ func (s *JobService) ValidateUserPermissions(ctx context.Context, token string,
 
 	return userInfo, nil
 }
+
+func (s *JobService) authenticate(ctx context.Context, token string) (*domain.UserInfo, error) {
+	if user, ok := gatewayauth.FromContext(ctx); ok {
+		return &domain.UserInfo{ID: user.ID, Email: user.Email, Role: user.Role, Name: user.Email}, nil
+	}
+
+	userInfo, err := s.authClient.ValidateToken(ctx, token)
+	if errors.Is(err, httpclient.ErrUnavailable) {
+		return nil, errors.New("authentication service unavailable")
+	}
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+	return userInfo, nil
+}
+
+// lockOwnedJob checks that userID owns the job and locks its row for the
+// rest of the unit of work, so concurrent edits of its skills run one after
+// the other.
+func (s *JobService) lockOwnedJob(ctx context.Context, jobID, userID uuid.UUID) error {
+	job, err := s.jobRepo.GetByIDForUpdate(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	if job.CreatedBy != userID {
+		return errors.New("you can only update jobs you created")
+	}
+
+	return nil
+}
+
+func (s *JobService) validateJobSkills(ctx context.Context, reqs []domain.CreateJobSkillRequest) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	skillIDs := make([]uuid.UUID, len(reqs))
+	seen := make(map[uuid.UUID]bool, len(reqs))
+	for i, req := range reqs {
+		if seen[req.SkillID] {
+			return errors.New("each skill can only be listed once")
+		}
+		seen[req.SkillID] = true
+		skillIDs[i] = req.SkillID
+	}
+
+	skills, err := s.skillRepo.GetByIDs(ctx, skillIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(skills) != len(skillIDs) {
+		return errors.New("one or more skills not found")
+	}
+
+	return nil
+}
+
+func buildJobSkills(jobID uuid.UUID, reqs []domain.CreateJobSkillRequest) []domain.JobSkill {
+	jobSkills := make([]domain.JobSkill, len(reqs))
+	for i, req := range reqs {
+		jobSkills[i] = domain.JobSkill{
+			ID:            uuid.New(),
+			JobID:         jobID,
+			SkillID:       req.SkillID,
+			RequiredLevel: req.RequiredLevel,
+			IsRequired:    req.IsRequired,
+			CreatedAt:     time.Now(),
+		}
+	}
+	return jobSkills
+}
+
+// resolveLocation geocodes the job's city when no coordinates were given
+// and fills in the free-text location for clients that still read it.
+func (s *JobService) resolveLocation(job *domain.Job) {
+	if job.City != "" && job.Latitude == nil {
+		if city, ok := s.geocoder.FindCity(job.City, job.State, job.Country); ok {
+			latitude, longitude := city.Latitude, city.Longitude
+			job.Latitude, job.Longitude = &latitude, &longitude
+			if job.State == "" {
+				job.State = city.State
+			}
+			if job.Country == "" {
+				job.Country = city.Country
+			}
+		}
+	}
+
+	if job.Location == "" {
+		parts := make([]string, 0, 2)
+		for _, part := range []string{job.City, job.State} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		job.Location = strings.Join(parts, ", ")
+	}
+}
+
+// ResolveCity looks a city up in the gazetteer for radius searches.
+func (s *JobService) ResolveCity(name, state, country string) (*domain.GeoPoint, error) {
+	city, ok := s.geocoder.FindCity(name, state, country)
+	if !ok {
+		return nil, fmt.Errorf("unknown city %q", name)
+	}
+	return &city.GeoPoint, nil
+}
+
+func validateSchedule(publishAt, closeAt *time.Time) error {
+	if closeAt == nil {
+		return nil
+	}
+	if !closeAt.After(time.Now()) {
+		return errors.New("close date must be in the future")
+	}
+	if publishAt != nil && !closeAt.After(*publishAt) {
+		return errors.New("close date must be after publish date")
+	}
+	return nil
+}
+
+// validateRejectionMessage checks the template candidate-service renders
+// when the job closes under the reject policy.
+func validateRejectionMessage(message string) error {
+	if _, err := template.New("rejection").Parse(message); err != nil {
+		return fmt.Errorf("invalid rejection message: %w", err)
+	}
+	return nil
+}
+
+func buildScreeningQuestions(jobID uuid.UUID, reqs []domain.CreateScreeningQuestionRequest) ([]domain.ScreeningQuestion, error) {
+	questions := make([]domain.ScreeningQuestion, 0, len(reqs))
+	for i, req := range reqs {
+		if utils.IsEmptyOrWhitespace(req.Question) {
+			return nil, fmt.Errorf("question %d: text is required", i+1)
+		}
+
+		question := domain.ScreeningQuestion{
+			ID:           uuid.New(),
+			JobID:        jobID,
+			Question:     utils.SanitizeString(req.Question),
+			QuestionType: req.QuestionType,
+			IsRequired:   req.IsRequired,
+			Position:     i,
+			CreatedAt:    time.Now(),
+		}
+
+		switch domain.ScreeningQuestionType(req.QuestionType) {
+		case domain.QuestionTypeText:
+		case domain.QuestionTypeYesNo:
+			question.KnockoutAnswers = req.KnockoutAnswers
+			for _, answer := range req.KnockoutAnswers {
+				if answer != "yes" && answer != "no" {
+					return nil, fmt.Errorf("question %d: knockout answers must be yes or no", i+1)
+				}
+			}
+		case domain.QuestionTypeSingleChoice, domain.QuestionTypeMultiChoice:
+			options := make([]string, 0, len(req.Options))
+			seen := make(map[string]bool)
+			for _, option := range req.Options {
+				option = utils.SanitizeString(option)
+				if option == "" || seen[option] {
+					continue
+				}
+				seen[option] = true
+				options = append(options, option)
+			}
+			if len(options) < 2 {
+				return nil, fmt.Errorf("question %d: choice questions need at least two options", i+1)
+			}
+			for _, answer := range req.KnockoutAnswers {
+				if !seen[answer] {
+					return nil, fmt.Errorf("question %d: knockout answer %q is not one of the options", i+1, answer)
+				}
+			}
+			question.Options = options
+			question.KnockoutAnswers = req.KnockoutAnswers
+		case domain.QuestionTypeNumeric:
+			if req.MinValue != nil && req.MaxValue != nil && *req.MinValue > *req.MaxValue {
+				return nil, fmt.Errorf("question %d: minimum value cannot be greater than maximum value", i+1)
+			}
+			question.MinValue = req.MinValue
+			question.MaxValue = req.MaxValue
+		default:
+			return nil, fmt.Errorf("question %d: invalid question type", i+1)
+		}
+
+		questions = append(questions, question)
+	}
+
+	return questions, nil
+}
@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"recruitment-system/services/job-service/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// txUnitOfWork marks the context it runs fn with, so fakes can tell writes
+// made inside a unit of work from writes made outside one.
+type txUnitOfWork struct {
+	runs int
+}
+
+func (u *txUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.runs++
+	return fn(context.WithValue(ctx, txKey{}, true))
+}
+
+type fakeSkillRepository struct {
+	domain.SkillRepository
+	known map[uuid.UUID]bool
+}
+
+func (r fakeSkillRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.Skill, error) {
+	var skills []domain.Skill
+	for _, id := range ids {
+		if r.known[id] {
+			skills = append(skills, domain.Skill{ID: id})
+		}
+	}
+	return skills, nil
+}
+
+type fakeJobSkillRepository struct {
+	domain.JobSkillRepository
+	skills        map[uuid.UUID]domain.JobSkill
+	writesOutside int
+}
+
+func (r *fakeJobSkillRepository) write(ctx context.Context) {
+	if ctx.Value(txKey{}) == nil {
+		r.writesOutside++
+	}
+}
+
+func (r *fakeJobSkillRepository) GetByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.JobSkill, error) {
+	var skills []domain.JobSkill
+	for _, skill := range r.skills {
+		skills = append(skills, skill)
+	}
+	return skills, nil
+}
+
+func (r *fakeJobSkillRepository) GetByJobIDAndSkillID(ctx context.Context, jobID, skillID uuid.UUID) (*domain.JobSkill, error) {
+	skill, ok := r.skills[skillID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &skill, nil
+}
+
+func (r *fakeJobSkillRepository) CreateBatch(ctx context.Context, jobSkills []domain.JobSkill) error {
+	r.write(ctx)
+	for _, skill := range jobSkills {
+		r.skills[skill.SkillID] = skill
+	}
+	return nil
+}
+
+func (r *fakeJobSkillRepository) Update(ctx context.Context, jobSkill *domain.JobSkill) error {
+	r.write(ctx)
+	r.skills[jobSkill.SkillID] = *jobSkill
+	return nil
+}
+
+func (r *fakeJobSkillRepository) DeleteByJobIDAndSkillID(ctx context.Context, jobID, skillID uuid.UUID) error {
+	r.write(ctx)
+	delete(r.skills, skillID)
+	return nil
+}
+
+func (r *fakeJobSkillRepository) DeleteByJobID(ctx context.Context, jobID uuid.UUID) error {
+	r.write(ctx)
+	r.skills = map[uuid.UUID]domain.JobSkill{}
+	return nil
+}
+
+func newSkillTestService(owner uuid.UUID, known ...uuid.UUID) (*JobService, *fakeJobRepository, *fakeJobSkillRepository, *txUnitOfWork) {
+	jobs := &fakeJobRepository{job: domain.Job{ID: uuid.New(), CreatedBy: owner}}
+	skills := fakeSkillRepository{known: map[uuid.UUID]bool{}}
+	for _, id := range known {
+		skills.known[id] = true
+	}
+	jobSkills := &fakeJobSkillRepository{skills: map[uuid.UUID]domain.JobSkill{}}
+	uow := &txUnitOfWork{}
+	service := &JobService{jobRepo: jobs, skillRepo: skills, jobSkillRepo: jobSkills, screeningRepo: emptyScreeningRepository{}, uow: uow}
+	return service, jobs, jobSkills, uow
+}
+
+func TestJobSkillEditsRunInOneLockedUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	owner, golang, sql := uuid.New(), uuid.New(), uuid.New()
+	service, jobs, jobSkills, uow := newSkillTestService(owner, golang, sql)
+	jobID := jobs.job.ID
+
+	job, err := service.AddJobSkill(ctx, jobID, domain.CreateJobSkillRequest{SkillID: golang, RequiredLevel: "advanced", IsRequired: true}, owner)
+	require.NoError(t, err)
+	assert.Len(t, job.Skills, 1)
+
+	optional := false
+	_, err = service.UpdateJobSkill(ctx, jobID, golang, domain.UpdateJobSkillRequest{RequiredLevel: "expert", IsRequired: &optional}, owner)
+	require.NoError(t, err)
+	assert.Equal(t, "expert", jobSkills.skills[golang].RequiredLevel)
+	assert.False(t, jobSkills.skills[golang].IsRequired)
+
+	_, err = service.ReplaceJobSkills(ctx, jobID, domain.ReplaceJobSkillsRequest{Skills: []domain.CreateJobSkillRequest{
+		{SkillID: golang, RequiredLevel: "beginner"},
+		{SkillID: sql, RequiredLevel: "intermediate"},
+	}}, owner)
+	require.NoError(t, err)
+	assert.Len(t, jobSkills.skills, 2)
+
+	job, err = service.RemoveJobSkill(ctx, jobID, sql, owner)
+	require.NoError(t, err)
+	assert.Len(t, job.Skills, 1)
+
+	assert.Equal(t, 4, uow.runs)
+	assert.Equal(t, 4, jobs.locks, "every edit locks the job before checking its owner")
+	assert.Zero(t, jobSkills.writesOutside)
+}
+
+func TestJobSkillEditsAreRejectedWithoutWrites(t *testing.T) {
+	ctx := context.Background()
+	owner, golang := uuid.New(), uuid.New()
+	service, jobs, jobSkills, _ := newSkillTestService(owner, golang)
+	jobID := jobs.job.ID
+
+	_, err := service.AddJobSkill(ctx, jobID, domain.CreateJobSkillRequest{SkillID: golang, RequiredLevel: "advanced"}, uuid.New())
+	assert.EqualError(t, err, "you can only update jobs you created")
+
+	_, err = service.AddJobSkill(ctx, jobID, domain.CreateJobSkillRequest{SkillID: uuid.New(), RequiredLevel: "advanced"}, owner)
+	assert.EqualError(t, err, "one or more skills not found")
+
+	_, err = service.ReplaceJobSkills(ctx, jobID, domain.ReplaceJobSkillsRequest{Skills: []domain.CreateJobSkillRequest{
+		{SkillID: golang, RequiredLevel: "beginner"},
+		{SkillID: golang, RequiredLevel: "expert"},
+	}}, owner)
+	assert.EqualError(t, err, "each skill can only be listed once")
+
+	_, err = service.UpdateJobSkill(ctx, jobID, golang, domain.UpdateJobSkillRequest{RequiredLevel: "expert"}, owner)
+	assert.EqualError(t, err, "skill is not required by this job")
+
+	_, err = service.RemoveJobSkill(ctx, jobID, golang, uuid.New())
+	assert.EqualError(t, err, "you can only update jobs you created")
+
+	assert.Empty(t, jobSkills.skills)
+	assert.Zero(t, jobSkills.writesOutside)
+
+	_, err = service.AddJobSkill(ctx, jobID, domain.CreateJobSkillRequest{SkillID: golang, RequiredLevel: "advanced"}, owner)
+	require.NoError(t, err)
+	_, err = service.AddJobSkill(ctx, jobID, domain.CreateJobSkillRequest{SkillID: golang, RequiredLevel: "expert"}, owner)
+	assert.EqualError(t, err, "skill is already required by this job")
+}
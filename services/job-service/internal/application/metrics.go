@@ -0,0 +1,31 @@
+package application
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"recruitment-system/services/job-service/internal/domain"
+)
+
+var (
+	jobsOpened = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "recruitment",
+		Name:      "jobs_opened_total",
+		Help:      "Jobs opened, on creation or by a status change.",
+	})
+
+	jobsClosed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "recruitment",
+		Name:      "jobs_closed_total",
+		Help:      "Jobs closed, by their owner or by the scheduler.",
+	})
+)
+
+func countStatusChange(status domain.JobStatus) {
+	switch status {
+	case domain.JobStatusOpen:
+		jobsOpened.Inc()
+	case domain.JobStatusClosed:
+		jobsClosed.Inc()
+	}
+}
@@ -0,0 +1,336 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxSavedSearchesPerUser = 20
+	maxJobsPerAlert         = 20
+
+	// alertCommitMargin keeps a digest's window behind the clock. Jobs get
+	// their opened_at before the transaction opening them commits, so a job
+	// stamped just before a run may only become visible after it; the margin
+	// leaves such jobs to the next digest instead of skipping them.
+	alertCommitMargin = 5 * time.Minute
+)
+
+type SavedSearchService struct {
+	searchRepo    domain.SavedSearchRepository
+	jobService    *JobService
+	notifier      domain.AlertNotifier
+	publicBaseURL string
+}
+
+func NewSavedSearchService(
+	searchRepo domain.SavedSearchRepository,
+	jobService *JobService,
+	notifier domain.AlertNotifier,
+	publicBaseURL string,
+) *SavedSearchService {
+	return &SavedSearchService{
+		searchRepo:    searchRepo,
+		jobService:    jobService,
+		notifier:      notifier,
+		publicBaseURL: strings.TrimRight(publicBaseURL, "/"),
+	}
+}
+
+func (s *SavedSearchService) CreateSavedSearch(ctx context.Context, req domain.CreateSavedSearchRequest, user *domain.UserInfo) (*domain.SavedSearch, error) {
+	if utils.IsEmptyOrWhitespace(req.Name) {
+		return nil, errors.New("name is required")
+	}
+
+	existing, err := s.searchRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxSavedSearchesPerUser {
+		return nil, fmt.Errorf("at most %d saved searches are allowed", maxSavedSearchesPerUser)
+	}
+
+	filter, err := normalizeSavedFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newUnsubscribeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	search := &domain.SavedSearch{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		Email:            user.Email,
+		Name:             utils.SanitizeString(req.Name),
+		Filter:           filter,
+		Frequency:        domain.AlertFrequencyDaily,
+		AlertsEnabled:    true,
+		UnsubscribeToken: token,
+		LastAlertedAt:    now,
+		AlertedThrough:   now,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if req.Frequency != "" {
+		search.Frequency = req.Frequency
+	}
+	if req.AlertsEnabled != nil {
+		search.AlertsEnabled = *req.AlertsEnabled
+	}
+
+	if err := s.searchRepo.Create(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+func (s *SavedSearchService) ListSavedSearches(ctx context.Context, userID uuid.UUID) ([]domain.SavedSearch, error) {
+	return s.searchRepo.ListByUserID(ctx, userID)
+}
+
+func (s *SavedSearchService) GetSavedSearch(ctx context.Context, id, userID uuid.UUID) (*domain.SavedSearch, error) {
+	search, err := s.searchRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if search.UserID != userID {
+		return nil, errors.New("you can only access your own saved searches")
+	}
+
+	return search, nil
+}
+
+func (s *SavedSearchService) UpdateSavedSearch(ctx context.Context, id uuid.UUID, req domain.UpdateSavedSearchRequest, userID uuid.UUID) (*domain.SavedSearch, error) {
+	search, err := s.GetSavedSearch(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		search.Name = utils.SanitizeString(req.Name)
+	}
+	if req.Filter != nil {
+		filter, err := normalizeSavedFilter(*req.Filter)
+		if err != nil {
+			return nil, err
+		}
+		search.Filter = filter
+	}
+	if req.Frequency != "" {
+		search.Frequency = req.Frequency
+	}
+	if req.AlertsEnabled != nil {
+		// Re-enabling alerts starts a fresh window so the first digest does
+		// not replay every job opened while they were off.
+		if *req.AlertsEnabled && !search.AlertsEnabled {
+			search.LastAlertedAt = time.Now()
+			search.AlertedThrough = search.LastAlertedAt
+		}
+		search.AlertsEnabled = *req.AlertsEnabled
+	}
+	search.UpdatedAt = time.Now()
+
+	if err := s.searchRepo.Update(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+func (s *SavedSearchService) DeleteSavedSearch(ctx context.Context, id, userID uuid.UUID) error {
+	if _, err := s.GetSavedSearch(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.searchRepo.Delete(ctx, id)
+}
+
+// Unsubscribe turns alerts off for the search owning token. It backs the
+// link sent in every alert, so it needs no authentication.
+func (s *SavedSearchService) Unsubscribe(ctx context.Context, token string) (*domain.SavedSearch, error) {
+	if token == "" {
+		return nil, errors.New("unsubscribe token is required")
+	}
+
+	search, err := s.searchRepo.GetByUnsubscribeToken(ctx, token)
+	if err != nil {
+		return nil, errors.New("invalid unsubscribe token")
+	}
+
+	if !search.AlertsEnabled {
+		return search, nil
+	}
+
+	search.AlertsEnabled = false
+	search.UpdatedAt = time.Now()
+	if err := s.searchRepo.Update(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// RunAlerts sends a digest for every saved search that is due, listing the
+// jobs opened since its previous alert, oldest first. A digest lists at most
+// maxJobsPerAlert jobs; the rest are left for the next one. A search whose
+// delivery fails keeps its window and is retried on the next run. Windows
+// end alertCommitMargin before the run.
+func (s *SavedSearchService) RunAlerts(ctx context.Context) error {
+	now := time.Now()
+	until := now.Add(-alertCommitMargin)
+
+	searches, err := s.searchRepo.GetDueForAlert(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range searches {
+		search := &searches[i]
+
+		filter := search.Filter
+		filter.Status = string(domain.JobStatusOpen)
+		filter.OpenedAfter = &search.AlertedThrough
+		filter.OpenedBefore = &until
+
+		jobs, _, err := s.jobService.ListJobs(ctx, filter, utils.PageRequest{
+			Limit: maxJobsPerAlert + 1,
+			Sort:  []utils.SortField{{Column: "jobs.opened_at"}},
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Saved search failed to match jobs", "saved_search_id", search.ID, "error", err)
+			continue
+		}
+		jobs, through := capAlert(jobs, until)
+
+		if len(jobs) > 0 {
+			err := s.notifier.SendJobAlert(ctx, domain.JobAlert{
+				SearchID:       search.ID,
+				SearchName:     search.Name,
+				UserID:         search.UserID,
+				Email:          search.Email,
+				Frequency:      search.Frequency,
+				Jobs:           jobs,
+				SearchURL:      s.publicBaseURL + "/api/v1/saved-searches/" + search.ID.String(),
+				UnsubscribeURL: s.publicBaseURL + "/api/v1/saved-searches/unsubscribe?token=" + url.QueryEscape(search.UnsubscribeToken),
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "Saved search failed to send alert", "saved_search_id", search.ID, "error", err)
+				continue
+			}
+		}
+
+		if err := s.searchRepo.MarkAlerted(ctx, search.ID, now, through); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// capAlert trims jobs, sorted by opened_at, to maxJobsPerAlert and returns
+// how far the digest reaches, which is until when every job fits. The next
+// digest picks up the jobs opened strictly after that point, so jobs sharing
+// the opened_at of the first job left out are left out with it.
+func capAlert(jobs []*domain.Job, until time.Time) ([]*domain.Job, time.Time) {
+	if len(jobs) <= maxJobsPerAlert {
+		return jobs, until
+	}
+
+	cutoff := *jobs[maxJobsPerAlert].OpenedAt
+	sent := jobs[:maxJobsPerAlert]
+	for len(sent) > 0 && !sent[len(sent)-1].OpenedAt.Before(cutoff) {
+		sent = sent[:len(sent)-1]
+	}
+	if len(sent) == 0 {
+		// More than maxJobsPerAlert jobs opened at the same instant; the
+		// ones past the cap are dropped rather than stalling the search.
+		return jobs[:maxJobsPerAlert], cutoff
+	}
+	return sent, *sent[len(sent)-1].OpenedAt
+}
+
+func (s *SavedSearchService) ValidateUserPermissions(ctx context.Context, token string, requiredRole string) (*domain.UserInfo, error) {
+	return s.jobService.ValidateUserPermissions(ctx, token, requiredRole)
+}
+
+// normalizeSavedFilter applies the same defaults and bounds as the job
+// listing query parameters. Status and the opened window are always chosen
+// by the alert run, never stored.
+func normalizeSavedFilter(filter domain.JobListFilter) (domain.JobListFilter, error) {
+	filter.Status = ""
+	filter.OpenedAfter = nil
+	filter.OpenedBefore = nil
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.Category = strings.TrimSpace(filter.Category)
+	filter.Country = strings.ToUpper(filter.Country)
+
+	if filter.Language == "" {
+		filter.Language = domain.SearchLanguagePortuguese
+	}
+	if filter.Language != domain.SearchLanguagePortuguese && filter.Language != domain.SearchLanguageEnglish {
+		return filter, errors.New("lang must be pt or en")
+	}
+
+	if filter.SkillMatch == "" {
+		filter.SkillMatch = domain.SkillMatchAny
+	}
+	if filter.SkillMatch != domain.SkillMatchAny && filter.SkillMatch != domain.SkillMatchAll {
+		return filter, errors.New("skill_match must be any or all")
+	}
+
+	if filter.MinSalary != nil || filter.MaxSalary != nil {
+		if filter.MinSalary != nil && filter.MaxSalary != nil && *filter.MinSalary > *filter.MaxSalary {
+			return filter, errors.New("min_salary cannot be greater than max_salary")
+		}
+		filter.SalaryCurrency = strings.ToUpper(filter.SalaryCurrency)
+		if filter.SalaryCurrency == "" {
+			filter.SalaryCurrency = domain.DefaultSalaryCurrency
+		}
+		if filter.SalaryPeriod == "" {
+			filter.SalaryPeriod = domain.DefaultSalaryPeriod
+		}
+		if _, ok := domain.SalaryPeriodsPerYear[filter.SalaryPeriod]; !ok {
+			return filter, errors.New("invalid salary_period")
+		}
+	}
+
+	if filter.Near != nil {
+		if filter.Near.Latitude < -90 || filter.Near.Latitude > 90 || filter.Near.Longitude < -180 || filter.Near.Longitude > 180 {
+			return filter, errors.New("near must be a valid latitude and longitude")
+		}
+		if filter.RadiusKm == 0 {
+			filter.RadiusKm = domain.DefaultSearchRadiusKm
+		}
+		if filter.RadiusKm < 0 || filter.RadiusKm > domain.MaxSearchRadiusKm {
+			return filter, fmt.Errorf("radius_km must be between 0 and %d", domain.MaxSearchRadiusKm)
+		}
+	} else {
+		filter.RadiusKm = 0
+		filter.IncludeRemote = false
+	}
+
+	return filter, nil
+}
+
+func newUnsubscribeToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
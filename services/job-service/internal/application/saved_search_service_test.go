@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSavedSearchRepository struct {
+	domain.SavedSearchRepository
+	searches []domain.SavedSearch
+	marked   map[uuid.UUID][2]time.Time
+}
+
+func (r *fakeSavedSearchRepository) GetDueForAlert(ctx context.Context, now time.Time) ([]domain.SavedSearch, error) {
+	return r.searches, nil
+}
+
+func (r *fakeSavedSearchRepository) MarkAlerted(ctx context.Context, id uuid.UUID, at, through time.Time) error {
+	r.marked[id] = [2]time.Time{at, through}
+	return nil
+}
+
+// listedJobRepository returns jobs for every list and records its filters.
+type listedJobRepository struct {
+	domain.JobRepository
+	jobs    []*domain.Job
+	filters []domain.JobListFilter
+}
+
+func (r *listedJobRepository) List(ctx context.Context, filter domain.JobListFilter, page utils.PageRequest) ([]*domain.Job, int64, error) {
+	r.filters = append(r.filters, filter)
+	return r.jobs, int64(len(r.jobs)), nil
+}
+
+type recordingNotifier struct{ alerts []domain.JobAlert }
+
+func (n *recordingNotifier) SendJobAlert(ctx context.Context, alert domain.JobAlert) error {
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func jobsOpenedAt(times ...time.Time) []*domain.Job {
+	jobs := make([]*domain.Job, len(times))
+	for i := range times {
+		jobs[i] = &domain.Job{OpenedAt: &times[i]}
+	}
+	return jobs
+}
+
+func TestCapAlert(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	opened := func(minutes ...int) []time.Time {
+		times := make([]time.Time, len(minutes))
+		for i, m := range minutes {
+			times[i] = now.Add(time.Duration(m-1000) * time.Minute)
+		}
+		return times
+	}
+	sequence := func(from, n int) []int {
+		minutes := make([]int, n)
+		for i := range minutes {
+			minutes[i] = from + i
+		}
+		return minutes
+	}
+
+	t.Run("under the cap covers the whole window", func(t *testing.T) {
+		jobs := jobsOpenedAt(opened(sequence(0, maxJobsPerAlert)...)...)
+		sent, through := capAlert(jobs, now)
+		assert.Len(t, sent, maxJobsPerAlert)
+		assert.Equal(t, now, through)
+	})
+
+	t.Run("over the cap stops at the last job sent", func(t *testing.T) {
+		jobs := jobsOpenedAt(opened(sequence(0, maxJobsPerAlert+1)...)...)
+		sent, through := capAlert(jobs, now)
+		assert.Len(t, sent, maxJobsPerAlert)
+		assert.Equal(t, *jobs[maxJobsPerAlert-1].OpenedAt, through)
+		assert.True(t, jobs[maxJobsPerAlert].OpenedAt.After(through))
+	})
+
+	t.Run("jobs tied with the first one left out wait for the next digest", func(t *testing.T) {
+		minutes := append(sequence(0, maxJobsPerAlert-2), 500, 500, 500)
+		jobs := jobsOpenedAt(opened(minutes...)...)
+		sent, through := capAlert(jobs, now)
+		assert.Len(t, sent, maxJobsPerAlert-2)
+		assert.Equal(t, *jobs[maxJobsPerAlert-3].OpenedAt, through)
+	})
+}
+
+func TestRunAlertsLeavesRecentJobsToTheNextDigest(t *testing.T) {
+	alertedThrough := time.Now().Add(-24 * time.Hour)
+	search := domain.SavedSearch{ID: uuid.New(), AlertsEnabled: true, AlertedThrough: alertedThrough}
+	searches := &fakeSavedSearchRepository{searches: []domain.SavedSearch{search}, marked: make(map[uuid.UUID][2]time.Time)}
+	jobs := &listedJobRepository{jobs: jobsOpenedAt(alertedThrough.Add(time.Hour))}
+	notifier := &recordingNotifier{}
+
+	service := NewSavedSearchService(searches, &JobService{jobRepo: jobs, jobSkillRepo: emptyJobSkillRepository{}}, notifier, "")
+	started := time.Now()
+	require.NoError(t, service.RunAlerts(context.Background()))
+	finished := time.Now()
+
+	require.Len(t, jobs.filters, 1)
+	filter := jobs.filters[0]
+	assert.Equal(t, alertedThrough, *filter.OpenedAfter)
+	assert.False(t, filter.OpenedBefore.After(finished.Add(-alertCommitMargin)), "a job opening now may not have committed yet")
+
+	require.Len(t, notifier.alerts, 1)
+	marked := searches.marked[search.ID]
+	assert.False(t, marked[0].Before(started))
+	assert.Equal(t, *filter.OpenedBefore, marked[1], "the next window starts where this one ended")
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var insertColumns = regexp.MustCompile(`^INSERT INTO "\w+" \(([^)]*)\)`)
+
+// insertValues returns the values GORM binds when creating value, by column,
+// without a database.
+func insertValues(t *testing.T, value interface{}) map[string]interface{} {
+	t.Helper()
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+
+	stmt := db.Create(value).Statement
+	match := insertColumns.FindStringSubmatch(stmt.SQL.String())
+	require.NotNil(t, match, stmt.SQL.String())
+
+	values := make(map[string]interface{})
+	for i, column := range strings.Split(match[1], ",") {
+		values[strings.Trim(column, `"`)] = stmt.Vars[i]
+	}
+	return values
+}
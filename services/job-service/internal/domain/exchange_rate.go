@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExchangeRate converts an amount in FromCurrency into ToCurrency by
+// multiplying it by Rate. Rates are maintained by admins; the inverse pair
+// is derived when only one direction is registered.
+type ExchangeRate struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FromCurrency string    `json:"from_currency" gorm:"type:char(3);not null"`
+	ToCurrency   string    `json:"to_currency" gorm:"type:char(3);not null"`
+	Rate         float64   `json:"rate" gorm:"type:decimal(18,8);not null"`
+	UpdatedBy    uuid.UUID `json:"updated_by" gorm:"type:uuid;not null"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (e *ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
+
+type UpsertExchangeRateRequest struct {
+	FromCurrency string  `json:"from_currency" binding:"required,iso4217"`
+	ToCurrency   string  `json:"to_currency" binding:"required,iso4217,nefield=FromCurrency"`
+	Rate         float64 `json:"rate" binding:"required,gt=0"`
+}
+
+type ExchangeRateResponse struct {
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	Rate         float64   `json:"rate"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+const (
+	DefaultSearchRadiusKm = 50
+	MaxSearchRadiusKm     = 1000
+)
+
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type City struct {
+	Name       string `json:"name"`
+	State      string `json:"state"`
+	Country    string `json:"country"`
+	Population int    `json:"population"`
+	GeoPoint
+}
+
+// Geocoder resolves city names to coordinates. State and country narrow
+// the match when several cities share a name; empty values match any.
+type Geocoder interface {
+	FindCity(name, state, country string) (*City, bool)
+}
@@ -7,18 +7,46 @@ import (
 )
 
 type Job struct {
-	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Title       string     `json:"title" gorm:"not null"`
-	Description string     `json:"description" gorm:"type:text;not null"`
-	Requirements string    `json:"requirements" gorm:"type:text"`
-	Location    string     `json:"location"`
-	SalaryMin   *float64   `json:"salary_min" gorm:"type:decimal(10,2)"`
-	SalaryMax   *float64   `json:"salary_max" gorm:"type:decimal(10,2)"`
-	Status      string     `json:"status" gorm:"not null;default:'open'"`
-	CreatedBy   uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	Skills      []JobSkill `json:"skills,omitempty" gorm:"foreignKey:JobID"`
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Title            string     `json:"title" gorm:"not null"`
+	Description      string     `json:"description" gorm:"type:text;not null"`
+	Requirements     string     `json:"requirements" gorm:"type:text"`
+	Location         string     `json:"location"`
+	Country          string     `json:"country" gorm:"type:char(2)"`
+	State            string     `json:"state"`
+	City             string     `json:"city"`
+	Latitude         *float64   `json:"latitude" gorm:"type:decimal(9,6)"`
+	Longitude        *float64   `json:"longitude" gorm:"type:decimal(9,6)"`
+	IsRemote         bool       `json:"is_remote" gorm:"not null;default:false"`
+	Category         string     `json:"category"`
+	WorkMode         string     `json:"work_mode"`
+	EmploymentType   string     `json:"employment_type"`
+	Seniority        string     `json:"seniority"`
+	SalaryMin        *float64   `json:"salary_min" gorm:"type:decimal(10,2)"`
+	SalaryMax        *float64   `json:"salary_max" gorm:"type:decimal(10,2)"`
+	SalaryCurrency   string     `json:"salary_currency" gorm:"type:char(3);not null;default:'BRL'"`
+	SalaryPeriod     string     `json:"salary_period" gorm:"not null;default:'monthly'"`
+	SalaryType       string     `json:"salary_type" gorm:"not null;default:'gross'"`
+	SalaryHidden     bool       `json:"salary_hidden" gorm:"not null;default:false"`
+	Status           string     `json:"status" gorm:"not null;default:'open'"`
+	PublishAt        *time.Time `json:"publish_at"`
+	CloseAt          *time.Time `json:"close_at"`
+	MaxApplications  *int       `json:"max_applications"`
+	ClosePolicy      string     `json:"close_policy" gorm:"not null;default:'notify'"`
+	RejectionMessage string     `json:"rejection_message" gorm:"type:text"`
+	OpenedAt         *time.Time `json:"opened_at"`
+	// ApplicationCount is maintained from application.submitted events and
+	// never written back by Update.
+	ApplicationCount   int                 `json:"-" gorm:"->"`
+	CreatedBy          uuid.UUID           `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+	Skills             []JobSkill          `json:"skills,omitempty" gorm:"foreignKey:JobID"`
+	ScreeningQuestions []ScreeningQuestion `json:"screening_questions,omitempty" gorm:"foreignKey:JobID"`
+	SearchRank         float64             `json:"-" gorm:"->;-:migration"`
+	HighlightedTitle   string              `json:"-" gorm:"->;-:migration"`
+	Snippet            string              `json:"-" gorm:"->;-:migration"`
+	DistanceKm         *float64            `json:"-" gorm:"->;-:migration"`
 }
 
 type JobSkill struct {
@@ -26,7 +54,7 @@ type JobSkill struct {
 	JobID         uuid.UUID `json:"job_id" gorm:"type:uuid;not null"`
 	SkillID       uuid.UUID `json:"skill_id" gorm:"type:uuid;not null"`
 	RequiredLevel string    `json:"required_level"`
-	IsRequired    bool      `json:"is_required" gorm:"default:true"`
+	IsRequired    bool      `json:"is_required"`
 	CreatedAt     time.Time `json:"created_at"`
 	Skill         *Skill    `json:"skill,omitempty" gorm:"foreignKey:SkillID"`
 }
@@ -38,13 +66,55 @@ type Skill struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+const (
+	DefaultSalaryCurrency = "BRL"
+	DefaultSalaryPeriod   = SalaryPeriodMonthly
+	DefaultSalaryType     = SalaryTypeGross
+)
+
+const (
+	SalaryPeriodHourly  = "hourly"
+	SalaryPeriodDaily   = "daily"
+	SalaryPeriodWeekly  = "weekly"
+	SalaryPeriodMonthly = "monthly"
+	SalaryPeriodYearly  = "yearly"
+)
+
+const (
+	SalaryTypeGross = "gross"
+	SalaryTypeNet   = "net"
+)
+
+// SalaryPeriodsPerYear converts a salary period into its annual amount,
+// assuming a 40-hour, 5-day working week.
+var SalaryPeriodsPerYear = map[string]float64{
+	SalaryPeriodHourly:  2080,
+	SalaryPeriodDaily:   260,
+	SalaryPeriodWeekly:  52,
+	SalaryPeriodMonthly: 12,
+	SalaryPeriodYearly:  1,
+}
+
 type JobStatus string
 
 const (
-	JobStatusOpen   JobStatus = "open"
-	JobStatusClosed JobStatus = "closed"
+	JobStatusDraft     JobStatus = "draft"
+	JobStatusScheduled JobStatus = "scheduled"
+	JobStatusOpen      JobStatus = "open"
+	JobStatusPaused    JobStatus = "paused"
+	JobStatusClosed    JobStatus = "closed"
+	JobStatusArchived  JobStatus = "archived"
 )
 
+var jobStatusTransitions = map[JobStatus][]JobStatus{
+	JobStatusDraft:     {JobStatusScheduled, JobStatusOpen, JobStatusArchived},
+	JobStatusScheduled: {JobStatusDraft, JobStatusOpen, JobStatusArchived},
+	JobStatusOpen:      {JobStatusPaused, JobStatusClosed},
+	JobStatusPaused:    {JobStatusOpen, JobStatusClosed},
+	JobStatusClosed:    {JobStatusOpen, JobStatusArchived},
+	JobStatusArchived:  {},
+}
+
 func (j *Job) TableName() string {
 	return "jobs"
 }
@@ -65,14 +135,54 @@ func (j *Job) IsClosed() bool {
 	return j.Status == string(JobStatusClosed)
 }
 
+// IsPublic reports whether the job can be seen by anyone other than its
+// owner. Drafts, scheduled and archived jobs stay private.
+func (j *Job) IsPublic() bool {
+	switch JobStatus(j.Status) {
+	case JobStatusOpen, JobStatusPaused, JobStatusClosed:
+		return true
+	}
+	return false
+}
+
+func (j *Job) CanTransitionTo(status JobStatus) bool {
+	for _, allowed := range jobStatusTransitions[JobStatus(j.Status)] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
 type CreateJobRequest struct {
-	Title        string              `json:"title" binding:"required"`
-	Description  string              `json:"description" binding:"required"`
-	Requirements string              `json:"requirements"`
-	Location     string              `json:"location"`
-	SalaryMin    *float64            `json:"salary_min"`
-	SalaryMax    *float64            `json:"salary_max"`
-	Skills       []CreateJobSkillRequest `json:"skills"`
+	Title              string                           `json:"title" binding:"required"`
+	Description        string                           `json:"description" binding:"required"`
+	Requirements       string                           `json:"requirements"`
+	Location           string                           `json:"location"`
+	Country            string                           `json:"country" binding:"omitempty,iso3166_1_alpha2"`
+	State              string                           `json:"state"`
+	City               string                           `json:"city"`
+	Latitude           *float64                         `json:"latitude" binding:"omitempty,latitude"`
+	Longitude          *float64                         `json:"longitude" binding:"omitempty,longitude"`
+	IsRemote           *bool                            `json:"is_remote"`
+	Category           string                           `json:"category"`
+	WorkMode           string                           `json:"work_mode" binding:"omitempty,oneof=remote hybrid onsite"`
+	EmploymentType     string                           `json:"employment_type" binding:"omitempty,oneof=full_time part_time contract internship temporary freelance"`
+	Seniority          string                           `json:"seniority" binding:"omitempty,oneof=intern junior mid senior lead principal"`
+	SalaryMin          *float64                         `json:"salary_min"`
+	SalaryMax          *float64                         `json:"salary_max"`
+	SalaryCurrency     string                           `json:"salary_currency" binding:"omitempty,iso4217"`
+	SalaryPeriod       string                           `json:"salary_period" binding:"omitempty,oneof=hourly daily weekly monthly yearly"`
+	SalaryType         string                           `json:"salary_type" binding:"omitempty,oneof=gross net"`
+	SalaryHidden       *bool                            `json:"salary_hidden"`
+	Status             string                           `json:"status" binding:"omitempty,oneof=draft open"`
+	PublishAt          *time.Time                       `json:"publish_at"`
+	CloseAt            *time.Time                       `json:"close_at"`
+	MaxApplications    *int                             `json:"max_applications" binding:"omitempty,min=1"`
+	ClosePolicy        string                           `json:"close_policy" binding:"omitempty,oneof=notify reject"`
+	RejectionMessage   string                           `json:"rejection_message"`
+	Skills             []CreateJobSkillRequest          `json:"skills"`
+	ScreeningQuestions []CreateScreeningQuestionRequest `json:"screening_questions" binding:"dive"`
 }
 
 type CreateJobSkillRequest struct {
@@ -81,40 +191,146 @@ type CreateJobSkillRequest struct {
 	IsRequired    bool      `json:"is_required"`
 }
 
+type UpdateJobSkillRequest struct {
+	RequiredLevel string `json:"required_level" binding:"omitempty,oneof=beginner intermediate advanced expert"`
+	IsRequired    *bool  `json:"is_required"`
+}
+
+type ReplaceJobSkillsRequest struct {
+	Skills []CreateJobSkillRequest `json:"skills" binding:"dive"`
+}
+
 type UpdateJobRequest struct {
-	Title        string   `json:"title"`
-	Description  string   `json:"description"`
-	Requirements string   `json:"requirements"`
-	Location     string   `json:"location"`
-	SalaryMin    *float64 `json:"salary_min"`
-	SalaryMax    *float64 `json:"salary_max"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	Requirements     string     `json:"requirements"`
+	Location         string     `json:"location"`
+	Country          string     `json:"country" binding:"omitempty,iso3166_1_alpha2"`
+	State            string     `json:"state"`
+	City             string     `json:"city"`
+	Latitude         *float64   `json:"latitude" binding:"omitempty,latitude"`
+	Longitude        *float64   `json:"longitude" binding:"omitempty,longitude"`
+	IsRemote         *bool      `json:"is_remote"`
+	Category         string     `json:"category"`
+	WorkMode         string     `json:"work_mode" binding:"omitempty,oneof=remote hybrid onsite"`
+	EmploymentType   string     `json:"employment_type" binding:"omitempty,oneof=full_time part_time contract internship temporary freelance"`
+	Seniority        string     `json:"seniority" binding:"omitempty,oneof=intern junior mid senior lead principal"`
+	SalaryMin        *float64   `json:"salary_min"`
+	SalaryMax        *float64   `json:"salary_max"`
+	SalaryCurrency   string     `json:"salary_currency" binding:"omitempty,iso4217"`
+	SalaryPeriod     string     `json:"salary_period" binding:"omitempty,oneof=hourly daily weekly monthly yearly"`
+	SalaryType       string     `json:"salary_type" binding:"omitempty,oneof=gross net"`
+	SalaryHidden     *bool      `json:"salary_hidden"`
+	PublishAt        *time.Time `json:"publish_at"`
+	CloseAt          *time.Time `json:"close_at"`
+	MaxApplications  *int       `json:"max_applications" binding:"omitempty,min=1"`
+	ClosePolicy      string     `json:"close_policy" binding:"omitempty,oneof=notify reject"`
+	RejectionMessage *string    `json:"rejection_message"`
 }
 
 type UpdateJobStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=open closed"`
+	Status string `json:"status" binding:"required,oneof=draft scheduled open paused closed archived"`
 }
 
+// JobListFilter is also persisted as JSON by saved searches, hence the
+// tags. Status and the opened window are always set by the server.
 type JobListFilter struct {
-	Status   string
-	Location string
-	Title    string
-	MinSalary *float64
-	MaxSalary *float64
+	Status         string      `json:"-"`
+	Location       string      `json:"location,omitempty"`
+	Title          string      `json:"title,omitempty"`
+	MinSalary      *float64    `json:"min_salary,omitempty"`
+	MaxSalary      *float64    `json:"max_salary,omitempty"`
+	SalaryCurrency string      `json:"currency,omitempty"`
+	SalaryPeriod   string      `json:"salary_period,omitempty"`
+	Country        string      `json:"country,omitempty"`
+	State          string      `json:"state,omitempty"`
+	City           string      `json:"city,omitempty"`
+	Remote         *bool       `json:"remote,omitempty"`
+	Near           *GeoPoint   `json:"near,omitempty"`
+	RadiusKm       float64     `json:"radius_km,omitempty"`
+	IncludeRemote  bool        `json:"include_remote,omitempty"`
+	Query          string      `json:"q,omitempty"`
+	Language       string      `json:"lang,omitempty"`
+	SkillIDs       []uuid.UUID `json:"skill_ids,omitempty"`
+	SkillMatch     string      `json:"skill_match,omitempty"`
+	Category       string      `json:"category,omitempty"`
+	WorkMode       string      `json:"work_mode,omitempty"`
+	EmploymentType string      `json:"employment_type,omitempty"`
+	Seniority      string      `json:"seniority,omitempty"`
+	OpenedAfter    *time.Time  `json:"-"`
+	OpenedBefore   *time.Time  `json:"-"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
 }
 
+type JobFacets struct {
+	Skills          []FacetCount `json:"skills"`
+	Locations       []FacetCount `json:"locations"`
+	Categories      []FacetCount `json:"categories"`
+	WorkModes       []FacetCount `json:"work_modes"`
+	EmploymentTypes []FacetCount `json:"employment_types"`
+	Seniorities     []FacetCount `json:"seniorities"`
+}
+
+const (
+	WorkModeRemote = "remote"
+	WorkModeHybrid = "hybrid"
+	WorkModeOnsite = "onsite"
+)
+
+const (
+	SkillMatchAny = "any"
+	SkillMatchAll = "all"
+)
+
+const (
+	SearchLanguagePortuguese = "pt"
+	SearchLanguageEnglish    = "en"
+)
+
 type JobResponse struct {
-	ID          uuid.UUID         `json:"id"`
-	Title       string            `json:"title"`
-	Description string            `json:"description"`
-	Requirements string           `json:"requirements"`
-	Location    string            `json:"location"`
-	SalaryMin   *float64          `json:"salary_min"`
-	SalaryMax   *float64          `json:"salary_max"`
-	Status      string            `json:"status"`
-	CreatedBy   uuid.UUID         `json:"created_by"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
-	Skills      []JobSkillResponse `json:"skills,omitempty"`
+	ID                 uuid.UUID                   `json:"id"`
+	Title              string                      `json:"title"`
+	Description        string                      `json:"description"`
+	Requirements       string                      `json:"requirements"`
+	Location           string                      `json:"location"`
+	Country            string                      `json:"country,omitempty"`
+	State              string                      `json:"state,omitempty"`
+	City               string                      `json:"city,omitempty"`
+	Latitude           *float64                    `json:"latitude,omitempty"`
+	Longitude          *float64                    `json:"longitude,omitempty"`
+	IsRemote           bool                        `json:"is_remote"`
+	DistanceKm         *float64                    `json:"distance_km,omitempty"`
+	Category           string                      `json:"category,omitempty"`
+	WorkMode           string                      `json:"work_mode,omitempty"`
+	EmploymentType     string                      `json:"employment_type,omitempty"`
+	Seniority          string                      `json:"seniority,omitempty"`
+	SalaryMin          *float64                    `json:"salary_min"`
+	SalaryMax          *float64                    `json:"salary_max"`
+	SalaryCurrency     string                      `json:"salary_currency"`
+	SalaryPeriod       string                      `json:"salary_period"`
+	SalaryType         string                      `json:"salary_type"`
+	SalaryHidden       bool                        `json:"salary_hidden"`
+	Status             string                      `json:"status"`
+	PublishAt          *time.Time                  `json:"publish_at,omitempty"`
+	CloseAt            *time.Time                  `json:"close_at,omitempty"`
+	MaxApplications    *int                        `json:"max_applications,omitempty"`
+	CreatedBy          uuid.UUID                   `json:"created_by"`
+	CreatedAt          time.Time                   `json:"created_at"`
+	UpdatedAt          time.Time                   `json:"updated_at"`
+	Skills             []JobSkillResponse          `json:"skills,omitempty"`
+	ScreeningQuestions []ScreeningQuestionResponse `json:"screening_questions,omitempty"`
+	SearchRank         *float64                    `json:"search_rank,omitempty"`
+	Highlights         *JobHighlights              `json:"highlights,omitempty"`
+}
+
+type JobHighlights struct {
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"`
 }
 
 type JobSkillResponse struct {
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionalJobSkillIsStoredAsOptional(t *testing.T) {
+	values := insertValues(t, &JobSkill{
+		ID:            uuid.New(),
+		JobID:         uuid.New(),
+		SkillID:       uuid.New(),
+		RequiredLevel: "intermediate",
+		IsRequired:    false,
+	})
+
+	assert.Equal(t, false, values["is_required"])
+}
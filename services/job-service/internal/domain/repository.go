@@ -2,6 +2,9 @@ package domain
 
 import (
 	"context"
+	"time"
+
+	"recruitment-system/shared/utils"
 
 	"github.com/google/uuid"
 )
@@ -9,28 +12,78 @@ import (
 type JobRepository interface {
 	Create(ctx context.Context, job *Job) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+	// GetByIDForUpdate reads the job and locks its row until the
+	// surrounding transaction ends.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*Job, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*Job, error)
+	// Update writes the job's editable fields. Status, opened_at and the
+	// application count only change through UpdateStatus and
+	// IncrementApplicationCount.
 	Update(ctx context.Context, job *Job) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, filter JobListFilter, offset, limit int) ([]*Job, int64, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
-	GetByCreatedBy(ctx context.Context, createdBy uuid.UUID, offset, limit int) ([]*Job, int64, error)
+	List(ctx context.Context, filter JobListFilter, page utils.PageRequest) ([]*Job, int64, error)
+	Facets(ctx context.Context, filter JobListFilter, limit int) (*JobFacets, error)
+	// UpdateStatus moves the job from status from to status to, reporting
+	// false when its status was changed in the meantime.
+	UpdateStatus(ctx context.Context, id uuid.UUID, from, to string) (bool, error)
+	GetByCreatedBy(ctx context.Context, createdBy uuid.UUID, status string, page utils.PageRequest) ([]*Job, int64, error)
+	GetDueForPublish(ctx context.Context, now time.Time) ([]*Job, error)
+	GetDueForClose(ctx context.Context, now time.Time) ([]*Job, error)
+	// IncrementApplicationCount records one more application to the job
+	// and returns the new total. The count is never decremented: withdrawn
+	// and rejected applications still count toward max_applications.
+	IncrementApplicationCount(ctx context.Context, id uuid.UUID) (int, error)
 }
 
 type JobSkillRepository interface {
 	CreateBatch(ctx context.Context, jobSkills []JobSkill) error
 	GetByJobID(ctx context.Context, jobID uuid.UUID) ([]JobSkill, error)
+	GetByJobIDAndSkillID(ctx context.Context, jobID, skillID uuid.UUID) (*JobSkill, error)
 	DeleteByJobID(ctx context.Context, jobID uuid.UUID) error
+	DeleteByJobIDAndSkillID(ctx context.Context, jobID, skillID uuid.UUID) error
 	Update(ctx context.Context, jobSkill *JobSkill) error
 }
 
+type ScreeningQuestionRepository interface {
+	CreateBatch(ctx context.Context, questions []ScreeningQuestion) error
+	GetByJobID(ctx context.Context, jobID uuid.UUID) ([]ScreeningQuestion, error)
+	DeleteByJobID(ctx context.Context, jobID uuid.UUID) error
+}
+
 type SkillRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*Skill, error)
 	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]Skill, error)
-	List(ctx context.Context, category, search string, offset, limit int) ([]*Skill, int64, error)
+	List(ctx context.Context, category, search string, page utils.PageRequest) ([]*Skill, int64, error)
 	Create(ctx context.Context, skill *Skill) error
 	ExistsByName(ctx context.Context, name string) (bool, error)
 }
 
+type ExchangeRateRepository interface {
+	List(ctx context.Context) ([]ExchangeRate, error)
+	GetByPair(ctx context.Context, fromCurrency, toCurrency string) (*ExchangeRate, error)
+	Upsert(ctx context.Context, rate *ExchangeRate) error
+	Delete(ctx context.Context, fromCurrency, toCurrency string) error
+}
+
+type SavedSearchRepository interface {
+	Create(ctx context.Context, search *SavedSearch) error
+	GetByID(ctx context.Context, id uuid.UUID) (*SavedSearch, error)
+	GetByUnsubscribeToken(ctx context.Context, token string) (*SavedSearch, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]SavedSearch, error)
+	Update(ctx context.Context, search *SavedSearch) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetDueForAlert(ctx context.Context, now time.Time) ([]SavedSearch, error)
+	// MarkAlerted records an alert run at time at whose digest covered the
+	// jobs opened up to through.
+	MarkAlerted(ctx context.Context, id uuid.UUID, at, through time.Time) error
+}
+
+// EventOutbox records domain events in the caller's unit of work; a relay
+// publishes them once the transaction commits.
+type EventOutbox interface {
+	Add(ctx context.Context, eventType string, aggregateID uuid.UUID, payload interface{}) error
+}
+
 type AuthServiceClient interface {
 	ValidateToken(ctx context.Context, token string) (*UserInfo, error)
 }
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AlertFrequencyInstant = "instant"
+	AlertFrequencyDaily   = "daily"
+	AlertFrequencyWeekly  = "weekly"
+)
+
+// SavedSearch is a candidate's named job filter. When alerts are enabled
+// the alert scheduler sends a digest of jobs opened after AlertedThrough
+// once per Frequency, counted from LastAlertedAt.
+type SavedSearch struct {
+	ID               uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID           uuid.UUID     `json:"user_id" gorm:"type:uuid;not null"`
+	Email            string        `json:"email" gorm:"not null"`
+	Name             string        `json:"name" gorm:"not null"`
+	Filter           JobListFilter `json:"filter" gorm:"type:jsonb;serializer:json;not null"`
+	Frequency        string        `json:"frequency" gorm:"not null;default:'daily'"`
+	AlertsEnabled    bool          `json:"alerts_enabled" gorm:"not null"`
+	UnsubscribeToken string        `json:"-" gorm:"uniqueIndex;not null"`
+	LastAlertedAt    time.Time     `json:"last_alerted_at"`
+	AlertedThrough   time.Time     `json:"-" gorm:"not null"`
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+}
+
+func (s *SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// JobAlert is one digest for a saved search, handed to an AlertNotifier.
+type JobAlert struct {
+	SearchID       uuid.UUID
+	SearchName     string
+	UserID         uuid.UUID
+	Email          string
+	Frequency      string
+	Jobs           []*Job
+	SearchURL      string
+	UnsubscribeURL string
+}
+
+// AlertNotifier delivers job alerts. Implementations decide the channel
+// (log, e-mail, ...); a failed delivery is retried on the next run.
+type AlertNotifier interface {
+	SendJobAlert(ctx context.Context, alert JobAlert) error
+}
+
+type CreateSavedSearchRequest struct {
+	Name          string        `json:"name" binding:"required,max=100"`
+	Filter        JobListFilter `json:"filter"`
+	Frequency     string        `json:"frequency" binding:"omitempty,oneof=instant daily weekly"`
+	AlertsEnabled *bool         `json:"alerts_enabled"`
+}
+
+type UpdateSavedSearchRequest struct {
+	Name          string         `json:"name" binding:"omitempty,max=100"`
+	Filter        *JobListFilter `json:"filter"`
+	Frequency     string         `json:"frequency" binding:"omitempty,oneof=instant daily weekly"`
+	AlertsEnabled *bool          `json:"alerts_enabled"`
+}
+
+type SavedSearchResponse struct {
+	ID            uuid.UUID     `json:"id"`
+	Name          string        `json:"name"`
+	Filter        JobListFilter `json:"filter"`
+	Frequency     string        `json:"frequency"`
+	AlertsEnabled bool          `json:"alerts_enabled"`
+	LastAlertedAt time.Time     `json:"last_alerted_at"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
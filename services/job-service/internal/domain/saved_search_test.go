@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearchWithoutAlertsIsStoredWithoutAlerts(t *testing.T) {
+	values := insertValues(t, &SavedSearch{
+		ID:               uuid.New(),
+		UserID:           uuid.New(),
+		Email:            "candidate@example.com",
+		Name:             "Go remoto",
+		Frequency:        AlertFrequencyDaily,
+		AlertsEnabled:    false,
+		UnsubscribeToken: "token",
+	})
+
+	assert.Equal(t, false, values["alerts_enabled"])
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScreeningQuestion struct {
+	ID              uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	JobID           uuid.UUID `json:"job_id" gorm:"type:uuid;not null"`
+	Question        string    `json:"question" gorm:"type:text;not null"`
+	QuestionType    string    `json:"question_type" gorm:"not null"`
+	Options         []string  `json:"options" gorm:"type:jsonb;serializer:json"`
+	IsRequired      bool      `json:"is_required"`
+	KnockoutAnswers []string  `json:"knockout_answers" gorm:"type:jsonb;serializer:json"`
+	MinValue        *float64  `json:"min_value" gorm:"type:decimal(12,2)"`
+	MaxValue        *float64  `json:"max_value" gorm:"type:decimal(12,2)"`
+	Position        int       `json:"position" gorm:"not null;default:0"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ScreeningQuestionType string
+
+const (
+	QuestionTypeText         ScreeningQuestionType = "text"
+	QuestionTypeYesNo        ScreeningQuestionType = "yes_no"
+	QuestionTypeSingleChoice ScreeningQuestionType = "single_choice"
+	QuestionTypeMultiChoice  ScreeningQuestionType = "multi_choice"
+	QuestionTypeNumeric      ScreeningQuestionType = "numeric"
+)
+
+func (q *ScreeningQuestion) TableName() string {
+	return "job_screening_questions"
+}
+
+func (q *ScreeningQuestion) IsChoice() bool {
+	return q.QuestionType == string(QuestionTypeSingleChoice) || q.QuestionType == string(QuestionTypeMultiChoice)
+}
+
+// CreateScreeningQuestionRequest describes a question attached to a job.
+// KnockoutAnswers lists the answers (yes/no or options) that automatically
+// reject an application; for numeric questions MinValue and MaxValue bound
+// the accepted range instead.
+type CreateScreeningQuestionRequest struct {
+	Question        string   `json:"question" binding:"required"`
+	QuestionType    string   `json:"question_type" binding:"required,oneof=text yes_no single_choice multi_choice numeric"`
+	Options         []string `json:"options"`
+	IsRequired      bool     `json:"is_required"`
+	KnockoutAnswers []string `json:"knockout_answers"`
+	MinValue        *float64 `json:"min_value"`
+	MaxValue        *float64 `json:"max_value"`
+}
+
+type ReplaceScreeningQuestionsRequest struct {
+	Questions []CreateScreeningQuestionRequest `json:"questions" binding:"dive"`
+}
+
+type ScreeningQuestionResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Question     string    `json:"question"`
+	QuestionType string    `json:"question_type"`
+	Options      []string  `json:"options,omitempty"`
+	IsRequired   bool      `json:"is_required"`
+	Position     int       `json:"position"`
+}
+
+type ScreeningQuestionDetailResponse struct {
+	ScreeningQuestionResponse
+	KnockoutAnswers []string `json:"knockout_answers,omitempty"`
+	MinValue        *float64 `json:"min_value,omitempty"`
+	MaxValue        *float64 `json:"max_value,omitempty"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionalScreeningQuestionIsStoredAsOptional(t *testing.T) {
+	values := insertValues(t, &ScreeningQuestion{
+		ID:           uuid.New(),
+		JobID:        uuid.New(),
+		Question:     "Do you have a portfolio?",
+		QuestionType: string(QuestionTypeYesNo),
+		IsRequired:   false,
+	})
+
+	assert.Equal(t, false, values["is_required"])
+}
@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"recruitment-system/services/job-service/internal/domain"
+)
+
+// LogAlertNotifier writes alerts to the service log. It is the default when
+// no mail server is configured, which keeps development setups quiet.
+type LogAlertNotifier struct{}
+
+func NewLogAlertNotifier() domain.AlertNotifier {
+	return &LogAlertNotifier{}
+}
+
+func (n *LogAlertNotifier) SendJobAlert(ctx context.Context, alert domain.JobAlert) error {
+	slog.InfoContext(ctx, "Job alert", "search", alert.SearchName, "email", alert.Email,
+		"jobs", len(alert.Jobs), "unsubscribe_url", alert.UnsubscribeURL)
+	return nil
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type SMTPAlertNotifier struct {
+	config SMTPConfig
+}
+
+func NewSMTPAlertNotifier(config SMTPConfig) domain.AlertNotifier {
+	return &SMTPAlertNotifier{config: config}
+}
+
+func (n *SMTPAlertNotifier) SendJobAlert(ctx context.Context, alert domain.JobAlert) error {
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+	}
+
+	addr := net.JoinHostPort(n.config.Host, n.config.Port)
+	return smtp.SendMail(addr, auth, n.config.From, []string{alert.Email}, renderJobAlert(n.config.From, alert))
+}
+
+func renderJobAlert(from string, alert domain.JobAlert) []byte {
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", from)
+	fmt.Fprintf(&body, "To: %s\r\n", alert.Email)
+	fmt.Fprintf(&body, "Subject: %d new job(s) for \"%s\"\r\n", len(alert.Jobs), alert.SearchName)
+	fmt.Fprintf(&body, "List-Unsubscribe: <%s>\r\n", alert.UnsubscribeURL)
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+
+	fmt.Fprintf(&body, "New jobs matching your saved search \"%s\":\r\n\r\n", alert.SearchName)
+	for _, job := range alert.Jobs {
+		fmt.Fprintf(&body, "- %s", job.Title)
+		if job.Location != "" {
+			fmt.Fprintf(&body, " (%s)", job.Location)
+		}
+		if !job.SalaryHidden && job.SalaryMin != nil && job.SalaryMax != nil {
+			fmt.Fprintf(&body, " - %s %.2f-%.2f/%s", job.SalaryCurrency, *job.SalaryMin, *job.SalaryMax, job.SalaryPeriod)
+		}
+		body.WriteString("\r\n")
+	}
+
+	fmt.Fprintf(&body, "\r\nManage this search: %s\r\n", alert.SearchURL)
+	fmt.Fprintf(&body, "Stop these alerts: %s\r\n", alert.UnsubscribeURL)
+	return []byte(body.String())
+}
@@ -0,0 +1,27 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"recruitment-system/services/job-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderJobAlertHidesHiddenSalaries(t *testing.T) {
+	min, max := 5000.0, 8000.0
+	message := string(renderJobAlert("alerts@example.com", domain.JobAlert{
+		SearchName:     "Go remoto",
+		Email:          "candidate@example.com",
+		UnsubscribeURL: "http://localhost:8081/api/v1/saved-searches/unsubscribe?token=abc",
+		Jobs: []*domain.Job{
+			{Title: "Backend Engineer", Location: "Remote", SalaryMin: &min, SalaryMax: &max, SalaryCurrency: "BRL", SalaryPeriod: "monthly"},
+			{Title: "Staff Engineer", SalaryMin: &min, SalaryMax: &max, SalaryCurrency: "BRL", SalaryPeriod: "monthly", SalaryHidden: true},
+		},
+	}))
+
+	assert.Contains(t, message, "Subject: 2 new job(s) for \"Go remoto\"")
+	assert.Contains(t, message, "List-Unsubscribe: <http://localhost:8081/api/v1/saved-searches/unsubscribe?token=abc>")
+	assert.Contains(t, message, "- Backend Engineer (Remote) - BRL 5000.00-8000.00/monthly")
+	assert.Contains(t, message, "- Staff Engineer\r\n")
+}
@@ -2,81 +2,41 @@ package infrastructure
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"time"
 
 	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/pb/authv1"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc"
 )
 
 type AuthServiceClientImpl struct {
-	baseURL    string
-	httpClient *http.Client
+	client authv1.AuthServiceClient
 }
 
-func NewAuthServiceClient(baseURL string) domain.AuthServiceClient {
+func NewAuthServiceClient(conn grpc.ClientConnInterface) domain.AuthServiceClient {
 	return &AuthServiceClientImpl{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		client: authv1.NewAuthServiceClient(conn),
 	}
 }
 
-type ValidateTokenRequest struct {
-	Token string `json:"token"`
-}
-
-type ValidateTokenResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Data    struct {
-		UserID string `json:"user_id"`
-		Email  string `json:"email"`
-		Role   string `json:"role"`
-	} `json:"data"`
-	Error string `json:"error,omitempty"`
-}
-
 func (c *AuthServiceClientImpl) ValidateToken(ctx context.Context, token string) (*domain.UserInfo, error) {
-	url := fmt.Sprintf("%s/api/v1/auth/validate", c.baseURL)
-
-	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	response, err := c.client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: token})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var response ValidateTokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if !response.Success {
-		return nil, fmt.Errorf("token validation failed: %s", response.Error)
+		return nil, fmt.Errorf("token validation failed: %w", err)
 	}
 
-	userID, err := uuid.Parse(response.Data.UserID)
+	userID, err := uuid.Parse(response.User.GetId())
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID format: %w", err)
 	}
 
 	userInfo := &domain.UserInfo{
 		ID:    userID,
-		Email: response.Data.Email,
-		Role:  response.Data.Role,
-		Name:  response.Data.Email,
+		Email: response.User.GetEmail(),
+		Role:  response.User.GetRole(),
+		Name:  response.User.GetEmail(),
 	}
 
 	return userInfo, nil
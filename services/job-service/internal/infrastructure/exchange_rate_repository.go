@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExchangeRateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewExchangeRateRepository(db *gorm.DB) domain.ExchangeRateRepository {
+	return &ExchangeRateRepositoryImpl{db: db}
+}
+
+func (r *ExchangeRateRepositoryImpl) List(ctx context.Context) ([]domain.ExchangeRate, error) {
+	var rates []domain.ExchangeRate
+	err := database.DB(ctx, r.db).Order("from_currency ASC, to_currency ASC").Find(&rates).Error
+	return rates, err
+}
+
+func (r *ExchangeRateRepositoryImpl) GetByPair(ctx context.Context, fromCurrency, toCurrency string) (*domain.ExchangeRate, error) {
+	var rate domain.ExchangeRate
+	err := database.DB(ctx, r.db).
+		Where("from_currency = ? AND to_currency = ?", fromCurrency, toCurrency).
+		First(&rate).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *ExchangeRateRepositoryImpl) Upsert(ctx context.Context, rate *domain.ExchangeRate) error {
+	return database.DB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "from_currency"}, {Name: "to_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_by", "updated_at"}),
+	}).Create(rate).Error
+}
+
+func (r *ExchangeRateRepositoryImpl) Delete(ctx context.Context, fromCurrency, toCurrency string) error {
+	return database.DB(ctx, r.db).
+		Where("from_currency = ? AND to_currency = ?", fromCurrency, toCurrency).
+		Delete(&domain.ExchangeRate{}).Error
+}
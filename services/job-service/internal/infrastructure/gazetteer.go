@@ -0,0 +1,114 @@
+package infrastructure
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"recruitment-system/services/job-service/internal/domain"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed data/cities.csv
+var bundledCities []byte
+
+// Gazetteer is an in-memory, offline city index. It ships with a small
+// bundled list and can load a larger CSV with the same columns
+// (country,state,city,latitude,longitude,population) instead.
+type Gazetteer struct {
+	cities map[string][]domain.City
+}
+
+func NewGazetteer(path string) (domain.Geocoder, error) {
+	data := bundledCities
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parseGazetteer(bytes.NewReader(data))
+}
+
+func parseGazetteer(r io.Reader) (*Gazetteer, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 6
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Gazetteer{cities: make(map[string][]domain.City)}
+	for i, record := range records {
+		if i == 0 && record[0] == "country" {
+			continue
+		}
+
+		latitude, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("gazetteer line %d: invalid latitude: %w", i+1, err)
+		}
+		longitude, err := strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("gazetteer line %d: invalid longitude: %w", i+1, err)
+		}
+		population, _ := strconv.Atoi(record[5])
+
+		city := domain.City{
+			Name:       record[2],
+			State:      record[1],
+			Country:    record[0],
+			Population: population,
+			GeoPoint:   domain.GeoPoint{Latitude: latitude, Longitude: longitude},
+		}
+		key := normalizePlaceName(city.Name)
+		g.cities[key] = append(g.cities[key], city)
+	}
+
+	return g, nil
+}
+
+// FindCity returns the most populous city matching name, ignoring case and
+// accents, so "sao paulo" finds "São Paulo".
+func (g *Gazetteer) FindCity(name, state, country string) (*domain.City, bool) {
+	candidates := g.cities[normalizePlaceName(name)]
+
+	var best *domain.City
+	for i, city := range candidates {
+		if state != "" && normalizePlaceName(city.State) != normalizePlaceName(state) {
+			continue
+		}
+		if country != "" && !strings.EqualFold(city.Country, country) {
+			continue
+		}
+		if best == nil || city.Population > best.Population {
+			best = &candidates[i]
+		}
+	}
+
+	if best == nil {
+		return nil, false
+	}
+	found := *best
+	return &found, true
+}
+
+func normalizePlaceName(name string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), name)
+	if err != nil {
+		folded = name
+	}
+	return strings.ToLower(strings.Join(strings.Fields(folded), " "))
+}
@@ -0,0 +1,44 @@
+package infrastructure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundledGazetteerFindsCitiesIgnoringAccents(t *testing.T) {
+	gazetteer, err := NewGazetteer("")
+	require.NoError(t, err)
+
+	city, ok := gazetteer.FindCity("sao paulo", "", "")
+	require.True(t, ok)
+	assert.Equal(t, "São Paulo", city.Name)
+	assert.Equal(t, "SP", city.State)
+	assert.Equal(t, "BR", city.Country)
+	assert.InDelta(t, -23.55, city.Latitude, 0.01)
+
+	_, ok = gazetteer.FindCity("Atlantis", "", "")
+	assert.False(t, ok)
+}
+
+func TestGazetteerPrefersMostPopulousMatch(t *testing.T) {
+	gazetteer, err := parseGazetteer(strings.NewReader(
+		"country,state,city,latitude,longitude,population\n" +
+			"US,TX,Paris,33.6609,-95.5555,25000\n" +
+			"FR,IDF,Paris,48.8566,2.3522,2160000\n",
+	))
+	require.NoError(t, err)
+
+	city, ok := gazetteer.FindCity("Paris", "", "")
+	require.True(t, ok)
+	assert.Equal(t, "FR", city.Country)
+
+	city, ok = gazetteer.FindCity("Paris", "", "us")
+	require.True(t, ok)
+	assert.Equal(t, "TX", city.State)
+
+	_, ok = gazetteer.FindCity("Paris", "CA", "US")
+	assert.False(t, ok)
+}
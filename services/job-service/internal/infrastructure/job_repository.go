@@ -2,12 +2,19 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/database"
+	"recruitment-system/shared/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type JobRepositoryImpl struct {
@@ -19,36 +26,113 @@ func NewJobRepository(db *gorm.DB) domain.JobRepository {
 }
 
 func (r *JobRepositoryImpl) Create(ctx context.Context, job *domain.Job) error {
-	return r.db.WithContext(ctx).Create(job).Error
+	return database.DB(ctx, r.db).Create(job).Error
 }
 
 func (r *JobRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
 	var job domain.Job
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&job).Error
 	if err != nil {
 		return nil, err
 	}
 	return &job, nil
 }
 
+func (r *JobRepositoryImpl) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	var job domain.Job
+	err := database.DB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *JobRepositoryImpl) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Job, error) {
+	var jobs []*domain.Job
+	err := database.DB(ctx, r.db).Where("id IN ?", ids).Find(&jobs).Error
+	return jobs, err
+}
+
+// lifecycleColumns are left out of Update so an edit based on a stale read
+// cannot undo a status change or an application counted in the meantime.
+var lifecycleColumns = []string{"status", "opened_at", "application_count", "created_by", "created_at"}
+
 func (r *JobRepositoryImpl) Update(ctx context.Context, job *domain.Job) error {
-	return r.db.WithContext(ctx).Save(job).Error
+	return database.DB(ctx, r.db).Model(job).Select("*").Omit(append(lifecycleColumns, clause.Associations)...).Updates(job).Error
 }
 
 func (r *JobRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.Job{}, id).Error
+	return database.DB(ctx, r.db).Delete(&domain.Job{}, id).Error
 }
 
-func (r *JobRepositoryImpl) List(ctx context.Context, filter domain.JobListFilter, offset, limit int) ([]*domain.Job, int64, error) {
+func (r *JobRepositoryImpl) List(ctx context.Context, filter domain.JobListFilter, page utils.PageRequest) ([]*domain.Job, int64, error) {
 	var jobs []*domain.Job
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Job{})
+	if page.Keyset && (filter.Query != "" || filter.Near != nil) {
+		return nil, 0, utils.ErrRankedWithCursor
+	}
+
+	query := applyJobFilter(database.DB(ctx, r.db).Model(&domain.Job{}), filter)
+
+	search := searchConfigFor(filter.Language)
+	tsQuery := fmt.Sprintf("websearch_to_tsquery('%s', ?)", search.config)
+
+	if !page.Keyset {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	selects := []string{"jobs.*"}
+	var args []interface{}
+
+	if filter.Query != "" {
+		selects = append(selects, fmt.Sprintf(
+			"ts_rank(%[1]s, %[2]s) AS search_rank, "+
+				"ts_headline('%[3]s', %[6]s, %[2]s, '%[4]s') AS highlighted_title, "+
+				"ts_headline('%[3]s', %[7]s, %[2]s, '%[5]s') AS snippet",
+			search.column, tsQuery, search.config, titleHeadlineOptions, snippetHeadlineOptions,
+			escapeHTML("title"), escapeHTML("COALESCE(requirements, '') || ' ' || description"),
+		))
+		args = append(args, filter.Query, filter.Query, filter.Query)
+		page = page.WithDefaultSort(
+			utils.SortField{Column: "search_rank", Desc: true},
+			utils.SortField{Column: "jobs.created_at", Desc: true},
+		)
+	}
+
+	if filter.Near != nil {
+		selects = append(selects, distanceSQL+" AS distance_km")
+		args = append(args, filter.Near.Latitude, filter.Near.Longitude, filter.Near.Latitude)
+		page = page.WithDefaultSort(
+			utils.SortField{Column: "distance_km"},
+			utils.SortField{Column: "jobs.created_at", Desc: true},
+		)
+	}
+
+	if len(selects) > 1 {
+		query = query.Select(strings.Join(selects, ", "), args...)
+	}
+
+	page = page.WithDefaultSort(utils.SortField{Column: "jobs.created_at", Desc: true})
+	err := query.Scopes(database.Paginate(page, "jobs.created_at", "jobs.id")).Find(&jobs).Error
+	return jobs, total, err
+}
 
+func applyJobFilter(query *gorm.DB, filter domain.JobListFilter) *gorm.DB {
 	if filter.Status != "" {
 		query = query.Where("status = ?", filter.Status)
 	}
 
+	if filter.OpenedAfter != nil {
+		query = query.Where("jobs.opened_at > ?", *filter.OpenedAfter)
+	}
+
+	if filter.OpenedBefore != nil {
+		query = query.Where("jobs.opened_at <= ?", *filter.OpenedBefore)
+	}
+
 	if filter.Location != "" {
 		query = query.Where("LOWER(location) LIKE ?", "%"+strings.ToLower(filter.Location)+"%")
 	}
@@ -57,36 +141,287 @@ func (r *JobRepositoryImpl) List(ctx context.Context, filter domain.JobListFilte
 		query = query.Where("LOWER(title) LIKE ?", "%"+strings.ToLower(filter.Title)+"%")
 	}
 
-	if filter.MinSalary != nil {
-		query = query.Where("salary_max >= ? OR salary_max IS NULL", *filter.MinSalary)
+	if filter.MinSalary != nil || filter.MaxSalary != nil {
+		perYear := domain.SalaryPeriodsPerYear[filter.SalaryPeriod]
+		if perYear == 0 {
+			perYear = domain.SalaryPeriodsPerYear[domain.DefaultSalaryPeriod]
+		}
+		currency := filter.SalaryCurrency
+		if currency == "" {
+			currency = domain.DefaultSalaryCurrency
+		}
+
+		if filter.MinSalary != nil {
+			query = query.Where(
+				"jobs.salary_hidden OR jobs.salary_max IS NULL OR "+annualSalarySQL("salary_max")+" >= @amount",
+				map[string]interface{}{"currency": currency, "amount": *filter.MinSalary * perYear},
+			)
+		}
+
+		if filter.MaxSalary != nil {
+			query = query.Where(
+				"jobs.salary_hidden OR jobs.salary_min IS NULL OR "+annualSalarySQL("salary_min")+" <= @amount",
+				map[string]interface{}{"currency": currency, "amount": *filter.MaxSalary * perYear},
+			)
+		}
 	}
 
-	if filter.MaxSalary != nil {
-		query = query.Where("salary_min <= ? OR salary_min IS NULL", *filter.MaxSalary)
+	if filter.Country != "" {
+		query = query.Where("jobs.country = ?", strings.ToUpper(filter.Country))
 	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if filter.State != "" {
+		query = query.Where("LOWER(jobs.state) = ?", strings.ToLower(filter.State))
 	}
 
-	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&jobs).Error
-	return jobs, total, err
+	if filter.City != "" {
+		query = query.Where("LOWER(jobs.city) = ?", strings.ToLower(filter.City))
+	}
+
+	if filter.Remote != nil {
+		query = query.Where("jobs.is_remote = ?", *filter.Remote)
+	}
+
+	if filter.Near != nil {
+		query = query.Where(withinRadius(*filter.Near, filter.RadiusKm, filter.IncludeRemote))
+	}
+
+	if filter.Category != "" {
+		query = query.Where("LOWER(category) = ?", strings.ToLower(filter.Category))
+	}
+
+	if filter.WorkMode != "" {
+		query = query.Where("work_mode = ?", filter.WorkMode)
+	}
+
+	if filter.EmploymentType != "" {
+		query = query.Where("employment_type = ?", filter.EmploymentType)
+	}
+
+	if filter.Seniority != "" {
+		query = query.Where("seniority = ?", filter.Seniority)
+	}
+
+	if len(filter.SkillIDs) > 0 {
+		if filter.SkillMatch == domain.SkillMatchAll {
+			query = query.Where(
+				"jobs.id IN (SELECT job_id FROM job_skills WHERE skill_id IN ? GROUP BY job_id HAVING COUNT(DISTINCT skill_id) = ?)",
+				filter.SkillIDs, len(filter.SkillIDs),
+			)
+		} else {
+			query = query.Where("jobs.id IN (SELECT job_id FROM job_skills WHERE skill_id IN ?)", filter.SkillIDs)
+		}
+	}
+
+	if filter.Query != "" {
+		search := searchConfigFor(filter.Language)
+		query = query.Where(fmt.Sprintf("%s @@ websearch_to_tsquery('%s', ?)", search.column, search.config), filter.Query)
+	}
+
+	return query
+}
+
+// Facets counts the jobs matching filter grouped by each facet dimension.
+// Each dimension applies every filter but its own, so the counts show what
+// selecting another value, or adding one, would return.
+func (r *JobRepositoryImpl) Facets(ctx context.Context, filter domain.JobListFilter, limit int) (*domain.JobFacets, error) {
+	facets := &domain.JobFacets{}
+	targets := map[string]*[]domain.FacetCount{
+		"skills":           &facets.Skills,
+		"locations":        &facets.Locations,
+		"categories":       &facets.Categories,
+		"work_modes":       &facets.WorkModes,
+		"employment_types": &facets.EmploymentTypes,
+		"seniorities":      &facets.Seniorities,
+	}
+
+	for name, query := range jobFacetQueries(database.DB(ctx, r.db), filter, limit) {
+		counts := []domain.FacetCount{}
+		if err := query.Scan(&counts).Error; err != nil {
+			return nil, err
+		}
+		*targets[name] = counts
+	}
+	return facets, nil
+}
+
+// jobFacetQueries builds the count query of each facet, keyed by its name
+// in domain.JobFacets.
+func jobFacetQueries(db *gorm.DB, filter domain.JobListFilter, limit int) map[string]*gorm.DB {
+	columns := []struct {
+		name   string
+		column string
+		clear  func(*domain.JobListFilter)
+	}{
+		{"locations", "location", func(f *domain.JobListFilter) { f.Location = "" }},
+		{"categories", "category", func(f *domain.JobListFilter) { f.Category = "" }},
+		{"work_modes", "work_mode", func(f *domain.JobListFilter) { f.WorkMode = "" }},
+		{"employment_types", "employment_type", func(f *domain.JobListFilter) { f.EmploymentType = "" }},
+		{"seniorities", "seniority", func(f *domain.JobListFilter) { f.Seniority = "" }},
+	}
+
+	queries := make(map[string]*gorm.DB, len(columns)+1)
+	for _, c := range columns {
+		others := filter
+		c.clear(&others)
+		queries[c.name] = applyJobFilter(db.Model(&domain.Job{}), others).
+			Select(c.column + " AS value, COUNT(*) AS count").
+			Where(c.column + " IS NOT NULL AND " + c.column + " <> ''").
+			Group(c.column).
+			Order("count DESC, value ASC").
+			Limit(limit)
+	}
+
+	others := filter
+	others.SkillIDs = nil
+	jobIDs := applyJobFilter(db.Model(&domain.Job{}), others).Select("jobs.id")
+	queries["skills"] = db.Table("job_skills").
+		Select("CAST(job_skills.skill_id AS text) AS value, skills.name AS label, COUNT(DISTINCT job_skills.job_id) AS count").
+		Joins("JOIN skills ON skills.id = job_skills.skill_id").
+		Where("job_skills.job_id IN (?)", jobIDs).
+		Group("job_skills.skill_id, skills.name").
+		Order("count DESC, label ASC").
+		Limit(limit)
+
+	return queries
+}
+
+// distanceSQL is the great-circle distance in km between a job and a point
+// bound as (latitude, longitude, latitude).
+const distanceSQL = "(6371 * acos(LEAST(1, GREATEST(-1, " +
+	"cos(radians(?)) * cos(radians(jobs.latitude)) * cos(radians(jobs.longitude) - radians(?)) + " +
+	"sin(radians(?)) * sin(radians(jobs.latitude))))))"
+
+const kmPerDegree = 111.045
+
+// withinRadius matches jobs within radiusKm of point. A bounding box on the
+// indexed coordinates prunes candidates before the exact distance check.
+func withinRadius(point domain.GeoPoint, radiusKm float64, includeRemote bool) clause.Expr {
+	latDelta := radiusKm / kmPerDegree
+	conditions := []string{"jobs.latitude BETWEEN ? AND ?"}
+	args := []interface{}{point.Latitude - latDelta, point.Latitude + latDelta}
+
+	lonDelta := radiusKm / (kmPerDegree * math.Max(math.Cos(point.Latitude*math.Pi/180), 0.01))
+	if point.Longitude-lonDelta > -180 && point.Longitude+lonDelta < 180 {
+		conditions = append(conditions, "jobs.longitude BETWEEN ? AND ?")
+		args = append(args, point.Longitude-lonDelta, point.Longitude+lonDelta)
+	}
+
+	conditions = append(conditions, distanceSQL+" <= ?")
+	args = append(args, point.Latitude, point.Longitude, point.Latitude, radiusKm)
+
+	sql := "(" + strings.Join(conditions, " AND ") + ")"
+	if includeRemote {
+		sql = "(jobs.is_remote OR " + sql + ")"
+	}
+	return gorm.Expr(sql, args...)
+}
+
+// annualSalarySQL converts a salary column into a yearly amount in the
+// @currency named argument. The rate comes from exchange_rates, falling back
+// to the inverse pair; without either the expression is NULL and the job
+// does not match the salary filter.
+func annualSalarySQL(column string) string {
+	periods := make([]string, 0, len(domain.SalaryPeriodsPerYear))
+	for period := range domain.SalaryPeriodsPerYear {
+		periods = append(periods, period)
+	}
+	sort.Strings(periods)
+
+	var perYear strings.Builder
+	perYear.WriteString("CASE jobs.salary_period")
+	for _, period := range periods {
+		fmt.Fprintf(&perYear, " WHEN '%s' THEN %g", period, domain.SalaryPeriodsPerYear[period])
+	}
+	perYear.WriteString(" END")
+
+	rate := "CASE WHEN jobs.salary_currency = @currency THEN 1 ELSE COALESCE(" +
+		"(SELECT rate FROM exchange_rates WHERE from_currency = jobs.salary_currency AND to_currency = @currency), " +
+		"(SELECT 1 / rate FROM exchange_rates WHERE from_currency = @currency AND to_currency = jobs.salary_currency)) END"
+
+	return fmt.Sprintf("(jobs.%s * %s * %s)", column, perYear.String(), rate)
 }
 
-func (r *JobRepositoryImpl) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
-	return r.db.WithContext(ctx).Model(&domain.Job{}).Where("id = ?", id).Update("status", status).Error
+const (
+	titleHeadlineOptions   = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	snippetHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+)
+
+// escapeHTML escapes the text of a SQL expression so the <mark> tags added
+// by ts_headline are the only markup in the highlights. The text is written
+// by recruiters and the highlights are served as HTML.
+func escapeHTML(expr string) string {
+	for _, r := range [][2]string{{"&", "&amp;"}, {"<", "&lt;"}, {">", "&gt;"}, {`"`, "&quot;"}, {"'", "&#39;"}} {
+		expr = fmt.Sprintf("replace(%s, '%s', '%s')", expr, strings.ReplaceAll(r[0], "'", "''"), r[1])
+	}
+	return expr
 }
 
-func (r *JobRepositoryImpl) GetByCreatedBy(ctx context.Context, createdBy uuid.UUID, offset, limit int) ([]*domain.Job, int64, error) {
+type searchConfig struct {
+	config string
+	column string
+}
+
+// searchConfigFor maps a request language to the text search configuration
+// and the matching generated tsvector column. Unknown languages fall back to
+// Portuguese.
+func searchConfigFor(language string) searchConfig {
+	if language == domain.SearchLanguageEnglish {
+		return searchConfig{config: "english", column: "search_vector_en"}
+	}
+	return searchConfig{config: "portuguese", column: "search_vector_pt"}
+}
+
+func (r *JobRepositoryImpl) UpdateStatus(ctx context.Context, id uuid.UUID, from, to string) (bool, error) {
+	updates := map[string]interface{}{"status": to}
+	if to == string(domain.JobStatusOpen) {
+		updates["opened_at"] = gorm.Expr("COALESCE(opened_at, ?)", time.Now())
+	}
+	result := database.DB(ctx, r.db).Model(&domain.Job{}).Where("id = ? AND status = ?", id, from).Updates(updates)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *JobRepositoryImpl) GetByCreatedBy(ctx context.Context, createdBy uuid.UUID, status string, page utils.PageRequest) ([]*domain.Job, int64, error) {
 	var jobs []*domain.Job
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Job{}).Where("created_by = ?", createdBy)
+	query := database.DB(ctx, r.db).Model(&domain.Job{}).Where("created_by = ?", createdBy)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if !page.Keyset {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
 	}
 
-	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&jobs).Error
+	page = page.WithDefaultSort(utils.SortField{Column: "jobs.created_at", Desc: true})
+	err := query.Scopes(database.Paginate(page, "jobs.created_at", "jobs.id")).Find(&jobs).Error
 	return jobs, total, err
 }
+
+func (r *JobRepositoryImpl) GetDueForPublish(ctx context.Context, now time.Time) ([]*domain.Job, error) {
+	var jobs []*domain.Job
+	err := database.DB(ctx, r.db).
+		Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", domain.JobStatusScheduled, now).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *JobRepositoryImpl) GetDueForClose(ctx context.Context, now time.Time) ([]*domain.Job, error) {
+	var jobs []*domain.Job
+	err := database.DB(ctx, r.db).
+		Where("status IN ? AND close_at IS NOT NULL AND close_at <= ?", []domain.JobStatus{domain.JobStatusOpen, domain.JobStatusPaused}, now).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *JobRepositoryImpl) IncrementApplicationCount(ctx context.Context, id uuid.UUID) (int, error) {
+	var count int
+	err := database.DB(ctx, r.db).
+		Raw("UPDATE jobs SET application_count = application_count + 1 WHERE id = ? RETURNING application_count", id).
+		Scan(&count).Error
+	return count, err
+}
@@ -0,0 +1,223 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDB returns a connection on a schema of its own in the database named
+// by TEST_DATABASE_DSN (key=value form), skipping the test when it is unset.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+
+	config := &gorm.Config{Logger: logger.Discard}
+	admin, err := gorm.Open(postgres.Open(dsn), config)
+	require.NoError(t, err)
+
+	schema := "jobs_test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+schema).Error)
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	db, err := gorm.Open(postgres.Open(dsn+" search_path="+schema), config)
+	require.NoError(t, err)
+	return db
+}
+
+// listSQL returns the query List runs to fetch a page, without a database.
+func listSQL(t *testing.T, filter domain.JobListFilter, page utils.PageRequest) string {
+	t.Helper()
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	// A dry run keeps the SQL of the count query, which List's page query
+	// reuses the statement of; reset it as a real run does.
+	err = db.Callback().Query().Before("gorm:query").Register("test:reset", func(db *gorm.DB) {
+		db.Statement.SQL.Reset()
+		db.Statement.Vars = nil
+	})
+	require.NoError(t, err)
+
+	var queries []string
+	err = db.Callback().Query().After("gorm:query").Register("test:capture", func(db *gorm.DB) {
+		queries = append(queries, db.Statement.SQL.String())
+	})
+	require.NoError(t, err)
+
+	_, _, err = NewJobRepository(db).List(context.Background(), filter, page)
+	require.NoError(t, err)
+	require.NotEmpty(t, queries)
+	return queries[len(queries)-1]
+}
+
+func TestSearchHighlightsEscapeJobText(t *testing.T) {
+	sql := listSQL(t, domain.JobListFilter{Query: "golang"}, utils.PageRequest{Limit: 10})
+
+	for _, headline := range strings.Split(sql, "ts_headline(")[1:] {
+		assert.Contains(t, headline, "replace(replace(replace(replace(replace(")
+		assert.Contains(t, headline, "'&', '&amp;'")
+		assert.Contains(t, headline, "'<', '&lt;'")
+	}
+	assert.Equal(t, 2, strings.Count(sql, "ts_headline("), sql)
+}
+
+func TestRankedSearchesOrderByRankAndRefuseCursors(t *testing.T) {
+	sql := listSQL(t, domain.JobListFilter{Query: "golang"}, utils.PageRequest{Limit: 10})
+	assert.Contains(t, sql, "ORDER BY search_rank DESC NULLS LAST,jobs.created_at DESC NULLS LAST,jobs.id ASC", sql)
+
+	sql = listSQL(t, domain.JobListFilter{Near: &domain.GeoPoint{Latitude: -23.55, Longitude: -46.63}}, utils.PageRequest{Limit: 10})
+	assert.Contains(t, sql, "ORDER BY distance_km ASC NULLS LAST", sql)
+
+	repo := NewJobRepository(nil)
+	for _, filter := range []domain.JobListFilter{{Query: "golang"}, {Near: &domain.GeoPoint{}}} {
+		_, _, err := repo.List(context.Background(), filter, utils.PageRequest{Limit: 10, Keyset: true})
+		assert.ErrorIs(t, err, utils.ErrRankedWithCursor)
+	}
+}
+
+func TestUpdateLeavesStatusChangedSinceTheRead(t *testing.T) {
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+
+	var sql string
+	err = db.Callback().Update().After("gorm:update").Register("test:capture", func(db *gorm.DB) {
+		sql = db.Statement.SQL.String()
+	})
+	require.NoError(t, err)
+
+	// The job was read while open; the scheduler has closed it since.
+	job := &domain.Job{ID: uuid.New(), Title: "Backend Engineer", Status: string(domain.JobStatusOpen), ApplicationCount: 3}
+	require.NoError(t, NewJobRepository(db).Update(context.Background(), job))
+
+	assert.Contains(t, sql, `"title"=`)
+	for _, column := range lifecycleColumns {
+		assert.NotContains(t, sql, `"`+column+`"=`, sql)
+	}
+}
+
+// facetSQL returns the count query of each facet, without a database.
+func facetSQL(t *testing.T, filter domain.JobListFilter) map[string]string {
+	t.Helper()
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	queries := make(map[string]string)
+	for name, query := range jobFacetQueries(db, filter, 10) {
+		var counts []domain.FacetCount
+		queries[name] = query.Scan(&counts).Statement.SQL.String()
+	}
+	return queries
+}
+
+func TestFacetsApplyEveryFilterButTheirOwn(t *testing.T) {
+	filters := map[string]string{
+		"categories":       "LOWER(category) = $",
+		"work_modes":       "work_mode = $",
+		"employment_types": "employment_type = $",
+		"seniorities":      "seniority = $",
+		"skills":           "job_skills WHERE skill_id IN",
+	}
+	queries := facetSQL(t, domain.JobListFilter{
+		Status:         string(domain.JobStatusOpen),
+		Category:       "engineering",
+		WorkMode:       "remote",
+		EmploymentType: "full_time",
+		Seniority:      "senior",
+		SkillIDs:       []uuid.UUID{uuid.New()},
+	})
+	require.Len(t, queries, 6)
+
+	for name, sql := range queries {
+		assert.Contains(t, sql, "status = $", name)
+		for dimension, condition := range filters {
+			if dimension == name {
+				assert.NotContains(t, sql, condition, name)
+			} else {
+				assert.Contains(t, sql, condition, name)
+			}
+		}
+	}
+}
+
+func TestSkillFilterMatchesAnyOrAllSkills(t *testing.T) {
+	skills := []uuid.UUID{uuid.New(), uuid.New()}
+
+	sql := listSQL(t, domain.JobListFilter{SkillIDs: skills}, utils.PageRequest{Limit: 10})
+	assert.Contains(t, sql, "jobs.id IN (SELECT job_id FROM job_skills WHERE skill_id IN ($1,$2))", sql)
+	assert.NotContains(t, sql, "HAVING", sql)
+
+	sql = listSQL(t, domain.JobListFilter{SkillIDs: skills, SkillMatch: domain.SkillMatchAll}, utils.PageRequest{Limit: 10})
+	assert.Contains(t, sql, "skill_id IN ($1,$2) GROUP BY job_id HAVING COUNT(DISTINCT skill_id) = $3", sql)
+
+	queries := facetSQL(t, domain.JobListFilter{SkillIDs: skills, SkillMatch: domain.SkillMatchAll})
+	assert.Contains(t, queries["categories"], "HAVING COUNT(DISTINCT skill_id) = $3", queries["categories"])
+}
+
+func TestAnnualSalarySQLCoversEveryPeriod(t *testing.T) {
+	sql := annualSalarySQL("salary_max")
+
+	for period, perYear := range domain.SalaryPeriodsPerYear {
+		assert.Contains(t, sql, fmt.Sprintf("WHEN '%s' THEN %g", period, perYear))
+	}
+	assert.Contains(t, sql, "(SELECT 1 / rate FROM exchange_rates WHERE from_currency = @currency AND to_currency = jobs.salary_currency)) END")
+}
+
+func TestAnnualSalaryConvertsPeriodsAndCurrencies(t *testing.T) {
+	db := testDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE jobs (id INTEGER, salary_max DECIMAL(12,2), salary_period VARCHAR(20), salary_currency VARCHAR(3))").Error)
+	require.NoError(t, db.Exec("CREATE TABLE exchange_rates (from_currency VARCHAR(3), to_currency VARCHAR(3), rate DECIMAL(18,8))").Error)
+	require.NoError(t, db.Exec("INSERT INTO exchange_rates VALUES ('USD', 'BRL', 5)").Error)
+	require.NoError(t, db.Exec(`INSERT INTO jobs VALUES
+		(1, 10, 'hourly', 'BRL'),
+		(2, 1000, 'monthly', 'USD'),
+		(3, 5000, 'monthly', 'BRL'),
+		(4, 100, 'yearly', 'EUR')`).Error)
+
+	annual := func(currency string) map[int]*float64 {
+		var rows []struct {
+			ID     int
+			Annual *float64
+		}
+		err := db.Raw("SELECT id, "+annualSalarySQL("salary_max")+" AS annual FROM jobs", map[string]interface{}{"currency": currency}).Scan(&rows).Error
+		require.NoError(t, err)
+
+		byID := make(map[int]*float64)
+		for _, row := range rows {
+			byID[row.ID] = row.Annual
+		}
+		return byID
+	}
+
+	brl := annual("BRL")
+	assert.InDelta(t, 20800, *brl[1], 0.01, "hourly in the same currency")
+	assert.InDelta(t, 60000, *brl[2], 0.01, "direct rate")
+	assert.InDelta(t, 60000, *brl[3], 0.01, "monthly in the same currency")
+	assert.Nil(t, brl[4], "no rate either way")
+
+	usd := annual("USD")
+	assert.InDelta(t, 4160, *usd[1], 0.01, "inverse rate")
+	assert.InDelta(t, 12000, *usd[3], 0.01, "inverse rate")
+	assert.Nil(t, usd[4], "no rate either way")
+
+	var matched []int
+	err := db.Table("jobs").Where("jobs.salary_max IS NULL OR "+annualSalarySQL("salary_max")+" >= @amount",
+		map[string]interface{}{"currency": "BRL", "amount": 0}).Pluck("id", &matched).Error
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 2, 3}, matched, "a job without a rate doesn't match the salary filter")
+}
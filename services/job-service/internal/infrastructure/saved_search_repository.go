@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SavedSearchRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSavedSearchRepository(db *gorm.DB) domain.SavedSearchRepository {
+	return &SavedSearchRepositoryImpl{db: db}
+}
+
+func (r *SavedSearchRepositoryImpl) Create(ctx context.Context, search *domain.SavedSearch) error {
+	return database.DB(ctx, r.db).Create(search).Error
+}
+
+func (r *SavedSearchRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.SavedSearch, error) {
+	var search domain.SavedSearch
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&search).Error
+	if err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (r *SavedSearchRepositoryImpl) GetByUnsubscribeToken(ctx context.Context, token string) (*domain.SavedSearch, error) {
+	var search domain.SavedSearch
+	err := database.DB(ctx, r.db).Where("unsubscribe_token = ?", token).First(&search).Error
+	if err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (r *SavedSearchRepositoryImpl) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.SavedSearch, error) {
+	var searches []domain.SavedSearch
+	err := database.DB(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&searches).Error
+	return searches, err
+}
+
+func (r *SavedSearchRepositoryImpl) Update(ctx context.Context, search *domain.SavedSearch) error {
+	return database.DB(ctx, r.db).Save(search).Error
+}
+
+func (r *SavedSearchRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return database.DB(ctx, r.db).Delete(&domain.SavedSearch{}, id).Error
+}
+
+// GetDueForAlert returns the searches with alerts enabled whose frequency
+// window has elapsed. Instant searches are due on every run.
+func (r *SavedSearchRepositoryImpl) GetDueForAlert(ctx context.Context, now time.Time) ([]domain.SavedSearch, error) {
+	var searches []domain.SavedSearch
+	err := database.DB(ctx, r.db).
+		Where("alerts_enabled").
+		Where(
+			"frequency = ? OR (frequency = ? AND last_alerted_at <= ?) OR (frequency = ? AND last_alerted_at <= ?)",
+			domain.AlertFrequencyInstant,
+			domain.AlertFrequencyDaily, now.Add(-24*time.Hour),
+			domain.AlertFrequencyWeekly, now.Add(-7*24*time.Hour),
+		).
+		Order("last_alerted_at ASC").
+		Find(&searches).Error
+	return searches, err
+}
+
+func (r *SavedSearchRepositoryImpl) MarkAlerted(ctx context.Context, id uuid.UUID, at, through time.Time) error {
+	return database.DB(ctx, r.db).Model(&domain.SavedSearch{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"last_alerted_at": at, "alerted_through": through}).Error
+}
@@ -0,0 +1,39 @@
+package infrastructure
+
+import (
+	"context"
+
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ScreeningQuestionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewScreeningQuestionRepository(db *gorm.DB) domain.ScreeningQuestionRepository {
+	return &ScreeningQuestionRepositoryImpl{db: db}
+}
+
+func (r *ScreeningQuestionRepositoryImpl) CreateBatch(ctx context.Context, questions []domain.ScreeningQuestion) error {
+	if len(questions) == 0 {
+		return nil
+	}
+	return database.DB(ctx, r.db).Create(&questions).Error
+}
+
+func (r *ScreeningQuestionRepositoryImpl) GetByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.ScreeningQuestion, error) {
+	var questions []domain.ScreeningQuestion
+	err := database.DB(ctx, r.db).
+		Where("job_id = ?", jobID).
+		Order("position ASC").
+		Find(&questions).Error
+	return questions, err
+}
+
+func (r *ScreeningQuestionRepositoryImpl) DeleteByJobID(ctx context.Context, jobID uuid.UUID) error {
+	return database.DB(ctx, r.db).Where("job_id = ?", jobID).Delete(&domain.ScreeningQuestion{}).Error
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/database"
+	"recruitment-system/shared/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -20,7 +22,7 @@ func NewSkillRepository(db *gorm.DB) domain.SkillRepository {
 
 func (r *SkillRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Skill, error) {
 	var skill domain.Skill
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&skill).Error
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&skill).Error
 	if err != nil {
 		return nil, err
 	}
@@ -29,15 +31,15 @@ func (r *SkillRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domai
 
 func (r *SkillRepositoryImpl) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.Skill, error) {
 	var skills []domain.Skill
-	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&skills).Error
+	err := database.DB(ctx, r.db).Where("id IN ?", ids).Find(&skills).Error
 	return skills, err
 }
 
-func (r *SkillRepositoryImpl) List(ctx context.Context, category, search string, offset, limit int) ([]*domain.Skill, int64, error) {
+func (r *SkillRepositoryImpl) List(ctx context.Context, category, search string, page utils.PageRequest) ([]*domain.Skill, int64, error) {
 	var skills []*domain.Skill
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Skill{})
+	query := database.DB(ctx, r.db).Model(&domain.Skill{})
 
 	if category != "" {
 		query = query.Where("LOWER(category) = ?", strings.ToLower(category))
@@ -47,21 +49,24 @@ func (r *SkillRepositoryImpl) List(ctx context.Context, category, search string,
 		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(search)+"%")
 	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if !page.Keyset {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
 	}
 
-	err := query.Order("name ASC").Offset(offset).Limit(limit).Find(&skills).Error
+	page = page.WithDefaultSort(utils.SortField{Column: "skills.name"})
+	err := query.Scopes(database.Paginate(page, "skills.created_at", "skills.id")).Find(&skills).Error
 	return skills, total, err
 }
 
 func (r *SkillRepositoryImpl) Create(ctx context.Context, skill *domain.Skill) error {
-	return r.db.WithContext(ctx).Create(skill).Error
+	return database.DB(ctx, r.db).Create(skill).Error
 }
 
 func (r *SkillRepositoryImpl) ExistsByName(ctx context.Context, name string) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&domain.Skill{}).Where("LOWER(name) = ?", strings.ToLower(name)).Count(&count).Error
+	err := database.DB(ctx, r.db).Model(&domain.Skill{}).Where("LOWER(name) = ?", strings.ToLower(name)).Count(&count).Error
 	return count > 0, err
 }
 
@@ -77,22 +82,39 @@ func (r *JobSkillRepositoryImpl) CreateBatch(ctx context.Context, jobSkills []do
 	if len(jobSkills) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).Create(&jobSkills).Error
+	return database.DB(ctx, r.db).Create(&jobSkills).Error
 }
 
 func (r *JobSkillRepositoryImpl) GetByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.JobSkill, error) {
 	var jobSkills []domain.JobSkill
-	err := r.db.WithContext(ctx).
+	err := database.DB(ctx, r.db).
 		Preload("Skill").
 		Where("job_id = ?", jobID).
 		Find(&jobSkills).Error
 	return jobSkills, err
 }
 
+func (r *JobSkillRepositoryImpl) GetByJobIDAndSkillID(ctx context.Context, jobID, skillID uuid.UUID) (*domain.JobSkill, error) {
+	var jobSkill domain.JobSkill
+	err := database.DB(ctx, r.db).
+		Where("job_id = ? AND skill_id = ?", jobID, skillID).
+		First(&jobSkill).Error
+	if err != nil {
+		return nil, err
+	}
+	return &jobSkill, nil
+}
+
 func (r *JobSkillRepositoryImpl) DeleteByJobID(ctx context.Context, jobID uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("job_id = ?", jobID).Delete(&domain.JobSkill{}).Error
+	return database.DB(ctx, r.db).Where("job_id = ?", jobID).Delete(&domain.JobSkill{}).Error
+}
+
+func (r *JobSkillRepositoryImpl) DeleteByJobIDAndSkillID(ctx context.Context, jobID, skillID uuid.UUID) error {
+	return database.DB(ctx, r.db).
+		Where("job_id = ? AND skill_id = ?", jobID, skillID).
+		Delete(&domain.JobSkill{}).Error
 }
 
 func (r *JobSkillRepositoryImpl) Update(ctx context.Context, jobSkill *domain.JobSkill) error {
-	return r.db.WithContext(ctx).Save(jobSkill).Error
+	return database.DB(ctx, r.db).Save(jobSkill).Error
 }
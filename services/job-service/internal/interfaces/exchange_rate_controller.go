@@ -0,0 +1,108 @@
+package interfaces
+
+import (
+	"net/http"
+
+	"recruitment-system/services/job-service/internal/application"
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeRateController struct {
+	jobService *application.JobService
+}
+
+func NewExchangeRateController(jobService *application.JobService) *ExchangeRateController {
+	return &ExchangeRateController{
+		jobService: jobService,
+	}
+}
+
+func (c *ExchangeRateController) ListExchangeRates(ctx *gin.Context) {
+	rates, err := c.jobService.ListExchangeRates(ctx.Request.Context())
+	if err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	responses := make([]domain.ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = mapExchangeRateToResponse(&rate)
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Exchange rates retrieved successfully", responses)
+}
+
+func (c *ExchangeRateController) UpsertExchangeRate(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.jobService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	var req domain.UpsertExchangeRateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	rate, err := c.jobService.UpsertExchangeRate(ctx.Request.Context(), req, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to save exchange rate", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Exchange rate saved successfully", mapExchangeRateToResponse(rate))
+}
+
+func (c *ExchangeRateController) DeleteExchangeRate(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	_, err := c.jobService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	err = c.jobService.DeleteExchangeRate(ctx.Request.Context(), ctx.Param("from"), ctx.Param("to"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to delete exchange rate", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Exchange rate deleted successfully", nil)
+}
+
+func (c *ExchangeRateController) extractToken(ctx *gin.Context) string {
+	authHeader := ctx.GetHeader("Authorization")
+	if authHeader == "" {
+		return ""
+	}
+
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		return authHeader[7:]
+	}
+
+	return ""
+}
+
+func mapExchangeRateToResponse(rate *domain.ExchangeRate) domain.ExchangeRateResponse {
+	return domain.ExchangeRateResponse{
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Rate:         rate.Rate,
+		UpdatedAt:    rate.UpdatedAt,
+	}
+}
@@ -0,0 +1,122 @@
+package interfaces
+
+import (
+	"context"
+
+	"recruitment-system/services/job-service/internal/application"
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/grpcutil"
+	"recruitment-system/shared/pb/jobv1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxJobLookupIDs = 100
+
+// JobGRPCServer serves the internal gRPC API defined in
+// proto/job/v1/job.proto. Like GetInternalJob, it returns jobs in any
+// status.
+type JobGRPCServer struct {
+	jobv1.UnimplementedJobServiceServer
+	jobService *application.JobService
+}
+
+func NewJobGRPCServer(jobService *application.JobService) *JobGRPCServer {
+	return &JobGRPCServer{jobService: jobService}
+}
+
+// GetJob also returns the job's screening questions, which candidate-service
+// validates applications against.
+func (s *JobGRPCServer) GetJob(ctx context.Context, req *jobv1.GetJobRequest) (*jobv1.Job, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job ID %q", req.Id)
+	}
+
+	job, err := s.jobService.GetJobByID(ctx, id)
+	if err != nil {
+		return nil, grpcutil.Error(err)
+	}
+	return toProtoJob(job), nil
+}
+
+func (s *JobGRPCServer) GetJobs(ctx context.Context, req *jobv1.GetJobsRequest) (*jobv1.GetJobsResponse, error) {
+	if len(req.Ids) == 0 || len(req.Ids) > maxJobLookupIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "between 1 and %d job IDs are required", maxJobLookupIDs)
+	}
+
+	ids := make([]uuid.UUID, len(req.Ids))
+	for i, value := range req.Ids {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid job ID %q", value)
+		}
+		ids[i] = id
+	}
+
+	jobs, err := s.jobService.GetJobsByIDs(ctx, ids)
+	if err != nil {
+		return nil, grpcutil.Error(err)
+	}
+
+	response := &jobv1.GetJobsResponse{Jobs: make([]*jobv1.Job, len(jobs))}
+	for i, job := range jobs {
+		response.Jobs[i] = toProtoJob(job)
+	}
+	return response, nil
+}
+
+func (s *JobGRPCServer) IsJobOpen(ctx context.Context, req *jobv1.IsJobOpenRequest) (*jobv1.IsJobOpenResponse, error) {
+	job, err := s.getJob(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &jobv1.IsJobOpenResponse{Open: job.Status == "open"}, nil
+}
+
+func (s *JobGRPCServer) getJob(ctx context.Context, value string) (*domain.Job, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job ID %q", value)
+	}
+
+	jobs, err := s.jobService.GetJobsByIDs(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, grpcutil.Error(err)
+	}
+	if len(jobs) == 0 {
+		return nil, status.Error(codes.NotFound, "job not found")
+	}
+	return jobs[0], nil
+}
+
+func toProtoJob(job *domain.Job) *jobv1.Job {
+	response := &jobv1.Job{
+		Id:          job.ID.String(),
+		Title:       job.Title,
+		Description: job.Description,
+		Location:    job.Location,
+		Status:      job.Status,
+		CreatedBy:   job.CreatedBy.String(),
+	}
+	if job.MaxApplications != nil {
+		maxApplications := int32(*job.MaxApplications)
+		response.MaxApplications = &maxApplications
+	}
+	for _, question := range job.ScreeningQuestions {
+		response.ScreeningQuestions = append(response.ScreeningQuestions, &jobv1.ScreeningQuestion{
+			Id:              question.ID.String(),
+			Question:        question.Question,
+			QuestionType:    question.QuestionType,
+			Options:         question.Options,
+			IsRequired:      question.IsRequired,
+			KnockoutAnswers: question.KnockoutAnswers,
+			MinValue:        question.MinValue,
+			MaxValue:        question.MaxValue,
+			Position:        int32(question.Position),
+		})
+	}
+	return response
+}
@@ -1,8 +1,11 @@
 package interfaces
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"recruitment-system/services/job-service/internal/application"
 	"recruitment-system/services/job-service/internal/domain"
@@ -12,13 +15,41 @@ import (
 	"github.com/google/uuid"
 )
 
+var jobSortFields = utils.SortableFields{
+	"created_at": "jobs.created_at",
+	"publish_at": "jobs.publish_at",
+	"close_at":   "jobs.close_at",
+	"title":      "jobs.title",
+	"salary_min": publicSalarySortColumn("salary_min"),
+	"salary_max": publicSalarySortColumn("salary_max"),
+}
+
+var myJobSortFields = utils.SortableFields{
+	"created_at": "jobs.created_at",
+	"updated_at": "jobs.updated_at",
+	"publish_at": "jobs.publish_at",
+	"close_at":   "jobs.close_at",
+	"title":      "jobs.title",
+	"status":     "jobs.status",
+	"salary_min": "jobs.salary_min",
+	"salary_max": "jobs.salary_max",
+}
+
+// publicSalarySortColumn keeps hidden salaries out of public ordering, which
+// would otherwise leak their relative amounts.
+func publicSalarySortColumn(column string) string {
+	return "(CASE WHEN jobs.salary_hidden THEN NULL ELSE jobs." + column + " END)"
+}
+
 type JobController struct {
 	jobService *application.JobService
+	cursors    *utils.CursorCodec
 }
 
-func NewJobController(jobService *application.JobService) *JobController {
+func NewJobController(jobService *application.JobService, cursors *utils.CursorCodec) *JobController {
 	return &JobController{
 		jobService: jobService,
+		cursors:    cursors,
 	}
 }
 
@@ -65,7 +96,16 @@ func (c *JobController) GetJob(ctx *gin.Context) {
 		return
 	}
 
+	isOwner := c.isJobOwner(ctx, job)
+	if !job.IsPublic() && !isOwner {
+		utils.NotFoundResponse(ctx, "Job")
+		return
+	}
+
 	response := c.mapJobToResponse(job)
+	if !isOwner {
+		redactHiddenSalary(&response)
+	}
 	utils.SuccessResponse(ctx, http.StatusOK, "Job retrieved successfully", response)
 }
 
@@ -168,12 +208,93 @@ func (c *JobController) DeleteJob(ctx *gin.Context) {
 }
 
 func (c *JobController) ListJobs(ctx *gin.Context) {
+	filter, err := c.parseJobListFilter(ctx)
+	if err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
 	pagination := utils.GetPaginationParams(ctx)
+	page, err := c.cursors.PageRequest(pagination, jobSortFieldsFor(filter))
+	if err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+	if page.Keyset && (filter.Query != "" || filter.Near != nil) {
+		utils.ValidationErrorResponse(ctx, utils.ErrRankedWithCursor)
+		return
+	}
+
+	jobs, total, err := c.jobService.ListJobs(ctx.Request.Context(), filter, page)
+	if err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	c.respondWithJobs(ctx, jobs, total, pagination, page, true)
+}
 
+func (c *JobController) GetJobFacets(ctx *gin.Context) {
+	filter, err := c.parseJobListFilter(ctx)
+	if err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("facet_limit", "10"))
+
+	facets, err := c.jobService.GetJobFacets(ctx.Request.Context(), filter, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Job facets retrieved successfully", facets)
+}
+
+// parseJobListFilter reads the public search filters from the query string.
+// Only open jobs are ever listed; malformed salary or UUID values are
+// ignored rather than rejected, but a radius search must be well formed.
+func (c *JobController) parseJobListFilter(ctx *gin.Context) (domain.JobListFilter, error) {
 	filter := domain.JobListFilter{
-		Status:   ctx.Query("status"),
-		Location: ctx.Query("location"),
-		Title:    ctx.Query("title"),
+		Status:         string(domain.JobStatusOpen),
+		Location:       ctx.Query("location"),
+		Title:          ctx.Query("title"),
+		Query:          strings.TrimSpace(ctx.Query("q")),
+		Language:       ctx.DefaultQuery("lang", domain.SearchLanguagePortuguese),
+		SkillMatch:     ctx.DefaultQuery("skill_match", domain.SkillMatchAny),
+		Category:       strings.TrimSpace(ctx.Query("category")),
+		WorkMode:       ctx.Query("work_mode"),
+		EmploymentType: ctx.Query("employment_type"),
+		Seniority:      ctx.Query("seniority"),
+		SalaryCurrency: strings.ToUpper(ctx.DefaultQuery("currency", domain.DefaultSalaryCurrency)),
+		SalaryPeriod:   ctx.DefaultQuery("salary_period", domain.DefaultSalaryPeriod),
+		Country:        ctx.Query("country"),
+		State:          ctx.Query("state"),
+		City:           ctx.Query("city"),
+		IncludeRemote:  ctx.Query("include_remote") == "true",
+	}
+
+	if remoteStr := ctx.Query("remote"); remoteStr != "" {
+		if remote, err := strconv.ParseBool(remoteStr); err == nil {
+			filter.Remote = &remote
+		}
+	}
+
+	near, err := c.parseNearPoint(ctx)
+	if err != nil {
+		return filter, err
+	}
+	if near != nil {
+		filter.Near = near
+		filter.RadiusKm = domain.DefaultSearchRadiusKm
+		if radiusStr := ctx.Query("radius_km"); radiusStr != "" {
+			radius, err := strconv.ParseFloat(radiusStr, 64)
+			if err != nil || radius <= 0 || radius > domain.MaxSearchRadiusKm {
+				return filter, fmt.Errorf("radius_km must be between 0 and %d", domain.MaxSearchRadiusKm)
+			}
+			filter.RadiusKm = radius
+		}
 	}
 
 	if minSalaryStr := ctx.Query("min_salary"); minSalaryStr != "" {
@@ -188,19 +309,47 @@ func (c *JobController) ListJobs(ctx *gin.Context) {
 		}
 	}
 
-	jobs, total, err := c.jobService.ListJobs(ctx.Request.Context(), filter, pagination.Page, pagination.Limit)
-	if err != nil {
-		utils.InternalServerErrorResponse(ctx, err)
-		return
+	for _, raw := range strings.Split(ctx.Query("skill_ids"), ",") {
+		if skillID, err := uuid.Parse(strings.TrimSpace(raw)); err == nil {
+			filter.SkillIDs = append(filter.SkillIDs, skillID)
+		}
 	}
 
-	responses := make([]domain.JobResponse, len(jobs))
-	for i, job := range jobs {
-		responses[i] = c.mapJobToResponse(job)
+	return filter, nil
+}
+
+// parseNearPoint reads the centre of a radius search, given either as
+// near=lat,lon or as near_city with optional near_state and near_country.
+func (c *JobController) parseNearPoint(ctx *gin.Context) (*domain.GeoPoint, error) {
+	if near := ctx.Query("near"); near != "" {
+		latStr, lonStr, ok := strings.Cut(near, ",")
+		latitude, latErr := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+		longitude, lonErr := strconv.ParseFloat(strings.TrimSpace(lonStr), 64)
+		if !ok || latErr != nil || lonErr != nil || latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+			return nil, errors.New("near must be formatted as latitude,longitude")
+		}
+		return &domain.GeoPoint{Latitude: latitude, Longitude: longitude}, nil
 	}
 
-	paginationInfo := utils.CreatePagination(pagination.Page, pagination.Limit, total)
-	utils.PaginatedSuccessResponse(ctx, http.StatusOK, "Jobs retrieved successfully", responses, paginationInfo)
+	if city := strings.TrimSpace(ctx.Query("near_city")); city != "" {
+		return c.jobService.ResolveCity(city, ctx.Query("near_state"), ctx.Query("near_country"))
+	}
+
+	return nil, nil
+}
+
+func jobSortFieldsFor(filter domain.JobListFilter) utils.SortableFields {
+	fields := make(utils.SortableFields, len(jobSortFields)+2)
+	for name, column := range jobSortFields {
+		fields[name] = column
+	}
+	if filter.Query != "" {
+		fields["relevance"] = "search_rank"
+	}
+	if filter.Near != nil {
+		fields["distance"] = "distance_km"
+	}
+	return fields
 }
 
 func (c *JobController) GetMyJobs(ctx *gin.Context) {
@@ -217,22 +366,262 @@ func (c *JobController) GetMyJobs(ctx *gin.Context) {
 	}
 
 	pagination := utils.GetPaginationParams(ctx)
+	page, err := c.cursors.PageRequest(pagination, myJobSortFields)
+	if err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
 
-	jobs, total, err := c.jobService.GetJobsByCreatedBy(ctx.Request.Context(), userInfo.ID, pagination.Page, pagination.Limit)
+	jobs, total, err := c.jobService.GetJobsByCreatedBy(ctx.Request.Context(), userInfo.ID, ctx.Query("status"), page)
 	if err != nil {
 		utils.InternalServerErrorResponse(ctx, err)
 		return
 	}
 
+	c.respondWithJobs(ctx, jobs, total, pagination, page, false)
+}
+
+func (c *JobController) respondWithJobs(ctx *gin.Context, jobs []*domain.Job, total int64, pagination utils.PaginationParams, page utils.PageRequest, public bool) {
+	var cursorInfo utils.CursorPagination
+	if page.Keyset {
+		jobs, cursorInfo = utils.KeysetPage(c.cursors, jobs, page, func(job *domain.Job) utils.Cursor {
+			return utils.Cursor{CreatedAt: job.CreatedAt, ID: job.ID}
+		})
+	}
+
 	responses := make([]domain.JobResponse, len(jobs))
 	for i, job := range jobs {
 		responses[i] = c.mapJobToResponse(job)
+		if public {
+			redactHiddenSalary(&responses[i])
+		}
+	}
+
+	if page.Keyset {
+		utils.CursorPaginatedSuccessResponse(ctx, http.StatusOK, "Jobs retrieved successfully", responses, cursorInfo)
+		return
 	}
 
 	paginationInfo := utils.CreatePagination(pagination.Page, pagination.Limit, total)
 	utils.PaginatedSuccessResponse(ctx, http.StatusOK, "Jobs retrieved successfully", responses, paginationInfo)
 }
 
+func (c *JobController) AddJobSkill(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.jobService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	var req domain.CreateJobSkillRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	job, err := c.jobService.AddJobSkill(ctx.Request.Context(), id, req, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to add job skill", err)
+		return
+	}
+
+	response := c.mapJobToResponse(job)
+	utils.SuccessResponse(ctx, http.StatusCreated, "Job skill added successfully", response)
+}
+
+func (c *JobController) UpdateJobSkill(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.jobService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	skillIDStr := ctx.Param("skillId")
+	skillID, err := uuid.Parse(skillIDStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	var req domain.UpdateJobSkillRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	job, err := c.jobService.UpdateJobSkill(ctx.Request.Context(), id, skillID, req, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to update job skill", err)
+		return
+	}
+
+	response := c.mapJobToResponse(job)
+	utils.SuccessResponse(ctx, http.StatusOK, "Job skill updated successfully", response)
+}
+
+func (c *JobController) RemoveJobSkill(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.jobService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	skillIDStr := ctx.Param("skillId")
+	skillID, err := uuid.Parse(skillIDStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	job, err := c.jobService.RemoveJobSkill(ctx.Request.Context(), id, skillID, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to remove job skill", err)
+		return
+	}
+
+	response := c.mapJobToResponse(job)
+	utils.SuccessResponse(ctx, http.StatusOK, "Job skill removed successfully", response)
+}
+
+func (c *JobController) ReplaceJobSkills(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.jobService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	var req domain.ReplaceJobSkillsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	job, err := c.jobService.ReplaceJobSkills(ctx.Request.Context(), id, req, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to replace job skills", err)
+		return
+	}
+
+	response := c.mapJobToResponse(job)
+	utils.SuccessResponse(ctx, http.StatusOK, "Job skills updated successfully", response)
+}
+
+func (c *JobController) GetScreeningQuestions(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.jobService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	questions, err := c.jobService.GetScreeningQuestions(ctx.Request.Context(), id, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to get screening questions", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Screening questions retrieved successfully", c.mapScreeningQuestionsToDetailResponse(questions))
+}
+
+func (c *JobController) ReplaceScreeningQuestions(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.jobService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	var req domain.ReplaceScreeningQuestionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	questions, err := c.jobService.ReplaceScreeningQuestions(ctx.Request.Context(), id, req, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to update screening questions", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Screening questions updated successfully", c.mapScreeningQuestionsToDetailResponse(questions))
+}
+
 func (c *JobController) extractToken(ctx *gin.Context) string {
 	authHeader := ctx.GetHeader("Authorization")
 	if authHeader == "" {
@@ -246,19 +635,88 @@ func (c *JobController) extractToken(ctx *gin.Context) string {
 	return ""
 }
 
+func (c *JobController) isJobOwner(ctx *gin.Context, job *domain.Job) bool {
+	token := c.extractToken(ctx)
+	if token == "" {
+		return false
+	}
+
+	userInfo, err := c.jobService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		return false
+	}
+
+	return job.CreatedBy == userInfo.ID
+}
+
+// GetInternalJob serves other services, which need jobs in any status
+// (applications of closed or paused jobs are still listed), so visibility
+// rules are not applied.
+func (c *JobController) GetInternalJob(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	job, err := c.jobService.GetJobByID(ctx.Request.Context(), id)
+	if err != nil {
+		utils.NotFoundResponse(ctx, "Job")
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Job retrieved successfully", c.mapJobToResponse(job))
+}
+
+// redactHiddenSalary strips the amounts of a salary the owner chose not to
+// disclose, keeping the currency and period so candidates know the terms.
+func redactHiddenSalary(response *domain.JobResponse) {
+	if response.SalaryHidden {
+		response.SalaryMin = nil
+		response.SalaryMax = nil
+	}
+}
+
 func (c *JobController) mapJobToResponse(job *domain.Job) domain.JobResponse {
 	response := domain.JobResponse{
-		ID:           job.ID,
-		Title:        job.Title,
-		Description:  job.Description,
-		Requirements: job.Requirements,
-		Location:     job.Location,
-		SalaryMin:    job.SalaryMin,
-		SalaryMax:    job.SalaryMax,
-		Status:       job.Status,
-		CreatedBy:    job.CreatedBy,
-		CreatedAt:    job.CreatedAt,
-		UpdatedAt:    job.UpdatedAt,
+		ID:              job.ID,
+		Title:           job.Title,
+		Description:     job.Description,
+		Requirements:    job.Requirements,
+		Location:        job.Location,
+		Country:         job.Country,
+		State:           job.State,
+		City:            job.City,
+		Latitude:        job.Latitude,
+		Longitude:       job.Longitude,
+		IsRemote:        job.IsRemote,
+		DistanceKm:      job.DistanceKm,
+		Category:        job.Category,
+		WorkMode:        job.WorkMode,
+		EmploymentType:  job.EmploymentType,
+		Seniority:       job.Seniority,
+		SalaryMin:       job.SalaryMin,
+		SalaryMax:       job.SalaryMax,
+		SalaryCurrency:  job.SalaryCurrency,
+		SalaryPeriod:    job.SalaryPeriod,
+		SalaryType:      job.SalaryType,
+		SalaryHidden:    job.SalaryHidden,
+		Status:          job.Status,
+		PublishAt:       job.PublishAt,
+		CloseAt:         job.CloseAt,
+		MaxApplications: job.MaxApplications,
+		CreatedBy:       job.CreatedBy,
+		CreatedAt:       job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
+	}
+
+	if job.HighlightedTitle != "" || job.Snippet != "" {
+		rank := job.SearchRank
+		response.SearchRank = &rank
+		response.Highlights = &domain.JobHighlights{
+			Title:   job.HighlightedTitle,
+			Snippet: job.Snippet,
+		}
 	}
 
 	if len(job.Skills) > 0 {
@@ -285,5 +743,36 @@ func (c *JobController) mapJobToResponse(job *domain.Job) domain.JobResponse {
 		}
 	}
 
+	if len(job.ScreeningQuestions) > 0 {
+		response.ScreeningQuestions = make([]domain.ScreeningQuestionResponse, len(job.ScreeningQuestions))
+		for i, question := range job.ScreeningQuestions {
+			response.ScreeningQuestions[i] = c.mapScreeningQuestionToResponse(question)
+		}
+	}
+
 	return response
 }
+
+func (c *JobController) mapScreeningQuestionToResponse(question domain.ScreeningQuestion) domain.ScreeningQuestionResponse {
+	return domain.ScreeningQuestionResponse{
+		ID:           question.ID,
+		Question:     question.Question,
+		QuestionType: question.QuestionType,
+		Options:      question.Options,
+		IsRequired:   question.IsRequired,
+		Position:     question.Position,
+	}
+}
+
+func (c *JobController) mapScreeningQuestionsToDetailResponse(questions []domain.ScreeningQuestion) []domain.ScreeningQuestionDetailResponse {
+	responses := make([]domain.ScreeningQuestionDetailResponse, len(questions))
+	for i, question := range questions {
+		responses[i] = domain.ScreeningQuestionDetailResponse{
+			ScreeningQuestionResponse: c.mapScreeningQuestionToResponse(question),
+			KnockoutAnswers:           question.KnockoutAnswers,
+			MinValue:                  question.MinValue,
+			MaxValue:                  question.MaxValue,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,114 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"recruitment-system/services/job-service/internal/application"
+	"recruitment-system/services/job-service/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type singleJobRepository struct {
+	domain.JobRepository
+	job *domain.Job
+}
+
+func (r singleJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	if id != r.job.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *r.job
+	return &copied, nil
+}
+
+type noJobSkills struct{ domain.JobSkillRepository }
+
+func (noJobSkills) GetByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.JobSkill, error) {
+	return nil, nil
+}
+
+type noScreeningQuestions struct {
+	domain.ScreeningQuestionRepository
+}
+
+func (noScreeningQuestions) GetByJobID(ctx context.Context, jobID uuid.UUID) ([]domain.ScreeningQuestion, error) {
+	return nil, nil
+}
+
+// tokenUsers authenticates each token as the admin it maps to.
+type tokenUsers map[string]uuid.UUID
+
+func (u tokenUsers) ValidateToken(ctx context.Context, token string) (*domain.UserInfo, error) {
+	id, ok := u[token]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &domain.UserInfo{ID: id, Role: "admin"}, nil
+}
+
+func TestGetJobRedactsHiddenSalaryForNonOwners(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	salaryMin, salaryMax := 8000.0, 12000.0
+	owner := uuid.New()
+	job := &domain.Job{
+		ID:             uuid.New(),
+		Title:          "Backend Engineer",
+		Status:         string(domain.JobStatusOpen),
+		SalaryMin:      &salaryMin,
+		SalaryMax:      &salaryMax,
+		SalaryCurrency: "BRL",
+		SalaryPeriod:   domain.SalaryPeriodMonthly,
+		SalaryHidden:   true,
+		CreatedBy:      owner,
+	}
+	users := tokenUsers{"owner-token": owner, "other-token": uuid.New()}
+	service := application.NewJobService(singleJobRepository{job: job}, nil, noJobSkills{}, noScreeningQuestions{}, nil, nil, nil, users, nil)
+
+	router := gin.New()
+	router.GET("/jobs/:id", NewJobController(service, nil).GetJob)
+
+	get := func(token string) domain.JobResponse {
+		request := httptest.NewRequest(http.MethodGet, "/jobs/"+job.ID.String(), nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+		var body struct {
+			Data domain.JobResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		return body.Data
+	}
+
+	for _, token := range []string{"", "other-token"} {
+		response := get(token)
+		assert.Nil(t, response.SalaryMin, token)
+		assert.Nil(t, response.SalaryMax, token)
+		assert.Equal(t, "BRL", response.SalaryCurrency, token)
+		assert.Equal(t, domain.SalaryPeriodMonthly, response.SalaryPeriod, token)
+	}
+
+	response := get("owner-token")
+	require.NotNil(t, response.SalaryMin)
+	require.NotNil(t, response.SalaryMax)
+	assert.Equal(t, salaryMin, *response.SalaryMin)
+	assert.Equal(t, salaryMax, *response.SalaryMax)
+
+	job.SalaryHidden = false
+	response = get("")
+	require.NotNil(t, response.SalaryMin, "disclosed salaries are shown to everyone")
+	assert.Equal(t, salaryMin, *response.SalaryMin)
+}
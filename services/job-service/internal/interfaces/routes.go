@@ -1,20 +1,30 @@
 package interfaces
 
 import (
+	"recruitment-system/shared/health"
+	"recruitment-system/shared/middleware"
+
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, jobController *JobController, skillController *SkillController) {
-	api := router.Group("/api/v1")
+func SetupRoutes(router *gin.Engine, jobController *JobController, skillController *SkillController, exchangeRateController *ExchangeRateController, savedSearchController *SavedSearchController, jwtSecret string, rateLimiter *middleware.RateLimiter, healthChecker *health.Checker) {
+	api := router.Group("/api/v1", rateLimiter.Limit("api"))
 
 	jobs := api.Group("/jobs")
 	{
 		jobs.GET("", jobController.ListJobs)
+		jobs.GET("/facets", jobController.GetJobFacets)
 		jobs.GET("/:id", jobController.GetJob)
 		jobs.POST("", jobController.CreateJob)
 		jobs.PUT("/:id", jobController.UpdateJob)
 		jobs.DELETE("/:id", jobController.DeleteJob)
 		jobs.PATCH("/:id/status", jobController.UpdateJobStatus)
+		jobs.POST("/:id/skills", jobController.AddJobSkill)
+		jobs.PUT("/:id/skills", jobController.ReplaceJobSkills)
+		jobs.PUT("/:id/skills/:skillId", jobController.UpdateJobSkill)
+		jobs.DELETE("/:id/skills/:skillId", jobController.RemoveJobSkill)
+		jobs.GET("/:id/screening-questions", jobController.GetScreeningQuestions)
+		jobs.PUT("/:id/screening-questions", jobController.ReplaceScreeningQuestions)
 		jobs.GET("/my", jobController.GetMyJobs)
 	}
 
@@ -24,10 +34,28 @@ func SetupRoutes(router *gin.Engine, jobController *JobController, skillControll
 		skills.POST("", skillController.CreateSkill)
 	}
 
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"service": "job-service",
-		})
-	})
+	exchangeRates := api.Group("/exchange-rates")
+	{
+		exchangeRates.GET("", exchangeRateController.ListExchangeRates)
+		exchangeRates.PUT("", exchangeRateController.UpsertExchangeRate)
+		exchangeRates.DELETE("/:from/:to", exchangeRateController.DeleteExchangeRate)
+	}
+
+	savedSearches := api.Group("/saved-searches")
+	{
+		savedSearches.GET("/unsubscribe", savedSearchController.Unsubscribe)
+		savedSearches.GET("", savedSearchController.ListSavedSearches)
+		savedSearches.POST("", savedSearchController.CreateSavedSearch)
+		savedSearches.GET("/:id", savedSearchController.GetSavedSearch)
+		savedSearches.PUT("/:id", savedSearchController.UpdateSavedSearch)
+		savedSearches.DELETE("/:id", savedSearchController.DeleteSavedSearch)
+	}
+
+	internal := router.Group("/internal/v1")
+	internal.Use(middleware.ServiceAuthMiddleware(jwtSecret))
+	{
+		internal.GET("/jobs/:id", jobController.GetInternalJob)
+	}
+
+	healthChecker.Register(router)
 }
@@ -0,0 +1,202 @@
+package interfaces
+
+import (
+	"net/http"
+
+	"recruitment-system/services/job-service/internal/application"
+	"recruitment-system/services/job-service/internal/domain"
+	"recruitment-system/shared/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SavedSearchController struct {
+	savedSearchService *application.SavedSearchService
+}
+
+func NewSavedSearchController(savedSearchService *application.SavedSearchService) *SavedSearchController {
+	return &SavedSearchController{
+		savedSearchService: savedSearchService,
+	}
+}
+
+func (c *SavedSearchController) CreateSavedSearch(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.savedSearchService.ValidateUserPermissions(ctx.Request.Context(), token, "candidate")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	var req domain.CreateSavedSearchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	search, err := c.savedSearchService.CreateSavedSearch(ctx.Request.Context(), req, userInfo)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to save search", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Search saved successfully", mapSavedSearchToResponse(search))
+}
+
+func (c *SavedSearchController) ListSavedSearches(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.savedSearchService.ValidateUserPermissions(ctx.Request.Context(), token, "candidate")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	searches, err := c.savedSearchService.ListSavedSearches(ctx.Request.Context(), userInfo.ID)
+	if err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	responses := make([]domain.SavedSearchResponse, len(searches))
+	for i := range searches {
+		responses[i] = mapSavedSearchToResponse(&searches[i])
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Saved searches retrieved successfully", responses)
+}
+
+func (c *SavedSearchController) GetSavedSearch(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.savedSearchService.ValidateUserPermissions(ctx.Request.Context(), token, "candidate")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid saved search ID", err)
+		return
+	}
+
+	search, err := c.savedSearchService.GetSavedSearch(ctx.Request.Context(), id, userInfo.ID)
+	if err != nil {
+		utils.NotFoundResponse(ctx, "Saved search")
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Saved search retrieved successfully", mapSavedSearchToResponse(search))
+}
+
+func (c *SavedSearchController) UpdateSavedSearch(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.savedSearchService.ValidateUserPermissions(ctx.Request.Context(), token, "candidate")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid saved search ID", err)
+		return
+	}
+
+	var req domain.UpdateSavedSearchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	search, err := c.savedSearchService.UpdateSavedSearch(ctx.Request.Context(), id, req, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to update saved search", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Saved search updated successfully", mapSavedSearchToResponse(search))
+}
+
+func (c *SavedSearchController) DeleteSavedSearch(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.savedSearchService.ValidateUserPermissions(ctx.Request.Context(), token, "candidate")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid saved search ID", err)
+		return
+	}
+
+	if err := c.savedSearchService.DeleteSavedSearch(ctx.Request.Context(), id, userInfo.ID); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to delete saved search", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Saved search deleted successfully", nil)
+}
+
+func (c *SavedSearchController) Unsubscribe(ctx *gin.Context) {
+	search, err := c.savedSearchService.Unsubscribe(ctx.Request.Context(), ctx.Query("token"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to unsubscribe", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Alerts disabled for saved search "+search.Name, nil)
+}
+
+func (c *SavedSearchController) extractToken(ctx *gin.Context) string {
+	authHeader := ctx.GetHeader("Authorization")
+	if authHeader == "" {
+		return ""
+	}
+
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		return authHeader[7:]
+	}
+
+	return ""
+}
+
+func mapSavedSearchToResponse(search *domain.SavedSearch) domain.SavedSearchResponse {
+	return domain.SavedSearchResponse{
+		ID:            search.ID,
+		Name:          search.Name,
+		Filter:        search.Filter,
+		Frequency:     search.Frequency,
+		AlertsEnabled: search.AlertsEnabled,
+		LastAlertedAt: search.LastAlertedAt,
+		CreatedAt:     search.CreatedAt,
+		UpdatedAt:     search.UpdatedAt,
+	}
+}
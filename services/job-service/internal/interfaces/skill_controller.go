@@ -10,27 +10,47 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+var skillSortFields = utils.SortableFields{
+	"name":       "skills.name",
+	"category":   "skills.category",
+	"created_at": "skills.created_at",
+}
+
 type SkillController struct {
 	jobService *application.JobService
+	cursors    *utils.CursorCodec
 }
 
-func NewSkillController(jobService *application.JobService) *SkillController {
+func NewSkillController(jobService *application.JobService, cursors *utils.CursorCodec) *SkillController {
 	return &SkillController{
 		jobService: jobService,
+		cursors:    cursors,
 	}
 }
 
 func (c *SkillController) ListSkills(ctx *gin.Context) {
 	pagination := utils.GetPaginationParams(ctx)
+	page, err := c.cursors.PageRequest(pagination, skillSortFields)
+	if err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
 	category := ctx.Query("category")
 	search := ctx.Query("search")
 
-	skills, total, err := c.jobService.ListSkills(ctx.Request.Context(), category, search, pagination.Page, pagination.Limit)
+	skills, total, err := c.jobService.ListSkills(ctx.Request.Context(), category, search, page)
 	if err != nil {
 		utils.InternalServerErrorResponse(ctx, err)
 		return
 	}
 
+	var cursorInfo utils.CursorPagination
+	if page.Keyset {
+		skills, cursorInfo = utils.KeysetPage(c.cursors, skills, page, func(skill *domain.Skill) utils.Cursor {
+			return utils.Cursor{CreatedAt: skill.CreatedAt, ID: skill.ID}
+		})
+	}
+
 	responses := make([]domain.SkillResponse, len(skills))
 	for i, skill := range skills {
 		responses[i] = domain.SkillResponse{
@@ -40,6 +60,11 @@ func (c *SkillController) ListSkills(ctx *gin.Context) {
 		}
 	}
 
+	if page.Keyset {
+		utils.CursorPaginatedSuccessResponse(ctx, http.StatusOK, "Skills retrieved successfully", responses, cursorInfo)
+		return
+	}
+
 	paginationInfo := utils.CreatePagination(pagination.Page, pagination.Limit, total)
 	utils.PaginatedSuccessResponse(ctx, http.StatusOK, "Skills retrieved successfully", responses, paginationInfo)
 }
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"recruitment-system/services/notification-service/internal/application"
+	"recruitment-system/services/notification-service/internal/infrastructure"
+	"recruitment-system/services/notification-service/internal/interfaces"
+	"recruitment-system/shared/database"
+	"recruitment-system/shared/events"
+	"recruitment-system/shared/grpcutil"
+	"recruitment-system/shared/health"
+	"recruitment-system/shared/httpclient"
+	"recruitment-system/shared/logging"
+	"recruitment-system/shared/metrics"
+	"recruitment-system/shared/middleware"
+	"recruitment-system/shared/serviceauth"
+	"recruitment-system/shared/tracing"
+	"recruitment-system/shared/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	envErr := godotenv.Load()
+	logger := logging.Setup("notification-service")
+	if envErr != nil {
+		logger.Info("No .env file found")
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "notification-service", tracing.GetConfigFromEnv())
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	dbConfig := database.GetConfigFromEnv()
+	db, err := database.NewConnection(dbConfig)
+	if err != nil {
+		logging.Fatal("Failed to connect to database", err)
+	}
+
+	if err := database.TestConnection(context.Background(), db); err != nil {
+		logging.Fatal("Database connection test failed", err)
+	}
+	logger.Info("Database connection established")
+	if err := metrics.InstrumentDB(db, dbConfig.DBName); err != nil {
+		logging.Fatal("Failed to instrument database", err)
+	}
+	if err := tracing.InstrumentDB(db); err != nil {
+		logging.Fatal("Failed to instrument database", err)
+	}
+
+	notificationRepo := infrastructure.NewNotificationRepository(db)
+	settingsRepo := infrastructure.NewSettingsRepository(db)
+	userDirectory := infrastructure.NewUserDirectory(db)
+
+	mailer := infrastructure.NewLogMailer()
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		mailer = infrastructure.NewSMTPMailer(infrastructure.SMTPConfig{
+			Host:     smtpHost,
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnv("SMTP_FROM", "no-reply@recruitment.local"),
+		})
+	}
+
+	serviceTokens := serviceauth.NewTokenSource(getEnv("AUTH_SERVICE_URL", "http://localhost:8083"), serviceauth.Credentials{
+		ClientID:     getEnv("SERVICE_CLIENT_ID", "notification-service"),
+		ClientSecret: os.Getenv("SERVICE_CLIENT_SECRET"),
+	})
+	authConn, err := grpcutil.Dial("auth-service", getEnv("AUTH_SERVICE_GRPC_ADDR", "localhost:9083"), serviceTokens, httpclient.DefaultConfig())
+	if err != nil {
+		logging.Fatal("Failed to connect to auth-service", err)
+	}
+	authClient := infrastructure.NewAuthServiceClient(authConn)
+
+	notificationService := application.NewNotificationService(
+		notificationRepo,
+		settingsRepo,
+		userDirectory,
+		mailer,
+		infrastructure.NewHTTPWebhookSender(),
+		authClient,
+	)
+
+	pollInterval, err := time.ParseDuration(getEnv("EVENT_POLL_INTERVAL", "5s"))
+	if err != nil {
+		logging.Fatal("Invalid EVENT_POLL_INTERVAL", err)
+	}
+	broker, err := events.NewBroker(getEnv("EVENT_BROKER", "postgres"), db, dbConfig.DSN(), pollInterval)
+	if err != nil {
+		logging.Fatal("Failed to create event broker", err)
+	}
+	handler := events.Idempotent(db, "notification-service", notificationService.HandleDomainEvent)
+	if err := broker.Subscribe(context.Background(), "notification-service", handler); err != nil {
+		logging.Fatal("Failed to subscribe to domain events", err)
+	}
+
+	webhookTimeout, err := time.ParseDuration(getEnv("WEBHOOK_TIMEOUT", "10s"))
+	if err != nil {
+		logging.Fatal("Invalid WEBHOOK_TIMEOUT", err)
+	}
+	webhookRetryBase, err := time.ParseDuration(getEnv("WEBHOOK_RETRY_BASE_DELAY", "30s"))
+	if err != nil {
+		logging.Fatal("Invalid WEBHOOK_RETRY_BASE_DELAY", err)
+	}
+	webhookService := application.NewWebhookService(
+		infrastructure.NewWebhookSubscriptionRepository(db),
+		infrastructure.NewWebhookDeliveryRepository(db),
+		infrastructure.NewHTTPWebhookPoster(webhookTimeout),
+		authClient,
+		webhookRetryBase,
+	)
+	webhookHandler := events.Idempotent(db, "integration-webhooks", webhookService.HandleDomainEvent)
+	if err := broker.Subscribe(context.Background(), "integration-webhooks", webhookHandler); err != nil {
+		logging.Fatal("Failed to subscribe to domain events", err)
+	}
+
+	webhookInterval, err := time.ParseDuration(getEnv("WEBHOOK_DELIVERY_INTERVAL", "10s"))
+	if err != nil {
+		logging.Fatal("Invalid WEBHOOK_DELIVERY_INTERVAL", err)
+	}
+	webhookScheduler := application.NewWebhookScheduler(webhookService, webhookInterval)
+	go webhookScheduler.Start(context.Background())
+
+	cursors := utils.NewCursorCodec(getEnv("CURSOR_SECRET", "your-cursor-secret"))
+	notificationController := interfaces.NewNotificationController(notificationService, cursors)
+	webhookController := interfaces.NewWebhookController(webhookService, cursors)
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(middleware.TrustedProxies(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		logging.Fatal("Invalid TRUSTED_PROXIES", err)
+	}
+	router.Use(gin.Recovery(), middleware.RequestID(), tracing.Middleware("notification-service"), middleware.RequestLogger(logger), metrics.Middleware(), middleware.GatewayIdentity(getEnv("GATEWAY_SECRET", "")))
+
+	router.Use(func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+
+		c.Next()
+	})
+
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+	rateLimitStore, err := middleware.NewRateLimitStore(getEnv("RATE_LIMIT_STORE", "memory"), db)
+	if err != nil {
+		logging.Fatal("Invalid RATE_LIMIT_STORE", err)
+	}
+	rateLimiter, err := middleware.NewRateLimiter(rateLimitStore, os.Getenv("RATE_LIMITS"),
+		middleware.RateLimitPolicy{Name: "api", Limit: 300, Window: time.Minute, Key: middleware.KeyByUser(jwtSecret)},
+	)
+	if err != nil {
+		logging.Fatal("Invalid RATE_LIMITS", err)
+	}
+
+	healthConfig, err := health.GetConfigFromEnv()
+	if err != nil {
+		logging.Fatal("Invalid health check configuration", err)
+	}
+	healthChecker := health.NewChecker("notification-service", healthConfig).
+		Add("database", health.Database(db)).
+		Add("schema", health.SchemaVersion(db)).
+		Add("auth-service", health.GRPC(authConn))
+
+	interfaces.SetupRoutes(router, notificationController, webhookController, rateLimiter, healthChecker)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	port := getEnv("PORT", "8085")
+	logger.Info("Notification Service starting", "port", port)
+
+	if err := router.Run(":" + port); err != nil {
+		logging.Fatal("Failed to start server", err)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
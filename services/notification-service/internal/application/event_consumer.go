@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/events"
+
+	"github.com/google/uuid"
+)
+
+// HandleDomainEvent turns a domain event from the broker into the
+// notifications it implies. Events nobody is told about are ignored.
+// Notification IDs derive from the message ID so a redelivered message
+// yields the same notifications.
+func (s *NotificationService) HandleDomainEvent(ctx context.Context, msg events.Message) error {
+	notifications, err := notificationsFor(msg)
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		if err := s.HandleEvent(ctx, notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func notificationsFor(msg events.Message) ([]domain.NotificationEvent, error) {
+	notify := func(eventType string, recipientID uuid.UUID, data map[string]string) domain.NotificationEvent {
+		return domain.NotificationEvent{
+			ID:          uuid.NewSHA1(msg.ID, []byte(eventType)),
+			Type:        eventType,
+			RecipientID: recipientID,
+			Data:        data,
+			OccurredAt:  msg.OccurredAt,
+		}
+	}
+
+	switch msg.Type {
+	case events.UserRegistered:
+		var payload events.UserRegisteredPayload
+		if err := msg.Decode(&payload); err != nil {
+			return nil, err
+		}
+		return []domain.NotificationEvent{
+			notify(domain.EventUserRegistered, payload.UserID, map[string]string{"name": payload.Name}),
+		}, nil
+
+	case events.JobStatusChanged:
+		var payload events.JobStatusChangedPayload
+		if err := msg.Decode(&payload); err != nil {
+			return nil, err
+		}
+		// Recruiters already know about changes they made themselves.
+		if !payload.Scheduled {
+			return nil, nil
+		}
+		return []domain.NotificationEvent{
+			notify(domain.EventJobStatusChanged, payload.CreatedBy, map[string]string{
+				"job_id":    payload.JobID.String(),
+				"job_title": payload.Title,
+				"status":    payload.To,
+			}),
+		}, nil
+
+	case events.ApplicationSubmitted:
+		var payload events.ApplicationSubmittedPayload
+		if err := msg.Decode(&payload); err != nil {
+			return nil, err
+		}
+		data := map[string]string{
+			"job_id":         payload.JobID.String(),
+			"job_title":      payload.JobTitle,
+			"application_id": payload.ApplicationID.String(),
+		}
+		received := notify(domain.EventApplicationReceived, payload.RecruiterID, withData(data, "candidate_name", payload.CandidateName))
+		submitted := notify(domain.EventApplicationSubmitted, payload.CandidateUserID, withData(data, "status", payload.Status))
+		return []domain.NotificationEvent{received, submitted}, nil
+
+	case events.ApplicationStatusChanged:
+		var payload events.ApplicationStatusChangedPayload
+		if err := msg.Decode(&payload); err != nil {
+			return nil, err
+		}
+		return []domain.NotificationEvent{
+			notify(domain.EventApplicationStatusChanged, payload.CandidateUserID, map[string]string{
+				"job_id":         payload.JobID.String(),
+				"job_title":      payload.JobTitle,
+				"application_id": payload.ApplicationID.String(),
+				"status":         payload.To,
+				"message":        payload.Message,
+			}),
+		}, nil
+
+	case events.ApplicationJobClosed:
+		var payload events.ApplicationJobClosedPayload
+		if err := msg.Decode(&payload); err != nil {
+			return nil, err
+		}
+		return []domain.NotificationEvent{
+			notify(domain.EventApplicationJobClosed, payload.CandidateUserID, map[string]string{
+				"job_id":         payload.JobID.String(),
+				"job_title":      payload.JobTitle,
+				"application_id": payload.ApplicationID.String(),
+				"status":         payload.Status,
+			}),
+		}, nil
+
+	case events.ResumeProcessed, events.ResumeFailed:
+		var payload events.ResumePayload
+		if err := msg.Decode(&payload); err != nil {
+			return nil, err
+		}
+		eventType := domain.EventResumeProcessed
+		if msg.Type == events.ResumeFailed {
+			eventType = domain.EventResumeFailed
+		}
+		return []domain.NotificationEvent{
+			notify(eventType, payload.CandidateUserID, map[string]string{
+				"resume_id": payload.ResumeID.String(),
+				"filename":  payload.Filename,
+			}),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func withData(data map[string]string, key, value string) map[string]string {
+	copied := make(map[string]string, len(data)+1)
+	for k, v := range data {
+		copied[k] = v
+	}
+	copied[key] = value
+	return copied
+}
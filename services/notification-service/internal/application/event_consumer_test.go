@@ -0,0 +1,63 @@
+package application
+
+import (
+	"testing"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationsForApplicationSubmittedNotifiesBothSides(t *testing.T) {
+	payload := events.ApplicationSubmittedPayload{
+		ApplicationID:   uuid.New(),
+		JobID:           uuid.New(),
+		JobTitle:        "Go Developer",
+		RecruiterID:     uuid.New(),
+		CandidateUserID: uuid.New(),
+		CandidateName:   "Ana",
+		Status:          "applied",
+	}
+	msg, err := events.NewMessage("candidate-service", events.ApplicationSubmitted, payload.ApplicationID, payload)
+	require.NoError(t, err)
+
+	notifications, err := notificationsFor(msg)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+
+	assert.Equal(t, domain.EventApplicationReceived, notifications[0].Type)
+	assert.Equal(t, payload.RecruiterID, notifications[0].RecipientID)
+	assert.Equal(t, "Ana", notifications[0].Data["candidate_name"])
+
+	assert.Equal(t, domain.EventApplicationSubmitted, notifications[1].Type)
+	assert.Equal(t, payload.CandidateUserID, notifications[1].RecipientID)
+	assert.Equal(t, "applied", notifications[1].Data["status"])
+
+	redelivered, err := notificationsFor(msg)
+	require.NoError(t, err)
+	assert.Equal(t, notifications[0].ID, redelivered[0].ID)
+	assert.NotEqual(t, notifications[0].ID, notifications[1].ID)
+}
+
+func TestNotificationsForSkipsManualJobStatusChanges(t *testing.T) {
+	payload := events.JobStatusChangedPayload{JobID: uuid.New(), CreatedBy: uuid.New(), From: "open", To: "closed"}
+	msg, err := events.NewMessage("job-service", events.JobStatusChanged, payload.JobID, payload)
+	require.NoError(t, err)
+
+	notifications, err := notificationsFor(msg)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+
+	payload.Scheduled = true
+	msg, err = events.NewMessage("job-service", events.JobStatusChanged, payload.JobID, payload)
+	require.NoError(t, err)
+
+	notifications, err = notificationsFor(msg)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, payload.CreatedBy, notifications[0].RecipientID)
+	assert.Equal(t, "closed", notifications[0].Data["status"])
+}
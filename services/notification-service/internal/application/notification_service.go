@@ -0,0 +1,300 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/egress"
+	"recruitment-system/shared/gatewayauth"
+	"recruitment-system/shared/httpclient"
+	"recruitment-system/shared/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationService struct {
+	notificationRepo domain.NotificationRepository
+	settingsRepo     domain.SettingsRepository
+	users            domain.UserDirectory
+	mailer           domain.Mailer
+	webhooks         domain.WebhookSender
+	authClient       domain.AuthServiceClient
+}
+
+func NewNotificationService(
+	notificationRepo domain.NotificationRepository,
+	settingsRepo domain.SettingsRepository,
+	users domain.UserDirectory,
+	mailer domain.Mailer,
+	webhooks domain.WebhookSender,
+	authClient domain.AuthServiceClient,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		settingsRepo:     settingsRepo,
+		users:            users,
+		mailer:           mailer,
+		webhooks:         webhooks,
+		authClient:       authClient,
+	}
+}
+
+// HandleEvent renders event in the recipient's locale and delivers it on
+// every channel the recipient has enabled. The inbox entry is the record of
+// the notification, so only its failure is returned; e-mail and webhook
+// failures are logged.
+func (s *NotificationService) HandleEvent(ctx context.Context, event domain.NotificationEvent) error {
+	if !domain.IsKnownEventType(event.Type) {
+		return errors.New("unknown event type")
+	}
+	if event.RecipientID == uuid.Nil {
+		return errors.New("recipient is required")
+	}
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	settings, err := s.getSettings(ctx, event.RecipientID)
+	if err != nil {
+		return err
+	}
+
+	enabled, err := s.enabledChannels(ctx, event.RecipientID, event.Type)
+	if err != nil {
+		return err
+	}
+
+	title, body, err := domain.RenderMessage(event.Type, settings.Locale, event.Data)
+	if err != nil {
+		return err
+	}
+
+	if enabled[domain.ChannelInApp] {
+		notification := &domain.Notification{
+			ID:        uuid.New(),
+			UserID:    event.RecipientID,
+			EventID:   event.ID,
+			EventType: event.Type,
+			Title:     title,
+			Body:      body,
+			Data:      event.Data,
+			CreatedAt: event.OccurredAt,
+		}
+		if err := s.notificationRepo.Create(ctx, notification); err != nil {
+			return err
+		}
+	}
+
+	if enabled[domain.ChannelEmail] {
+		email := settings.Email
+		if email == "" {
+			if user, err := s.users.GetUser(ctx, event.RecipientID); err == nil {
+				email = user.Email
+			}
+		}
+		if email != "" {
+			if err := s.mailer.Send(ctx, domain.Email{To: email, Subject: title, Body: body}); err != nil {
+				slog.ErrorContext(ctx, "Failed to send notification e-mail", "event_id", event.ID, "error", err)
+			}
+		}
+	}
+
+	if enabled[domain.ChannelWebhook] && settings.WebhookURL != "" {
+		payload := domain.WebhookPayload{
+			EventID:    event.ID,
+			EventType:  event.Type,
+			UserID:     event.RecipientID,
+			Title:      title,
+			Body:       body,
+			Data:       event.Data,
+			OccurredAt: event.OccurredAt,
+		}
+		if err := s.webhooks.Send(ctx, settings.WebhookURL, settings.WebhookSecret, payload); err != nil {
+			slog.ErrorContext(ctx, "Failed to call notification webhook", "event_id", event.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *NotificationService) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, page utils.PageRequest) ([]domain.Notification, int64, error) {
+	if page.Limit < 1 || page.Limit > 100 {
+		page.Limit = 10
+	}
+
+	return s.notificationRepo.ListByUserID(ctx, userID, unreadOnly, page)
+}
+
+func (s *NotificationService) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return s.notificationRepo.CountUnread(ctx, userID)
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, id, userID uuid.UUID) error {
+	notification, err := s.notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if notification.UserID != userID {
+		return errors.New("you can only update your own notifications")
+	}
+
+	if notification.ReadAt != nil {
+		return nil
+	}
+
+	return s.notificationRepo.MarkRead(ctx, id)
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return s.notificationRepo.MarkAllRead(ctx, userID)
+}
+
+func (s *NotificationService) GetPreferences(ctx context.Context, userID uuid.UUID) (*domain.PreferencesResponse, error) {
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := s.settingsRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PreferencesResponse{
+		Locale:        settings.Locale,
+		Email:         settings.Email,
+		WebhookURL:    settings.WebhookURL,
+		WebhookSecret: settings.WebhookSecret,
+		Preferences:   preferences,
+	}, nil
+}
+
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID uuid.UUID, req domain.UpdatePreferencesRequest) (*domain.PreferencesResponse, error) {
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Locale != "" {
+		settings.Locale = req.Locale
+	}
+	if req.Email != nil {
+		settings.Email = strings.TrimSpace(*req.Email)
+	}
+	if req.WebhookURL != nil {
+		webhookURL := strings.TrimSpace(*req.WebhookURL)
+		if webhookURL != "" {
+			if err := egress.CheckURL(ctx, webhookURL); err != nil {
+				return nil, fmt.Errorf("webhook_url %w", err)
+			}
+		}
+		if webhookURL != settings.WebhookURL {
+			settings.WebhookSecret = ""
+			if webhookURL != "" {
+				if settings.WebhookSecret, err = newWebhookSecret(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		settings.WebhookURL = webhookURL
+	}
+	settings.UpdatedAt = time.Now()
+
+	preferences := make([]domain.NotificationPreference, len(req.Preferences))
+	for i, pref := range req.Preferences {
+		if !domain.IsKnownEventType(pref.EventType) {
+			return nil, errors.New("unknown event type " + pref.EventType)
+		}
+		preferences[i] = domain.NotificationPreference{
+			UserID:    userID,
+			EventType: pref.EventType,
+			Channel:   pref.Channel,
+			Enabled:   pref.Enabled,
+		}
+	}
+
+	if err := s.settingsRepo.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	if err := s.settingsRepo.SavePreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+
+	return s.GetPreferences(ctx, userID)
+}
+
+// ValidateUserPermissions returns the caller's user, taken from the gateway
+// when the request came through it and from auth-service otherwise.
+func (s *NotificationService) ValidateUserPermissions(ctx context.Context, token string, requiredRole string) (*domain.UserInfo, error) {
+	userInfo, err := s.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if requiredRole != "" && userInfo.Role != requiredRole {
+		return nil, errors.New("insufficient permissions")
+	}
+
+	return userInfo, nil
+}
+
+func (s *NotificationService) authenticate(ctx context.Context, token string) (*domain.UserInfo, error) {
+	if user, ok := gatewayauth.FromContext(ctx); ok {
+		return &domain.UserInfo{ID: user.ID, Email: user.Email, Role: user.Role, Name: user.Email}, nil
+	}
+
+	userInfo, err := s.authClient.ValidateToken(ctx, token)
+	if errors.Is(err, httpclient.ErrUnavailable) {
+		return nil, errors.New("authentication service unavailable")
+	}
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+	return userInfo, nil
+}
+
+func (s *NotificationService) getSettings(ctx context.Context, userID uuid.UUID) (*domain.NotificationSettings, error) {
+	settings, err := s.settingsRepo.GetSettings(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &domain.NotificationSettings{UserID: userID, Locale: domain.DefaultLocale}, nil
+	}
+	return settings, err
+}
+
+func (s *NotificationService) enabledChannels(ctx context.Context, userID uuid.UUID, eventType string) (map[string]bool, error) {
+	enabled := make(map[string]bool, len(domain.Channels))
+	for _, channel := range domain.Channels {
+		enabled[channel] = true
+	}
+
+	preferences, err := s.settingsRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pref := range preferences {
+		if pref.EventType == eventType {
+			enabled[pref.Channel] = pref.Enabled
+		}
+	}
+	return enabled, nil
+}
+
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/egress"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type fakeSettingsRepository struct {
+	domain.SettingsRepository
+	saved []domain.NotificationSettings
+}
+
+func (r *fakeSettingsRepository) GetSettings(ctx context.Context, userID uuid.UUID) (*domain.NotificationSettings, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeSettingsRepository) SaveSettings(ctx context.Context, settings *domain.NotificationSettings) error {
+	r.saved = append(r.saved, *settings)
+	return nil
+}
+
+func TestUpdatePreferencesRejectsInternalWebhookURLs(t *testing.T) {
+	repo := &fakeSettingsRepository{}
+	service := &NotificationService{settingsRepo: repo}
+
+	for _, webhookURL := range []string{
+		"http://169.254.169.254/latest/meta-data/",
+		"http://127.0.0.1:8084/api/v1/notifications",
+		"http://localhost:5432/",
+		"http://10.0.0.12/hooks",
+	} {
+		_, err := service.UpdatePreferences(context.Background(), uuid.New(), domain.UpdatePreferencesRequest{WebhookURL: &webhookURL})
+		assert.ErrorIs(t, err, egress.ErrNotPublic, webhookURL)
+	}
+	assert.Empty(t, repo.saved)
+}
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type WebhookScheduler struct {
+	webhookService *WebhookService
+	interval       time.Duration
+}
+
+func NewWebhookScheduler(webhookService *WebhookService, interval time.Duration) *WebhookScheduler {
+	return &WebhookScheduler{
+		webhookService: webhookService,
+		interval:       interval,
+	}
+}
+
+func (s *WebhookScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx)
+		}
+	}
+}
+
+func (s *WebhookScheduler) run(ctx context.Context) {
+	if err := s.webhookService.RunDeliveries(ctx); err != nil {
+		slog.ErrorContext(ctx, "Webhook scheduler run failed", "error", err)
+	}
+}
@@ -0,0 +1,471 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"time"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/egress"
+	"recruitment-system/shared/events"
+	"recruitment-system/shared/gatewayauth"
+	"recruitment-system/shared/httpclient"
+	"recruitment-system/shared/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxWebhookAttempts   = 8
+	maxWebhookRetryDelay = 6 * time.Hour
+	webhookBatchSize     = 50
+	// webhookClaimTimeout hides claimed deliveries from other replicas. It
+	// must outlast sending a whole batch.
+	webhookClaimTimeout = 15 * time.Minute
+)
+
+// WebhookService fans domain events out to the integration webhooks admins
+// subscribe, and retries failed deliveries with exponential backoff.
+type WebhookService struct {
+	subscriptions domain.WebhookSubscriptionRepository
+	deliveries    domain.WebhookDeliveryRepository
+	poster        domain.WebhookPoster
+	authClient    domain.AuthServiceClient
+	retryBase     time.Duration
+	// checkURL vets subscription URLs; tests swap it to reach local
+	// receivers.
+	checkURL func(ctx context.Context, raw string) error
+}
+
+func NewWebhookService(
+	subscriptions domain.WebhookSubscriptionRepository,
+	deliveries domain.WebhookDeliveryRepository,
+	poster domain.WebhookPoster,
+	authClient domain.AuthServiceClient,
+	retryBase time.Duration,
+) *WebhookService {
+	return &WebhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		poster:        poster,
+		authClient:    authClient,
+		retryBase:     retryBase,
+		checkURL:      egress.CheckURL,
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, req domain.CreateWebhookSubscriptionRequest, createdBy uuid.UUID) (*domain.WebhookSubscriptionResponse, error) {
+	if err := s.checkURL(ctx, req.URL); err != nil {
+		return nil, fmt.Errorf("url %w", err)
+	}
+	eventTypes, err := normalizeWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &domain.WebhookSubscription{
+		ID:          uuid.New(),
+		URL:         req.URL,
+		EventTypes:  eventTypes,
+		Secret:      secret,
+		Description: utils.SanitizeString(req.Description),
+		Active:      true,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.subscriptions.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return &domain.WebhookSubscriptionResponse{WebhookSubscription: *subscription, Secret: secret}, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.subscriptions.List(ctx)
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	return s.subscriptions.GetByID(ctx, id)
+}
+
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id uuid.UUID, req domain.UpdateWebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := s.checkURL(ctx, *req.URL); err != nil {
+			return nil, fmt.Errorf("url %w", err)
+		}
+		subscription.URL = *req.URL
+	}
+	if req.EventTypes != nil {
+		eventTypes, err := normalizeWebhookEventTypes(req.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		subscription.EventTypes = eventTypes
+	}
+	if req.Description != nil {
+		subscription.Description = utils.SanitizeString(*req.Description)
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	subscription.UpdatedAt = time.Now()
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// RotateSecret replaces the signing secret. Deliveries from then on,
+// including retries of older events, are signed with the new one.
+func (s *WebhookService) RotateSecret(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscriptionResponse, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	subscription.Secret = secret
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return &domain.WebhookSubscriptionResponse{WebhookSubscription: *subscription, Secret: secret}, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.subscriptions.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.subscriptions.Delete(ctx, id)
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, status string, page utils.PageRequest) ([]domain.WebhookDelivery, int64, error) {
+	if _, err := s.subscriptions.GetByID(ctx, subscriptionID); err != nil {
+		return nil, 0, err
+	}
+	return s.deliveries.ListBySubscriptionID(ctx, subscriptionID, status, page)
+}
+
+func (s *WebhookService) GetDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery, err := s.deliveries.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.SubscriptionID != subscriptionID {
+		return nil, errors.New("delivery does not belong to this subscription")
+	}
+	return delivery, nil
+}
+
+// Redeliver sends a delivery again right away, whatever its status. It
+// does not reset the attempt count, so a failed delivery that fails again
+// stays failed. The delivery is claimed like RunDeliveries does, so one
+// being sent by the scheduler is refused rather than sent twice.
+func (s *WebhookService) Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	if _, err := s.GetDelivery(ctx, subscriptionID, deliveryID); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.subscriptions.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	delivery, err := s.deliveries.Claim(ctx, deliveryID, now, now.Add(webhookClaimTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attempt(ctx, subscription, delivery, true); err != nil {
+		return nil, err
+	}
+	return s.deliveries.GetByID(ctx, delivery.ID)
+}
+
+// HandleDomainEvent queues a delivery of msg for every active subscription
+// to its webhook event types. Sending happens in RunDeliveries.
+func (s *WebhookService) HandleDomainEvent(ctx context.Context, msg events.Message) error {
+	eventTypes, err := webhookEventTypesFor(msg)
+	if err != nil || len(eventTypes) == 0 {
+		return err
+	}
+
+	subscriptions, err := s.subscriptions.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, eventType := range eventTypes {
+		eventID := msg.ID
+		if eventType != msg.Type {
+			eventID = uuid.NewSHA1(msg.ID, []byte(eventType))
+		}
+
+		payload, err := json.Marshal(domain.WebhookEnvelope{
+			ID:         eventID,
+			Type:       eventType,
+			OccurredAt: msg.OccurredAt,
+			Data:       msg.Payload,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, subscription := range subscriptions {
+			if !subscription.Subscribes(eventType) {
+				continue
+			}
+
+			err := s.deliveries.Create(ctx, &domain.WebhookDelivery{
+				ID:             uuid.New(),
+				SubscriptionID: subscription.ID,
+				EventID:        eventID,
+				EventType:      eventType,
+				Payload:        string(payload),
+				Status:         domain.DeliveryStatusPending,
+				NextAttemptAt:  &now,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RunDeliveries sends the deliveries whose next attempt is due. A failing
+// receiver, or a subscription that can't be loaded, only affects its own
+// deliveries. Deliveries are claimed first, so replicas running
+// concurrently never send the same one twice.
+func (s *WebhookService) RunDeliveries(ctx context.Context) error {
+	now := time.Now()
+	due, err := s.deliveries.ClaimDue(ctx, now, now.Add(webhookClaimTimeout), webhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make(map[uuid.UUID]*domain.WebhookSubscription)
+	for i := range due {
+		delivery := &due[i]
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.subscriptions.GetByID(ctx, delivery.SubscriptionID)
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to load webhook subscription", "delivery_id", delivery.ID, "subscription_id", delivery.SubscriptionID, "error", err)
+				if err := s.fail(ctx, delivery, "subscription could not be loaded: "+err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		if !subscription.Active {
+			if err := s.fail(ctx, delivery, "subscription is inactive"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.attempt(ctx, subscription, delivery, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateUserPermissions returns the caller's user, taken from the gateway
+// when the request came through it and from auth-service otherwise.
+func (s *WebhookService) ValidateUserPermissions(ctx context.Context, token string, requiredRole string) (*domain.UserInfo, error) {
+	userInfo, err := s.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if requiredRole != "" && userInfo.Role != requiredRole {
+		return nil, errors.New("insufficient permissions")
+	}
+
+	return userInfo, nil
+}
+
+func (s *WebhookService) authenticate(ctx context.Context, token string) (*domain.UserInfo, error) {
+	if user, ok := gatewayauth.FromContext(ctx); ok {
+		return &domain.UserInfo{ID: user.ID, Email: user.Email, Role: user.Role, Name: user.Email}, nil
+	}
+
+	userInfo, err := s.authClient.ValidateToken(ctx, token)
+	if errors.Is(err, httpclient.ErrUnavailable) {
+		return nil, errors.New("authentication service unavailable")
+	}
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+	return userInfo, nil
+}
+
+// fail gives up on a delivery without sending it.
+func (s *WebhookService) fail(ctx context.Context, delivery *domain.WebhookDelivery, reason string) error {
+	delivery.Status = domain.DeliveryStatusFailed
+	delivery.NextAttemptAt = nil
+	delivery.ClaimedUntil = nil
+	delivery.LastError = reason
+	delivery.UpdatedAt = time.Now()
+	return s.deliveries.Update(ctx, delivery)
+}
+
+// attempt posts the delivery once and records the outcome. Only failures to
+// record it are returned; a receiver error schedules a retry, or fails the
+// delivery once it ran out of attempts.
+func (s *WebhookService) attempt(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery, manual bool) error {
+	started := time.Now()
+	response, err := s.poster.Post(ctx, subscription.URL, subscription.Secret, delivery)
+	now := time.Now()
+
+	attempt := &domain.WebhookDeliveryAttempt{
+		ID:           uuid.New(),
+		DeliveryID:   delivery.ID,
+		ResponseBody: response.Body,
+		DurationMs:   now.Sub(started).Milliseconds(),
+		Manual:       manual,
+		CreatedAt:    now,
+	}
+	delivery.Attempts++
+	delivery.ClaimedUntil = nil
+	delivery.LastStatusCode = nil
+	delivery.LastError = ""
+	delivery.UpdatedAt = now
+
+	switch {
+	case err != nil:
+		attempt.Error = err.Error()
+		delivery.LastError = err.Error()
+	case !response.OK():
+		attempt.Error = fmt.Sprintf("receiver responded with status %d", response.StatusCode)
+		delivery.LastError = attempt.Error
+	}
+	if response.StatusCode != 0 {
+		code := response.StatusCode
+		attempt.StatusCode = &code
+		delivery.LastStatusCode = &code
+	}
+
+	switch {
+	case attempt.Error == "":
+		delivery.Status = domain.DeliveryStatusSucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= maxWebhookAttempts:
+		delivery.Status = domain.DeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+	default:
+		delivery.Status = domain.DeliveryStatusPending
+		next := now.Add(s.retryDelay(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+
+	if err := s.deliveries.AddAttempt(ctx, attempt); err != nil {
+		return err
+	}
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		return err
+	}
+
+	if attempt.Error != "" {
+		slog.WarnContext(ctx, "Webhook delivery failed", "delivery_id", delivery.ID, "url", subscription.URL, "attempt", delivery.Attempts, "error", attempt.Error)
+	}
+	return nil
+}
+
+// retryDelay doubles the base delay after every attempt, up to
+// maxWebhookRetryDelay, and adds up to 20% jitter so receivers coming back
+// up are not hit by every pending delivery at once.
+func (s *WebhookService) retryDelay(attempts int) time.Duration {
+	delay := s.retryBase
+	for i := 1; i < attempts && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxWebhookRetryDelay {
+		delay = maxWebhookRetryDelay
+	}
+	if jitter := int64(delay / 5); jitter > 0 {
+		delay += time.Duration(rand.Int63n(jitter))
+	}
+	return delay
+}
+
+// webhookEventTypesFor maps a domain event to the webhook event types it
+// produces: its own type when integrators can subscribe to it, plus
+// application.hired for applications moving to accepted.
+func webhookEventTypesFor(msg events.Message) ([]string, error) {
+	var eventTypes []string
+	if isWebhookEventType(msg.Type) {
+		eventTypes = append(eventTypes, msg.Type)
+	}
+
+	if msg.Type == events.ApplicationStatusChanged {
+		var payload events.ApplicationStatusChangedPayload
+		if err := msg.Decode(&payload); err != nil {
+			return nil, err
+		}
+		if payload.To == "accepted" {
+			eventTypes = append(eventTypes, domain.WebhookEventApplicationHired)
+		}
+	}
+	return eventTypes, nil
+}
+
+func normalizeWebhookEventTypes(eventTypes []string) ([]string, error) {
+	seen := make(map[string]bool, len(eventTypes))
+	normalized := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !isWebhookEventType(eventType) {
+			return nil, fmt.Errorf("unsupported event type %q", eventType)
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			normalized = append(normalized, eventType)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, errors.New("at least one event type is required")
+	}
+	return normalized, nil
+}
+
+func isWebhookEventType(eventType string) bool {
+	for _, known := range domain.WebhookEventTypes {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
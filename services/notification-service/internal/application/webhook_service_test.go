@@ -0,0 +1,401 @@
+package application
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/services/notification-service/internal/infrastructure"
+	"recruitment-system/shared/events"
+	"recruitment-system/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type memoryWebhookStore struct {
+	subscriptions map[uuid.UUID]*domain.WebhookSubscription
+	deliveries    map[uuid.UUID]*domain.WebhookDelivery
+	attempts      []domain.WebhookDeliveryAttempt
+}
+
+func newMemoryWebhookStore() *memoryWebhookStore {
+	return &memoryWebhookStore{
+		subscriptions: make(map[uuid.UUID]*domain.WebhookSubscription),
+		deliveries:    make(map[uuid.UUID]*domain.WebhookDelivery),
+	}
+}
+
+type memorySubscriptions struct{ *memoryWebhookStore }
+
+func (r memorySubscriptions) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	copied := *subscription
+	r.subscriptions[subscription.ID] = &copied
+	return nil
+}
+
+func (r memorySubscriptions) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *subscription
+	return &copied, nil
+}
+
+func (r memorySubscriptions) List(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return subscriptions, nil
+}
+
+func (r memorySubscriptions) ListActive(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.Active {
+			subscriptions = append(subscriptions, *subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r memorySubscriptions) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return r.Create(ctx, subscription)
+}
+
+func (r memorySubscriptions) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(r.subscriptions, id)
+	return nil
+}
+
+type memoryDeliveries struct{ *memoryWebhookStore }
+
+func (r memoryDeliveries) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	for _, existing := range r.deliveries {
+		if existing.SubscriptionID == delivery.SubscriptionID && existing.EventID == delivery.EventID {
+			return nil
+		}
+	}
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+func (r memoryDeliveries) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *delivery
+	for _, attempt := range r.attempts {
+		if attempt.DeliveryID == id {
+			copied.AttemptLog = append(copied.AttemptLog, attempt)
+		}
+	}
+	return &copied, nil
+}
+
+func (r memoryDeliveries) ListBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, status string, page utils.PageRequest) ([]domain.WebhookDelivery, int64, error) {
+	var deliveries []domain.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.SubscriptionID == subscriptionID && (status == "" || delivery.Status == status) {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+	return deliveries, int64(len(deliveries)), nil
+}
+
+func (r memoryDeliveries) ClaimDue(ctx context.Context, now, until time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	var due []domain.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		claimable := delivery.ClaimedUntil == nil || !delivery.ClaimedUntil.After(now)
+		if delivery.Status == domain.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) && claimable && len(due) < limit {
+			claimed := until
+			delivery.ClaimedUntil = &claimed
+			due = append(due, *delivery)
+		}
+	}
+	return due, nil
+}
+
+func (r memoryDeliveries) Claim(ctx context.Context, id uuid.UUID, now, until time.Time) (*domain.WebhookDelivery, error) {
+	delivery, ok := r.deliveries[id]
+	if !ok || (delivery.ClaimedUntil != nil && delivery.ClaimedUntil.After(now)) {
+		return nil, domain.ErrDeliveryClaimed
+	}
+	claimed := until
+	delivery.ClaimedUntil = &claimed
+	copied := *delivery
+	return &copied, nil
+}
+
+func (r memoryDeliveries) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if _, ok := r.deliveries[delivery.ID]; !ok {
+		return nil
+	}
+	copied := *delivery
+	copied.AttemptLog = nil
+	r.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+func (r memoryDeliveries) AddAttempt(ctx context.Context, attempt *domain.WebhookDeliveryAttempt) error {
+	r.attempts = append(r.attempts, *attempt)
+	return nil
+}
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	rcv.requests = append(rcv.requests, r)
+	rcv.bodies = append(rcv.bodies, body)
+
+	status := http.StatusOK
+	if len(rcv.statuses) > 0 {
+		status, rcv.statuses = rcv.statuses[0], rcv.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+// newLocalWebhookService posts to the test's local receivers, which the
+// egress guard would refuse.
+func newLocalWebhookService(store *memoryWebhookStore, retryBase time.Duration) *WebhookService {
+	poster := infrastructure.NewHTTPWebhookPosterWithClient(&http.Client{Timeout: time.Second})
+	service := NewWebhookService(memorySubscriptions{store}, memoryDeliveries{store}, poster, nil, retryBase)
+	service.checkURL = func(ctx context.Context, raw string) error { return nil }
+	return service
+}
+
+func TestWebhookServiceDeliversRetriesAndRedelivers(t *testing.T) {
+	ctx := context.Background()
+	receiver := &webhookReceiver{statuses: []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	store := newMemoryWebhookStore()
+	service := newLocalWebhookService(store, time.Minute)
+
+	subscription, err := service.CreateSubscription(ctx, domain.CreateWebhookSubscriptionRequest{
+		URL:        server.URL,
+		EventTypes: []string{domain.WebhookEventApplicationHired},
+	}, uuid.New())
+	require.NoError(t, err)
+	require.NotEmpty(t, subscription.Secret)
+
+	payload := events.ApplicationStatusChangedPayload{ApplicationID: uuid.New(), JobTitle: "Go Developer", From: "interview", To: "accepted"}
+	msg, err := events.NewMessage("candidate-service", events.ApplicationStatusChanged, payload.ApplicationID, payload)
+	require.NoError(t, err)
+
+	require.NoError(t, service.HandleDomainEvent(ctx, msg))
+	require.NoError(t, service.HandleDomainEvent(ctx, msg))
+	require.Len(t, store.deliveries, 1, "redelivered events are queued once")
+
+	require.NoError(t, service.RunDeliveries(ctx))
+
+	var delivery *domain.WebhookDelivery
+	for _, d := range store.deliveries {
+		delivery = d
+	}
+	assert.Equal(t, domain.DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, *delivery.LastStatusCode)
+	require.NotNil(t, delivery.NextAttemptAt)
+	assert.True(t, delivery.NextAttemptAt.After(time.Now().Add(59*time.Second)))
+
+	require.NoError(t, service.RunDeliveries(ctx))
+	assert.Equal(t, 1, delivery.Attempts, "retry is not due yet")
+
+	past := time.Now().Add(-time.Second)
+	delivery.NextAttemptAt = &past
+	require.NoError(t, service.RunDeliveries(ctx))
+
+	delivery = store.deliveries[delivery.ID]
+	assert.Equal(t, domain.DeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.NotNil(t, delivery.DeliveredAt)
+
+	redelivered, err := service.Redeliver(ctx, subscription.ID, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, redelivered.Attempts)
+	require.Len(t, redelivered.AttemptLog, 3)
+	assert.True(t, redelivered.AttemptLog[2].Manual)
+
+	require.Len(t, receiver.requests, 3)
+	for i, request := range receiver.requests {
+		var timestamp int64
+		var signature string
+		_, err := fmt.Sscanf(request.Header.Get(infrastructure.WebhookSignatureHeader), "t=%d,sha256=%s", &timestamp, &signature)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+
+		mac := hmac.New(sha256.New, []byte(subscription.Secret))
+		fmt.Fprintf(mac, "%d.%s", timestamp, receiver.bodies[i])
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+		assert.Equal(t, delivery.ID.String(), request.Header.Get(infrastructure.WebhookDeliveryHeader))
+	}
+
+	var envelope domain.WebhookEnvelope
+	require.NoError(t, json.Unmarshal(receiver.bodies[0], &envelope))
+	assert.Equal(t, domain.WebhookEventApplicationHired, envelope.Type)
+	assert.Equal(t, uuid.NewSHA1(msg.ID, []byte(domain.WebhookEventApplicationHired)), envelope.ID)
+}
+
+func TestWebhookServiceGivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	store := newMemoryWebhookStore()
+	service := newLocalWebhookService(store, time.Millisecond)
+
+	_, err := service.CreateSubscription(ctx, domain.CreateWebhookSubscriptionRequest{
+		URL:        server.URL,
+		EventTypes: []string{domain.WebhookEventJobCreated},
+	}, uuid.New())
+	require.NoError(t, err)
+
+	msg, err := events.NewMessage("job-service", events.JobCreated, uuid.New(), events.JobPayload{Title: "Go Developer"})
+	require.NoError(t, err)
+	require.NoError(t, service.HandleDomainEvent(ctx, msg))
+
+	for i := 0; i < maxWebhookAttempts; i++ {
+		for _, delivery := range store.deliveries {
+			if delivery.NextAttemptAt != nil {
+				past := time.Now().Add(-time.Second)
+				delivery.NextAttemptAt = &past
+			}
+		}
+		require.NoError(t, service.RunDeliveries(ctx))
+	}
+
+	for _, delivery := range store.deliveries {
+		assert.Equal(t, domain.DeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, maxWebhookAttempts, delivery.Attempts)
+		assert.Nil(t, delivery.NextAttemptAt)
+	}
+}
+
+func TestWebhookRetryDelayGrowsExponentially(t *testing.T) {
+	service := &WebhookService{retryBase: time.Minute}
+
+	for attempts, base := range map[int]time.Duration{1: time.Minute, 2: 2 * time.Minute, 4: 8 * time.Minute, 20: maxWebhookRetryDelay} {
+		delay := service.retryDelay(attempts)
+		assert.GreaterOrEqual(t, delay, base)
+		assert.Less(t, delay, base+base/5+1)
+	}
+}
+
+func TestCreateSubscriptionRejectsUnknownEventTypes(t *testing.T) {
+	service := newLocalWebhookService(newMemoryWebhookStore(), time.Minute)
+
+	_, err := service.CreateSubscription(context.Background(), domain.CreateWebhookSubscriptionRequest{
+		URL:        "https://hr.example.com/hooks",
+		EventTypes: []string{"user.registered"},
+	}, uuid.New())
+	assert.Error(t, err)
+}
+
+func TestSubscriptionsRefuseInternalURLs(t *testing.T) {
+	store := newMemoryWebhookStore()
+	service := NewWebhookService(memorySubscriptions{store}, memoryDeliveries{store}, nil, nil, time.Minute)
+
+	for _, url := range []string{"http://127.0.0.1:8081/internal/v1/users", "http://169.254.169.254/latest/meta-data", "ftp://hr.example.com"} {
+		_, err := service.CreateSubscription(context.Background(), domain.CreateWebhookSubscriptionRequest{
+			URL:        url,
+			EventTypes: []string{domain.WebhookEventJobCreated},
+		}, uuid.New())
+		assert.Error(t, err, url)
+	}
+	assert.Empty(t, store.subscriptions)
+}
+
+func TestRunDeliveriesFailsDeliveriesOfMissingSubscriptionsAndGoesOn(t *testing.T) {
+	ctx := context.Background()
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	store := newMemoryWebhookStore()
+	service := newLocalWebhookService(store, time.Minute)
+
+	_, err := service.CreateSubscription(ctx, domain.CreateWebhookSubscriptionRequest{
+		URL:        server.URL,
+		EventTypes: []string{domain.WebhookEventJobCreated},
+	}, uuid.New())
+	require.NoError(t, err)
+
+	msg, err := events.NewMessage("job-service", events.JobCreated, uuid.New(), events.JobPayload{Title: "Go Developer"})
+	require.NoError(t, err)
+	require.NoError(t, service.HandleDomainEvent(ctx, msg))
+
+	past := time.Now().Add(-time.Second)
+	orphan := &domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: uuid.New(), EventID: uuid.New(), EventType: domain.WebhookEventJobCreated, Status: domain.DeliveryStatusPending, NextAttemptAt: &past}
+	store.deliveries[orphan.ID] = orphan
+
+	require.NoError(t, service.RunDeliveries(ctx))
+
+	assert.Len(t, receiver.requests, 1)
+	for _, delivery := range store.deliveries {
+		assert.Nil(t, delivery.ClaimedUntil)
+		if delivery.ID == orphan.ID {
+			assert.Equal(t, domain.DeliveryStatusFailed, delivery.Status)
+			assert.Contains(t, delivery.LastError, "subscription")
+		} else {
+			assert.Equal(t, domain.DeliveryStatusSucceeded, delivery.Status)
+		}
+	}
+}
+
+func TestRedeliverRefusesDeliveriesBeingSent(t *testing.T) {
+	ctx := context.Background()
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	store := newMemoryWebhookStore()
+	service := newLocalWebhookService(store, time.Minute)
+
+	subscription, err := service.CreateSubscription(ctx, domain.CreateWebhookSubscriptionRequest{
+		URL:        server.URL,
+		EventTypes: []string{domain.WebhookEventJobCreated},
+	}, uuid.New())
+	require.NoError(t, err)
+
+	msg, err := events.NewMessage("job-service", events.JobCreated, uuid.New(), events.JobPayload{Title: "Go Developer"})
+	require.NoError(t, err)
+	require.NoError(t, service.HandleDomainEvent(ctx, msg))
+
+	now := time.Now()
+	claimed, err := memoryDeliveries{store}.ClaimDue(ctx, now, now.Add(webhookClaimTimeout), webhookBatchSize)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	_, err = service.Redeliver(ctx, subscription.ID, claimed[0].ID)
+	assert.ErrorIs(t, err, domain.ErrDeliveryClaimed)
+	assert.Empty(t, receiver.requests)
+}
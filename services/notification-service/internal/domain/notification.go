@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification event types. They name what the recipient is told, which is
+// not always the domain event behind it: one submitted application becomes
+// application.received for the recruiter and application.submitted for the
+// candidate.
+const (
+	EventUserRegistered           = "user.registered"
+	EventJobStatusChanged         = "job.status_changed"
+	EventApplicationReceived      = "application.received"
+	EventApplicationSubmitted     = "application.submitted"
+	EventApplicationStatusChanged = "application.status_changed"
+	EventApplicationJobClosed     = "application.job_closed"
+	EventResumeProcessed          = "resume.processed"
+	EventResumeFailed             = "resume.failed"
+)
+
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+var Channels = []string{ChannelInApp, ChannelEmail, ChannelWebhook}
+
+const (
+	LocalePortuguese = "pt-BR"
+	LocaleEnglish    = "en"
+	DefaultLocale    = LocalePortuguese
+)
+
+// NotificationEvent is one message for one recipient, rendered with the
+// template of Type from Data.
+type NotificationEvent struct {
+	ID          uuid.UUID
+	Type        string
+	RecipientID uuid.UUID
+	Data        map[string]string
+	OccurredAt  time.Time
+}
+
+// Notification is an entry in a user's in-app inbox.
+type Notification struct {
+	ID        uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID         `json:"user_id" gorm:"type:uuid;not null"`
+	EventID   uuid.UUID         `json:"event_id" gorm:"type:uuid;not null"`
+	EventType string            `json:"event_type" gorm:"not null"`
+	Title     string            `json:"title" gorm:"not null"`
+	Body      string            `json:"body" gorm:"type:text;not null"`
+	Data      map[string]string `json:"data" gorm:"type:jsonb;serializer:json"`
+	ReadAt    *time.Time        `json:"read_at"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func (n *Notification) TableName() string {
+	return "notifications"
+}
+
+// NotificationSettings holds the per-user delivery details. Users without
+// a row get DefaultLocale, their account e-mail and no webhook.
+type NotificationSettings struct {
+	UserID        uuid.UUID `json:"user_id" gorm:"type:uuid;primary_key"`
+	Locale        string    `json:"locale" gorm:"not null;default:'pt-BR'"`
+	Email         string    `json:"email"`
+	WebhookURL    string    `json:"webhook_url"`
+	WebhookSecret string    `json:"-"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (s *NotificationSettings) TableName() string {
+	return "notification_settings"
+}
+
+// NotificationPreference turns one channel on or off for one event type.
+// Every channel is on when the user has no row for it; webhooks also need
+// a URL in the settings.
+type NotificationPreference struct {
+	UserID    uuid.UUID `json:"-" gorm:"type:uuid;primary_key"`
+	EventType string    `json:"event_type" gorm:"primary_key"`
+	Channel   string    `json:"channel" gorm:"primary_key"`
+	Enabled   bool      `json:"enabled"`
+}
+
+func (p *NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type WebhookPayload struct {
+	EventID    uuid.UUID         `json:"event_id"`
+	EventType  string            `json:"event_type"`
+	UserID     uuid.UUID         `json:"user_id"`
+	Title      string            `json:"title"`
+	Body       string            `json:"body"`
+	Data       map[string]string `json:"data"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+type UserInfo struct {
+	ID    uuid.UUID `json:"id"`
+	Email string    `json:"email"`
+	Role  string    `json:"role"`
+	Name  string    `json:"name"`
+}
+
+type PreferenceRequest struct {
+	EventType string `json:"event_type" binding:"required"`
+	Channel   string `json:"channel" binding:"required,oneof=in_app email webhook"`
+	Enabled   bool   `json:"enabled"`
+}
+
+type UpdatePreferencesRequest struct {
+	Locale      string              `json:"locale" binding:"omitempty,oneof=pt-BR en"`
+	Email       *string             `json:"email" binding:"omitempty,email"`
+	WebhookURL  *string             `json:"webhook_url"`
+	Preferences []PreferenceRequest `json:"preferences" binding:"dive"`
+}
+
+type PreferencesResponse struct {
+	Locale        string                   `json:"locale"`
+	Email         string                   `json:"email"`
+	WebhookURL    string                   `json:"webhook_url"`
+	WebhookSecret string                   `json:"webhook_secret,omitempty"`
+	Preferences   []NotificationPreference `json:"preferences"`
+}
+
+type NotificationResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	EventType string            `json:"event_type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data"`
+	Read      bool              `json:"read"`
+	ReadAt    *time.Time        `json:"read_at"`
+	CreatedAt time.Time         `json:"created_at"`
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"recruitment-system/shared/utils"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *Notification) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Notification, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, page utils.PageRequest) ([]Notification, int64, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int64, error)
+	MarkRead(ctx context.Context, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+}
+
+type SettingsRepository interface {
+	GetSettings(ctx context.Context, userID uuid.UUID) (*NotificationSettings, error)
+	SaveSettings(ctx context.Context, settings *NotificationSettings) error
+	GetPreferences(ctx context.Context, userID uuid.UUID) ([]NotificationPreference, error)
+	SavePreferences(ctx context.Context, preferences []NotificationPreference) error
+}
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *WebhookSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error)
+	List(ctx context.Context) ([]WebhookSubscription, error)
+	ListActive(ctx context.Context) ([]WebhookSubscription, error)
+	Update(ctx context.Context, subscription *WebhookSubscription) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookDeliveryRepository interface {
+	// Create ignores a delivery of an event the subscription already has.
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	GetByID(ctx context.Context, id uuid.UUID) (*WebhookDelivery, error)
+	ListBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, status string, page utils.PageRequest) ([]WebhookDelivery, int64, error)
+	// ClaimDue returns up to limit unclaimed pending deliveries due at now
+	// and claims them until until, so other replicas skip them while they
+	// are sent. Deliveries claimed by a replica that dies are claimable
+	// again at until.
+	ClaimDue(ctx context.Context, now, until time.Time, limit int) ([]WebhookDelivery, error)
+	// Claim claims one delivery until until, whatever its status, and
+	// returns ErrDeliveryClaimed when someone else holds it.
+	Claim(ctx context.Context, id uuid.UUID, now, until time.Time) (*WebhookDelivery, error)
+	// Update writes the delivery's columns, including releasing its claim.
+	// It does nothing when the delivery no longer exists.
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+	AddAttempt(ctx context.Context, attempt *WebhookDeliveryAttempt) error
+}
+
+// UserDirectory resolves the account details of a notification recipient.
+type UserDirectory interface {
+	GetUser(ctx context.Context, userID uuid.UUID) (*UserInfo, error)
+}
+
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, url, secret string, payload WebhookPayload) error
+}
+
+// WebhookPoster posts a delivery's payload, signed with secret, to url. An
+// error means no response was received.
+type WebhookPoster interface {
+	Post(ctx context.Context, url, secret string, delivery *WebhookDelivery) (WebhookResponse, error)
+}
+
+type AuthServiceClient interface {
+	ValidateToken(ctx context.Context, token string) (*UserInfo, error)
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+type MessageTemplate struct {
+	Title string
+	Body  string
+}
+
+// messageTemplates holds the pt-BR and English copy for every event type.
+// Templates read the event data map, e.g. {{.job_title}}.
+var messageTemplates = map[string]map[string]MessageTemplate{
+	EventUserRegistered: {
+		LocalePortuguese: {
+			Title: "Bem-vindo(a), {{.name}}",
+			Body:  "Sua conta foi criada. Você receberá aqui as novidades sobre vagas e candidaturas.",
+		},
+		LocaleEnglish: {
+			Title: "Welcome, {{.name}}",
+			Body:  "Your account is ready. Updates about jobs and applications will show up here.",
+		},
+	},
+	EventJobStatusChanged: {
+		LocalePortuguese: {
+			Title: "Vaga {{.job_title}} atualizada",
+			Body:  "O status da vaga \"{{.job_title}}\" mudou para {{.status}}.",
+		},
+		LocaleEnglish: {
+			Title: "Job {{.job_title}} updated",
+			Body:  "The status of \"{{.job_title}}\" changed to {{.status}}.",
+		},
+	},
+	EventApplicationReceived: {
+		LocalePortuguese: {
+			Title: "Nova candidatura para {{.job_title}}",
+			Body:  "{{with .candidate_name}}{{.}}{{else}}Um candidato{{end}} se candidatou à vaga \"{{.job_title}}\".",
+		},
+		LocaleEnglish: {
+			Title: "New application for {{.job_title}}",
+			Body:  "{{with .candidate_name}}{{.}}{{else}}A candidate{{end}} applied to \"{{.job_title}}\".",
+		},
+	},
+	EventApplicationSubmitted: {
+		LocalePortuguese: {
+			Title: "Candidatura enviada",
+			Body:  "Recebemos sua candidatura para a vaga \"{{.job_title}}\". Status atual: {{.status}}.",
+		},
+		LocaleEnglish: {
+			Title: "Application submitted",
+			Body:  "We received your application to \"{{.job_title}}\". Current status: {{.status}}.",
+		},
+	},
+	EventApplicationStatusChanged: {
+		LocalePortuguese: {
+			Title: "Atualização da candidatura para {{.job_title}}",
+			Body:  "Sua candidatura para a vaga \"{{.job_title}}\" mudou para {{.status}}.{{with .message}} {{.}}{{end}}",
+		},
+		LocaleEnglish: {
+			Title: "Update on your application to {{.job_title}}",
+			Body:  "Your application to \"{{.job_title}}\" changed to {{.status}}.{{with .message}} {{.}}{{end}}",
+		},
+	},
+	EventApplicationJobClosed: {
+		LocalePortuguese: {
+			Title: "Vaga encerrada: {{.job_title}}",
+			Body:  "A vaga \"{{.job_title}}\" foi encerrada. Sua candidatura continua registrada e o recrutador ainda pode entrar em contato.",
+		},
+		LocaleEnglish: {
+			Title: "Job closed: {{.job_title}}",
+			Body:  "The job \"{{.job_title}}\" was closed. Your application remains on file and the recruiter may still get in touch.",
+		},
+	},
+	EventResumeProcessed: {
+		LocalePortuguese: {
+			Title: "Currículo processado",
+			Body:  "Seu currículo {{.filename}} foi processado e seu perfil foi atualizado.",
+		},
+		LocaleEnglish: {
+			Title: "Resume processed",
+			Body:  "Your resume {{.filename}} was processed and your profile was updated.",
+		},
+	},
+	EventResumeFailed: {
+		LocalePortuguese: {
+			Title: "Falha ao processar currículo",
+			Body:  "Não foi possível processar seu currículo {{.filename}}. Tente enviá-lo novamente.",
+		},
+		LocaleEnglish: {
+			Title: "Resume processing failed",
+			Body:  "We could not process your resume {{.filename}}. Please try uploading it again.",
+		},
+	},
+}
+
+func IsKnownEventType(eventType string) bool {
+	_, ok := messageTemplates[eventType]
+	return ok
+}
+
+func EventTypes() []string {
+	types := make([]string, 0, len(messageTemplates))
+	for eventType := range messageTemplates {
+		types = append(types, eventType)
+	}
+	return types
+}
+
+// RenderMessage renders the title and body of eventType in locale, falling
+// back to DefaultLocale for unsupported locales.
+func RenderMessage(eventType, locale string, data map[string]string) (string, string, error) {
+	byLocale, ok := messageTemplates[eventType]
+	if !ok {
+		return "", "", fmt.Errorf("unknown event type %q", eventType)
+	}
+
+	tmpl, ok := byLocale[locale]
+	if !ok {
+		tmpl = byLocale[DefaultLocale]
+	}
+
+	title, err := renderText(tmpl.Title, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := renderText(tmpl.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+	return title, body, nil
+}
+
+func renderText(text string, data map[string]string) (string, error) {
+	tmpl, err := template.New("message").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
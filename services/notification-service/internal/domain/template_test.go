@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMessageUsesRecipientLocale(t *testing.T) {
+	data := map[string]string{"job_title": "Go Developer", "candidate_name": "Ana"}
+
+	title, body, err := RenderMessage(EventApplicationReceived, LocaleEnglish, data)
+	require.NoError(t, err)
+	assert.Equal(t, "New application for Go Developer", title)
+	assert.Equal(t, "Ana applied to \"Go Developer\".", body)
+
+	title, _, err = RenderMessage(EventApplicationReceived, LocalePortuguese, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Nova candidatura para Go Developer", title)
+}
+
+func TestRenderMessageFallsBackToDefaultLocaleAndMissingData(t *testing.T) {
+	_, body, err := RenderMessage(EventApplicationReceived, "fr", map[string]string{"job_title": "Go Developer"})
+	require.NoError(t, err)
+	assert.Equal(t, "Um candidato se candidatou à vaga \"Go Developer\".", body)
+}
+
+func TestRenderMessageRejectsUnknownEventType(t *testing.T) {
+	_, _, err := RenderMessage("job.deleted", LocaleEnglish, nil)
+	assert.Error(t, err)
+}
+
+func TestEveryEventTypeHasBothLocales(t *testing.T) {
+	for _, eventType := range EventTypes() {
+		for _, locale := range []string{LocalePortuguese, LocaleEnglish} {
+			_, ok := messageTemplates[eventType][locale]
+			assert.True(t, ok, "%s is missing %s copy", eventType, locale)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Integration webhook event types. They are domain event types, except
+// application.hired, which is derived from an application moving to
+// accepted.
+const (
+	WebhookEventJobCreated               = "job.created"
+	WebhookEventJobClosed                = "job.closed"
+	WebhookEventApplicationSubmitted     = "application.submitted"
+	WebhookEventApplicationStatusChanged = "application.status_changed"
+	WebhookEventApplicationHired         = "application.hired"
+)
+
+var WebhookEventTypes = []string{
+	WebhookEventJobCreated,
+	WebhookEventJobClosed,
+	WebhookEventApplicationSubmitted,
+	WebhookEventApplicationStatusChanged,
+	WebhookEventApplicationHired,
+}
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// WebhookSubscription is an admin-managed endpoint of an external system
+// that receives the selected event types.
+type WebhookSubscription struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	URL         string    `json:"url" gorm:"not null"`
+	EventTypes  []string  `json:"event_types" gorm:"type:jsonb;serializer:json;not null"`
+	Secret      string    `json:"-" gorm:"not null"`
+	Description string    `json:"description"`
+	Active      bool      `json:"active" gorm:"not null;default:true"`
+	CreatedBy   uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (s *WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (s *WebhookSubscription) Subscribes(eventType string) bool {
+	for _, subscribed := range s.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrDeliveryClaimed is returned when a delivery is being sent by someone
+// else.
+var ErrDeliveryClaimed = errors.New("delivery is being sent, try again later")
+
+// WebhookDelivery is one event sent to one subscription. Payload is the
+// exact body posted, so redeliveries carry the same bytes.
+type WebhookDelivery struct {
+	ID             uuid.UUID                `json:"id" gorm:"type:uuid;primary_key"`
+	SubscriptionID uuid.UUID                `json:"subscription_id" gorm:"type:uuid;not null"`
+	EventID        uuid.UUID                `json:"event_id" gorm:"type:uuid;not null"`
+	EventType      string                   `json:"event_type" gorm:"not null"`
+	Payload        string                   `json:"payload" gorm:"type:text;not null"`
+	Status         string                   `json:"status" gorm:"not null;default:'pending'"`
+	Attempts       int                      `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time               `json:"next_attempt_at"`
+	LastStatusCode *int                     `json:"last_status_code"`
+	LastError      string                   `json:"last_error"`
+	DeliveredAt    *time.Time               `json:"delivered_at"`
+	ClaimedUntil   *time.Time               `json:"-"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+	AttemptLog     []WebhookDeliveryAttempt `json:"attempt_log,omitempty" gorm:"foreignKey:DeliveryID"`
+}
+
+func (d *WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeliveryAttempt records one HTTP call of a delivery.
+type WebhookDeliveryAttempt struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	DeliveryID   uuid.UUID `json:"delivery_id" gorm:"type:uuid;not null"`
+	StatusCode   *int      `json:"status_code"`
+	ResponseBody string    `json:"response_body"`
+	Error        string    `json:"error"`
+	DurationMs   int64     `json:"duration_ms"`
+	Manual       bool      `json:"manual"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (a *WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
+
+// WebhookEnvelope is the JSON body posted to integrators.
+type WebhookEnvelope struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// WebhookResponse is what the receiving endpoint answered. StatusCode is
+// zero when no response arrived.
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
+
+func (r WebhookResponse) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	URL         string   `json:"url" binding:"required,url"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1"`
+	Description string   `json:"description" binding:"max=255"`
+}
+
+type UpdateWebhookSubscriptionRequest struct {
+	URL         *string  `json:"url" binding:"omitempty,url"`
+	EventTypes  []string `json:"event_types"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Active      *bool    `json:"active"`
+}
+
+// WebhookSubscriptionResponse includes the secret only when it was just
+// created or rotated.
+type WebhookSubscriptionResponse struct {
+	WebhookSubscription
+	Secret string `json:"secret,omitempty"`
+}
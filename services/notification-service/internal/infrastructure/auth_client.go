@@ -0,0 +1,43 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/pb/authv1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+type AuthServiceClientImpl struct {
+	client authv1.AuthServiceClient
+}
+
+func NewAuthServiceClient(conn grpc.ClientConnInterface) domain.AuthServiceClient {
+	return &AuthServiceClientImpl{
+		client: authv1.NewAuthServiceClient(conn),
+	}
+}
+
+func (c *AuthServiceClientImpl) ValidateToken(ctx context.Context, token string) (*domain.UserInfo, error) {
+	response, err := c.client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: token})
+	if err != nil {
+		return nil, fmt.Errorf("token validation failed: %w", err)
+	}
+
+	userID, err := uuid.Parse(response.User.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	userInfo := &domain.UserInfo{
+		ID:    userID,
+		Email: response.User.GetEmail(),
+		Role:  response.User.GetRole(),
+		Name:  response.User.GetEmail(),
+	}
+
+	return userInfo, nil
+}
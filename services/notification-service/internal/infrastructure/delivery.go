@@ -0,0 +1,120 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/egress"
+)
+
+// LogMailer writes e-mails to the service log. It is used when no SMTP
+// server is configured.
+type LogMailer struct{}
+
+func NewLogMailer() domain.Mailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, email domain.Email) error {
+	slog.InfoContext(ctx, "E-mail", "to", email.To, "subject", email.Subject)
+	return nil
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+func NewSMTPMailer(config SMTPConfig) domain.Mailer {
+	return &SMTPMailer{config: config}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, email domain.Email) error {
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", email.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", email.Subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(email.Body)
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	return smtp.SendMail(addr, auth, m.config.From, []string{email.To}, []byte(msg.String()))
+}
+
+const SignatureHeader = "X-Notification-Signature"
+
+// HTTPWebhookSender posts notifications as JSON. When a secret is set the
+// body is signed with HMAC-SHA256 and sent as "sha256=<hex>" in
+// SignatureHeader. The URLs are chosen by users, so only public addresses
+// are called.
+type HTTPWebhookSender struct {
+	httpClient *http.Client
+}
+
+func NewHTTPWebhookSender() domain.WebhookSender {
+	return &HTTPWebhookSender{
+		httpClient: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: egress.Transport(),
+		},
+	}
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, url, secret string, payload domain.WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+sign(secret, body))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/database"
+	"recruitment-system/shared/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) domain.NotificationRepository {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+func (r *NotificationRepositoryImpl) Create(ctx context.Context, notification *domain.Notification) error {
+	return database.DB(ctx, r.db).Create(notification).Error
+}
+
+func (r *NotificationRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Notification, error) {
+	var notification domain.Notification
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&notification).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *NotificationRepositoryImpl) ListByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, page utils.PageRequest) ([]domain.Notification, int64, error) {
+	var notifications []domain.Notification
+	var total int64
+
+	query := database.DB(ctx, r.db).Model(&domain.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if !page.Keyset {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	page = page.WithDefaultSort(utils.SortField{Column: "created_at", Desc: true})
+	err := query.Scopes(database.Paginate(page, "created_at", "id")).Find(&notifications).Error
+	return notifications, total, err
+}
+
+func (r *NotificationRepositoryImpl) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := database.DB(ctx, r.db).Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *NotificationRepositoryImpl) MarkRead(ctx context.Context, id uuid.UUID) error {
+	return database.DB(ctx, r.db).Model(&domain.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", time.Now()).Error
+}
+
+func (r *NotificationRepositoryImpl) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return database.DB(ctx, r.db).Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
+
+type SettingsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSettingsRepository(db *gorm.DB) domain.SettingsRepository {
+	return &SettingsRepositoryImpl{db: db}
+}
+
+func (r *SettingsRepositoryImpl) GetSettings(ctx context.Context, userID uuid.UUID) (*domain.NotificationSettings, error) {
+	var settings domain.NotificationSettings
+	err := database.DB(ctx, r.db).Where("user_id = ?", userID).First(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *SettingsRepositoryImpl) SaveSettings(ctx context.Context, settings *domain.NotificationSettings) error {
+	return database.DB(ctx, r.db).Save(settings).Error
+}
+
+func (r *SettingsRepositoryImpl) GetPreferences(ctx context.Context, userID uuid.UUID) ([]domain.NotificationPreference, error) {
+	var preferences []domain.NotificationPreference
+	err := database.DB(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("event_type ASC, channel ASC").
+		Find(&preferences).Error
+	return preferences, err
+}
+
+func (r *SettingsRepositoryImpl) SavePreferences(ctx context.Context, preferences []domain.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+	return database.DB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_type"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(&preferences).Error
+}
+
+// UserDirectoryImpl reads recipients from the users table owned by the
+// auth service, as the candidate service does for profiles.
+type UserDirectoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserDirectory(db *gorm.DB) domain.UserDirectory {
+	return &UserDirectoryImpl{db: db}
+}
+
+func (d *UserDirectoryImpl) GetUser(ctx context.Context, userID uuid.UUID) (*domain.UserInfo, error) {
+	var user domain.UserInfo
+	err := database.DB(ctx, d.db).Table("users").
+		Select("id, email, role, name").
+		Where("id = ?", userID).
+		Take(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/egress"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+
+	maxLoggedResponseBody = 1024
+)
+
+// HTTPWebhookPoster sends integration webhooks. Response bodies end up in
+// the delivery log, so only public addresses are called, as for
+// notification webhooks. WebhookSignatureHeader is
+// "t=<unix seconds>,sha256=<hex>", the HMAC-SHA256 of "<t>.<body>", so
+// receivers can reject old requests replayed with a valid signature. The
+// delivery ID header stays the same across retries so receivers can
+// deduplicate.
+type HTTPWebhookPoster struct {
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func NewHTTPWebhookPoster(timeout time.Duration) domain.WebhookPoster {
+	return NewHTTPWebhookPosterWithClient(&http.Client{
+		Timeout:   timeout,
+		Transport: egress.Transport(),
+	})
+}
+
+// NewHTTPWebhookPosterWithClient posts with client as is, without the
+// egress guard, for tests with local receivers.
+func NewHTTPWebhookPosterWithClient(client *http.Client) domain.WebhookPoster {
+	return &HTTPWebhookPoster{httpClient: client, now: time.Now}
+}
+
+// signWebhook returns the WebhookSignatureHeader value for body sent at
+// timestamp.
+func signWebhook(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",sha256=" + sign(secret, append([]byte(t+"."), body...))
+}
+
+func (p *HTTPWebhookPoster) Post(ctx context.Context, url, secret string, delivery *domain.WebhookDelivery) (domain.WebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return domain.WebhookResponse{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "recruitment-system-webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(WebhookSignatureHeader, signWebhook(secret, p.now(), []byte(delivery.Payload)))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return domain.WebhookResponse{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedResponseBody))
+	return domain.WebhookResponse{
+		StatusCode: resp.StatusCode,
+		Body:       string(responseBody),
+	}, nil
+}
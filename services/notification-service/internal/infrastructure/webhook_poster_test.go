@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/egress"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPWebhookPosterSignsPayload(t *testing.T) {
+	delivery := &domain.WebhookDelivery{
+		ID:        uuid.New(),
+		EventType: domain.WebhookEventApplicationSubmitted,
+		Payload:   `{"type":"application.submitted"}`,
+	}
+
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("queued"))
+	}))
+	defer receiver.Close()
+
+	sentAt := time.Unix(1700000000, 0)
+	poster := NewHTTPWebhookPosterWithClient(&http.Client{Timeout: time.Second}).(*HTTPWebhookPoster)
+	poster.now = func() time.Time { return sentAt }
+
+	response, err := poster.Post(context.Background(), receiver.URL, "secret", delivery)
+	require.NoError(t, err)
+
+	assert.True(t, response.OK())
+	assert.Equal(t, http.StatusAccepted, response.StatusCode)
+	assert.Equal(t, "queued", response.Body)
+	assert.Equal(t, delivery.Payload, string(body))
+	assert.Equal(t, "t=1700000000,sha256="+sign("secret", []byte("1700000000."+delivery.Payload)), received.Header.Get(WebhookSignatureHeader))
+	assert.Equal(t, delivery.ID.String(), received.Header.Get(WebhookDeliveryHeader))
+	assert.Equal(t, domain.WebhookEventApplicationSubmitted, received.Header.Get(WebhookEventHeader))
+}
+
+func TestHTTPWebhookPosterRefusesInternalAddresses(t *testing.T) {
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	delivery := &domain.WebhookDelivery{ID: uuid.New(), EventType: domain.WebhookEventJobCreated, Payload: `{}`}
+	_, err := NewHTTPWebhookPoster(time.Second).Post(context.Background(), receiver.URL, "secret", delivery)
+
+	assert.ErrorIs(t, err, egress.ErrNotPublic)
+	assert.False(t, called)
+}
@@ -0,0 +1,149 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/database"
+	"recruitment-system/shared/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookSubscriptionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) domain.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepositoryImpl{db: db}
+}
+
+func (r *WebhookSubscriptionRepositoryImpl) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return database.DB(ctx, r.db).Create(subscription).Error
+}
+
+func (r *WebhookSubscriptionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	err := database.DB(ctx, r.db).Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookSubscriptionRepositoryImpl) List(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	err := database.DB(ctx, r.db).Order("created_at DESC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *WebhookSubscriptionRepositoryImpl) ListActive(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subscriptions []domain.WebhookSubscription
+	err := database.DB(ctx, r.db).Where("active = ?", true).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *WebhookSubscriptionRepositoryImpl) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return database.DB(ctx, r.db).Save(subscription).Error
+}
+
+func (r *WebhookSubscriptionRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return database.DB(ctx, r.db).Delete(&domain.WebhookSubscription{}, id).Error
+}
+
+type WebhookDeliveryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) domain.WebhookDeliveryRepository {
+	return &WebhookDeliveryRepositoryImpl{db: db}
+}
+
+func (r *WebhookDeliveryRepositoryImpl) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return database.DB(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(delivery).Error
+}
+
+func (r *WebhookDeliveryRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := database.DB(ctx, r.db).
+		Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("id = ?", id).
+		First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) ListBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, status string, page utils.PageRequest) ([]domain.WebhookDelivery, int64, error) {
+	var deliveries []domain.WebhookDelivery
+	var total int64
+
+	query := database.DB(ctx, r.db).Model(&domain.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if !page.Keyset {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	page = page.WithDefaultSort(utils.SortField{Column: "created_at", Desc: true})
+	err := query.Scopes(database.Paginate(page, "created_at", "id")).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+func (r *WebhookDeliveryRepositoryImpl) ClaimDue(ctx context.Context, now, until time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := database.DB(ctx, r.db).Raw(`
+		UPDATE webhook_deliveries SET claimed_until = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			AND (claimed_until IS NULL OR claimed_until <= ?)
+			ORDER BY next_attempt_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		until, domain.DeliveryStatusPending, now, now, limit,
+	).Scan(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *WebhookDeliveryRepositoryImpl) Claim(ctx context.Context, id uuid.UUID, now, until time.Time) (*domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := database.DB(ctx, r.db).Raw(`
+		UPDATE webhook_deliveries SET claimed_until = ?
+		WHERE id = ? AND (claimed_until IS NULL OR claimed_until <= ?)
+		RETURNING *`,
+		until, id, now,
+	).Scan(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, domain.ErrDeliveryClaimed
+	}
+	return &deliveries[0], nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return database.DB(ctx, r.db).Model(delivery).Select("*").Omit(clause.Associations).Updates(delivery).Error
+}
+
+func (r *WebhookDeliveryRepositoryImpl) AddAttempt(ctx context.Context, attempt *domain.WebhookDeliveryAttempt) error {
+	return database.DB(ctx, r.db).Create(attempt).Error
+}
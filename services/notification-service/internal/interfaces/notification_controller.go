@@ -0,0 +1,221 @@
+package interfaces
+
+import (
+	"net/http"
+
+	"recruitment-system/services/notification-service/internal/application"
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var notificationSortFields = utils.SortableFields{
+	"created_at": "created_at",
+}
+
+type NotificationController struct {
+	notificationService *application.NotificationService
+	cursors             *utils.CursorCodec
+}
+
+func NewNotificationController(notificationService *application.NotificationService, cursors *utils.CursorCodec) *NotificationController {
+	return &NotificationController{
+		notificationService: notificationService,
+		cursors:             cursors,
+	}
+}
+
+func (c *NotificationController) ListNotifications(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.notificationService.ValidateUserPermissions(ctx.Request.Context(), token, "")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	pagination := utils.GetPaginationParams(ctx)
+	page, err := c.cursors.PageRequest(pagination, notificationSortFields)
+	if err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	notifications, total, err := c.notificationService.ListNotifications(ctx.Request.Context(), userInfo.ID, ctx.Query("unread") == "true", page)
+	if err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	var cursorInfo utils.CursorPagination
+	if page.Keyset {
+		notifications, cursorInfo = utils.KeysetPage(c.cursors, notifications, page, func(notification domain.Notification) utils.Cursor {
+			return utils.Cursor{CreatedAt: notification.CreatedAt, ID: notification.ID}
+		})
+	}
+
+	responses := make([]domain.NotificationResponse, len(notifications))
+	for i := range notifications {
+		responses[i] = mapNotificationToResponse(&notifications[i])
+	}
+
+	if page.Keyset {
+		utils.CursorPaginatedSuccessResponse(ctx, http.StatusOK, "Notifications retrieved successfully", responses, cursorInfo)
+		return
+	}
+
+	paginationInfo := utils.CreatePagination(pagination.Page, pagination.Limit, total)
+	utils.PaginatedSuccessResponse(ctx, http.StatusOK, "Notifications retrieved successfully", responses, paginationInfo)
+}
+
+func (c *NotificationController) CountUnread(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.notificationService.ValidateUserPermissions(ctx.Request.Context(), token, "")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	count, err := c.notificationService.CountUnread(ctx.Request.Context(), userInfo.ID)
+	if err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Unread count retrieved successfully", gin.H{"unread": count})
+}
+
+func (c *NotificationController) MarkRead(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.notificationService.ValidateUserPermissions(ctx.Request.Context(), token, "")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid notification ID", err)
+		return
+	}
+
+	if err := c.notificationService.MarkRead(ctx.Request.Context(), id, userInfo.ID); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to mark notification as read", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Notification marked as read", nil)
+}
+
+func (c *NotificationController) MarkAllRead(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.notificationService.ValidateUserPermissions(ctx.Request.Context(), token, "")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	if err := c.notificationService.MarkAllRead(ctx.Request.Context(), userInfo.ID); err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "All notifications marked as read", nil)
+}
+
+func (c *NotificationController) GetPreferences(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.notificationService.ValidateUserPermissions(ctx.Request.Context(), token, "")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	preferences, err := c.notificationService.GetPreferences(ctx.Request.Context(), userInfo.ID)
+	if err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Preferences retrieved successfully", preferences)
+}
+
+func (c *NotificationController) UpdatePreferences(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.notificationService.ValidateUserPermissions(ctx.Request.Context(), token, "")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	var req domain.UpdatePreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	preferences, err := c.notificationService.UpdatePreferences(ctx.Request.Context(), userInfo.ID, req)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to update preferences", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Preferences updated successfully", preferences)
+}
+
+func (c *NotificationController) extractToken(ctx *gin.Context) string {
+	authHeader := ctx.GetHeader("Authorization")
+	if authHeader == "" {
+		return ""
+	}
+
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		return authHeader[7:]
+	}
+
+	return ""
+}
+
+func mapNotificationToResponse(notification *domain.Notification) domain.NotificationResponse {
+	return domain.NotificationResponse{
+		ID:        notification.ID,
+		EventType: notification.EventType,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Data:      notification.Data,
+		Read:      notification.ReadAt != nil,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"recruitment-system/shared/health"
+	"recruitment-system/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(router *gin.Engine, notificationController *NotificationController, webhookController *WebhookController, rateLimiter *middleware.RateLimiter, healthChecker *health.Checker) {
+	api := router.Group("/api/v1", rateLimiter.Limit("api"))
+
+	notifications := api.Group("/notifications")
+	{
+		notifications.GET("", notificationController.ListNotifications)
+		notifications.GET("/unread-count", notificationController.CountUnread)
+		notifications.POST("/read-all", notificationController.MarkAllRead)
+		notifications.PATCH("/:id/read", notificationController.MarkRead)
+		notifications.GET("/preferences", notificationController.GetPreferences)
+		notifications.PUT("/preferences", notificationController.UpdatePreferences)
+	}
+
+	webhooks := api.Group("/admin/webhooks")
+	{
+		webhooks.POST("", webhookController.CreateSubscription)
+		webhooks.GET("", webhookController.ListSubscriptions)
+		webhooks.GET("/:id", webhookController.GetSubscription)
+		webhooks.PUT("/:id", webhookController.UpdateSubscription)
+		webhooks.DELETE("/:id", webhookController.DeleteSubscription)
+		webhooks.POST("/:id/rotate-secret", webhookController.RotateSecret)
+		webhooks.GET("/:id/deliveries", webhookController.ListDeliveries)
+		webhooks.GET("/:id/deliveries/:deliveryId", webhookController.GetDelivery)
+		webhooks.POST("/:id/deliveries/:deliveryId/redeliver", webhookController.Redeliver)
+	}
+
+	healthChecker.Register(router)
+}
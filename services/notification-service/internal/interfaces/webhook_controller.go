@@ -0,0 +1,323 @@
+package interfaces
+
+import (
+	"net/http"
+
+	"recruitment-system/services/notification-service/internal/application"
+	"recruitment-system/services/notification-service/internal/domain"
+	"recruitment-system/shared/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var deliverySortFields = utils.SortableFields{
+	"created_at": "created_at",
+}
+
+// WebhookController manages the integration webhooks. Every endpoint is
+// restricted to admins.
+type WebhookController struct {
+	webhookService *application.WebhookService
+	cursors        *utils.CursorCodec
+}
+
+func NewWebhookController(webhookService *application.WebhookService, cursors *utils.CursorCodec) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+		cursors:        cursors,
+	}
+}
+
+func (c *WebhookController) CreateSubscription(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	userInfo, err := c.webhookService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	var req domain.CreateWebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	subscription, err := c.webhookService.CreateSubscription(ctx.Request.Context(), req, userInfo.ID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to create webhook", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Webhook created successfully", subscription)
+}
+
+func (c *WebhookController) ListSubscriptions(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	_, err := c.webhookService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	subscriptions, err := c.webhookService.ListSubscriptions(ctx.Request.Context())
+	if err != nil {
+		utils.InternalServerErrorResponse(ctx, err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Webhooks retrieved successfully", subscriptions)
+}
+
+func (c *WebhookController) GetSubscription(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	_, err := c.webhookService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid webhook ID", err)
+		return
+	}
+
+	subscription, err := c.webhookService.GetSubscription(ctx.Request.Context(), id)
+	if err != nil {
+		utils.NotFoundResponse(ctx, "Webhook")
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Webhook retrieved successfully", subscription)
+}
+
+func (c *WebhookController) UpdateSubscription(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	_, err := c.webhookService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid webhook ID", err)
+		return
+	}
+
+	var req domain.UpdateWebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	subscription, err := c.webhookService.UpdateSubscription(ctx.Request.Context(), id, req)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to update webhook", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Webhook updated successfully", subscription)
+}
+
+func (c *WebhookController) RotateSecret(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	_, err := c.webhookService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid webhook ID", err)
+		return
+	}
+
+	subscription, err := c.webhookService.RotateSecret(ctx.Request.Context(), id)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to rotate webhook secret", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Webhook secret rotated successfully", subscription)
+}
+
+func (c *WebhookController) DeleteSubscription(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	_, err := c.webhookService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid webhook ID", err)
+		return
+	}
+
+	if err := c.webhookService.DeleteSubscription(ctx.Request.Context(), id); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to delete webhook", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Webhook deleted successfully", nil)
+}
+
+func (c *WebhookController) ListDeliveries(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	_, err := c.webhookService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid webhook ID", err)
+		return
+	}
+
+	pagination := utils.GetPaginationParams(ctx)
+	page, err := c.cursors.PageRequest(pagination, deliverySortFields)
+	if err != nil {
+		utils.ValidationErrorResponse(ctx, err)
+		return
+	}
+
+	deliveries, total, err := c.webhookService.ListDeliveries(ctx.Request.Context(), id, ctx.Query("status"), page)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to list deliveries", err)
+		return
+	}
+
+	if page.Keyset {
+		var cursorInfo utils.CursorPagination
+		deliveries, cursorInfo = utils.KeysetPage(c.cursors, deliveries, page, func(delivery domain.WebhookDelivery) utils.Cursor {
+			return utils.Cursor{CreatedAt: delivery.CreatedAt, ID: delivery.ID}
+		})
+		utils.CursorPaginatedSuccessResponse(ctx, http.StatusOK, "Deliveries retrieved successfully", deliveries, cursorInfo)
+		return
+	}
+
+	paginationInfo := utils.CreatePagination(pagination.Page, pagination.Limit, total)
+	utils.PaginatedSuccessResponse(ctx, http.StatusOK, "Deliveries retrieved successfully", deliveries, paginationInfo)
+}
+
+func (c *WebhookController) GetDelivery(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	_, err := c.webhookService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid webhook ID", err)
+		return
+	}
+
+	deliveryID, err := uuid.Parse(ctx.Param("deliveryId"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid delivery ID", err)
+		return
+	}
+
+	delivery, err := c.webhookService.GetDelivery(ctx.Request.Context(), id, deliveryID)
+	if err != nil {
+		utils.NotFoundResponse(ctx, "Delivery")
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Delivery retrieved successfully", delivery)
+}
+
+func (c *WebhookController) Redeliver(ctx *gin.Context) {
+	token := c.extractToken(ctx)
+	if token == "" {
+		utils.UnauthorizedResponse(ctx)
+		return
+	}
+
+	_, err := c.webhookService.ValidateUserPermissions(ctx.Request.Context(), token, "admin")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "Authentication failed", err)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid webhook ID", err)
+		return
+	}
+
+	deliveryID, err := uuid.Parse(ctx.Param("deliveryId"))
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid delivery ID", err)
+		return
+	}
+
+	delivery, err := c.webhookService.Redeliver(ctx.Request.Context(), id, deliveryID)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to redeliver webhook", err)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Webhook redelivered", delivery)
+}
+
+func (c *WebhookController) extractToken(ctx *gin.Context) string {
+	authHeader := ctx.GetHeader("Authorization")
+	if authHeader == "" {
+		return ""
+	}
+
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		return authHeader[7:]
+	}
+
+	return ""
+}
@@ -1,14 +1,14 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type Config struct {
@@ -18,14 +18,32 @@ type Config struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// LogLevel and SlowQueryThreshold configure SQL logging (DB_LOG_LEVEL,
+	// DB_SLOW_QUERY_THRESHOLD); see NewSQLLogger.
+	LogLevel           string
+	SlowQueryThreshold string
+}
+
+func (c Config) DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
+		c.Host, c.User, c.Password, c.DBName, c.Port, c.SSLMode)
 }
 
 func NewConnection(config Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
-		config.Host, config.User, config.Password, config.DBName, config.Port, config.SSLMode)
+	logLevel, err := ParseSQLLogLevel(config.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	var slowThreshold time.Duration
+	if config.SlowQueryThreshold != "" {
+		slowThreshold, err = time.ParseDuration(config.SlowQueryThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slow query threshold %q: %w", config.SlowQueryThreshold, err)
+		}
+	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	db, err := gorm.Open(postgres.Open(config.DSN()), &gorm.Config{
+		Logger: NewSQLLogger(slog.Default(), logLevel, slowThreshold),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -51,6 +69,9 @@ func GetConfigFromEnv() Config {
 		Password: getEnv("DB_PASSWORD", "postgres"),
 		DBName:   getEnv("DB_NAME", "recruitment_db"),
 		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+		LogLevel:           getEnv("DB_LOG_LEVEL", "warn"),
+		SlowQueryThreshold: getEnv("DB_SLOW_QUERY_THRESHOLD", "200ms"),
 	}
 }
 
@@ -61,16 +82,14 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-func TestConnection(db *gorm.DB) error {
+func TestConnection(ctx context.Context, db *gorm.DB) error {
 	sqlDB, err := db.DB()
 	if err != nil {
 		return err
 	}
 
-	if err := sqlDB.Ping(); err != nil {
+	if err := sqlDB.PingContext(ctx); err != nil {
 		return fmt.Errorf("failed to ping database: %w", err)
 	}
-
-	log.Println("Database connection established successfully")
 	return nil
 }
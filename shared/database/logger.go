@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SQLLogger writes GORM logs through slog, so SQL lines carry the request
+// ID of the context the query ran with. Statements are logged with their
+// placeholders, never their bind values, which hold passwords, tokens and
+// personal data.
+type SQLLogger struct {
+	logger        *slog.Logger
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewSQLLogger logs failed queries at "error", adds queries slower than
+// slowThreshold at "warn" and every statement at "info". A zero threshold
+// disables slow query logging.
+func NewSQLLogger(l *slog.Logger, level logger.LogLevel, slowThreshold time.Duration) *SQLLogger {
+	return &SQLLogger{logger: l, level: level, slowThreshold: slowThreshold}
+}
+
+// ParseSQLLogLevel reads DB_LOG_LEVEL: "silent", "error", "warn" or "info".
+func ParseSQLLogLevel(level string) (logger.LogLevel, error) {
+	switch strings.ToLower(level) {
+	case "silent":
+		return logger.Silent, nil
+	case "error":
+		return logger.Error, nil
+	case "", "warn":
+		return logger.Warn, nil
+	case "info":
+		return logger.Info, nil
+	default:
+		return 0, fmt.Errorf("unknown SQL log level %q", level)
+	}
+}
+
+func (l *SQLLogger) LogMode(level logger.LogLevel) logger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *SQLLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Info {
+		l.logger.InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *SQLLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Warn {
+		l.logger.WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *SQLLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Error {
+		l.logger.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// ParamsFilter drops the bind values GORM would otherwise inline into the
+// SQL handed to Trace.
+func (l *SQLLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// unboundPlaceholder matches the "$1$" the postgres dialector leaves for
+// placeholders it has no value for.
+var unboundPlaceholder = regexp.MustCompile(`\$(\d+)\$`)
+
+func statement(fc func() (string, int64)) (string, int64) {
+	sql, rows := fc()
+	return unboundPlaceholder.ReplaceAllString(sql, "$$$1"), rows
+}
+
+func (l *SQLLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		sql, rows := statement(fc)
+		l.logger.ErrorContext(ctx, "SQL query failed", "sql", sql, "rows", rows, "duration_ms", elapsed.Milliseconds(), "error", err)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
+		sql, rows := statement(fc)
+		l.logger.WarnContext(ctx, "Slow SQL query", "sql", sql, "rows", rows, "duration_ms", elapsed.Milliseconds(), "threshold_ms", l.slowThreshold.Milliseconds())
+	case l.level >= logger.Info:
+		sql, rows := statement(fc)
+		l.logger.InfoContext(ctx, "SQL query", "sql", sql, "rows", rows, "duration_ms", elapsed.Milliseconds())
+	}
+}
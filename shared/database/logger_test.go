@@ -0,0 +1,70 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func traceQuery(l *SQLLogger, elapsed time.Duration, err error) {
+	l.Trace(context.Background(), time.Now().Add(-elapsed), func() (string, int64) {
+		return "SELECT * FROM jobs", 1
+	}, err)
+}
+
+func TestSQLLogger_LogsOnlySlowAndFailedQueriesAtWarn(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSQLLogger(slog.New(slog.NewJSONHandler(&buf, nil)), logger.Warn, 100*time.Millisecond)
+
+	traceQuery(l, 10*time.Millisecond, nil)
+	traceQuery(l, time.Millisecond, gorm.ErrRecordNotFound)
+	assert.Zero(t, buf.Len())
+
+	traceQuery(l, 150*time.Millisecond, nil)
+	assert.Contains(t, buf.String(), `"msg":"Slow SQL query"`)
+	assert.Contains(t, buf.String(), `"threshold_ms":100`)
+
+	buf.Reset()
+	traceQuery(l, time.Millisecond, errors.New("syntax error"))
+	assert.Contains(t, buf.String(), `"level":"ERROR"`)
+	assert.Contains(t, buf.String(), `"sql":"SELECT * FROM jobs"`)
+}
+
+func TestSQLLogger_InfoLogsEveryStatement(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSQLLogger(slog.New(slog.NewJSONHandler(&buf, nil)), logger.Warn, 0).LogMode(logger.Info).(*SQLLogger)
+
+	traceQuery(l, 500*time.Millisecond, nil)
+	assert.Contains(t, buf.String(), `"msg":"SQL query"`)
+}
+
+func TestSQLLogger_OmitsBindValues(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSQLLogger(slog.New(slog.NewJSONHandler(&buf, nil)), logger.Info, 0)
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{Logger: l, DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+
+	db.Exec("UPDATE users SET password_hash = ? WHERE email = ?", "s3cret-hash", "user@example.com")
+
+	assert.Contains(t, buf.String(), "password_hash = $1 WHERE email = $2")
+	assert.NotContains(t, buf.String(), "s3cret-hash")
+	assert.NotContains(t, buf.String(), "user@example.com")
+}
+
+func TestParseSQLLogLevel(t *testing.T) {
+	level, err := ParseSQLLogLevel("")
+	assert.NoError(t, err)
+	assert.Equal(t, logger.Warn, level)
+
+	_, err = ParseSQLLogLevel("verbose")
+	assert.Error(t, err)
+}
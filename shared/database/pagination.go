@@ -0,0 +1,27 @@
+package database
+
+import (
+	"recruitment-system/shared/utils"
+
+	"gorm.io/gorm"
+)
+
+// Paginate applies page to a query. Offset mode orders by page.Sort with
+// idColumn as a final tiebreaker so rows never shift between pages; keyset
+// mode orders newest first by (timeColumn, idColumn) and fetches one extra
+// row so utils.KeysetPage can tell whether more exist.
+func Paginate(page utils.PageRequest, timeColumn, idColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !page.Keyset {
+			for _, field := range page.Sort {
+				db = db.Order(field.Clause())
+			}
+			return db.Order(idColumn + " ASC").Offset(page.Offset).Limit(page.Limit)
+		}
+
+		if page.After != nil {
+			db = db.Where("("+timeColumn+", "+idColumn+") < (?, ?)", page.After.CreatedAt, page.After.ID)
+		}
+		return db.Order(timeColumn + " DESC").Order(idColumn + " DESC").Limit(page.Limit + 1)
+	}
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// SchemaVersion is the last migration the code depends on. Each migration
+// inserts its number into schema_migrations; bump this together with a new
+// migration the services need.
+const SchemaVersion = 19
+
+// CheckSchemaVersion fails when the database is behind SchemaVersion.
+func CheckSchemaVersion(ctx context.Context, db *gorm.DB) error {
+	var version *int
+	err := db.WithContext(ctx).Raw("SELECT MAX(version) FROM schema_migrations").Scan(&version).Error
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version == nil || *version < SchemaVersion {
+		current := 0
+		if version != nil {
+			current = *version
+		}
+		return fmt.Errorf("schema version %d is behind required version %d", current, SchemaVersion)
+	}
+	return nil
+}
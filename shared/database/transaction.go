@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// UnitOfWork runs a function inside a database transaction. Repositories
+// pick the transaction up from the context through DB, so every write made
+// with the context passed to fn commits or rolls back together.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// Do joins the transaction already bound to ctx, if any, so nested calls
+// share a single commit.
+func (u *GormUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok && tx != nil {
+		return fn(ctx)
+	}
+
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Savepoint runs fn in a savepoint of the transaction bound to ctx, or in
+// a transaction of its own when there is none. When fn fails only its
+// writes are rolled back, and the surrounding transaction stays usable even
+// if the failure was a SQL error.
+func Savepoint(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	return DB(ctx, db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// DB returns the transaction bound to ctx, falling back to db when the
+// call is not part of a unit of work.
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok && tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// Detach returns ctx without its transaction, for work that must commit or
+// fail on its own even though it is started from inside a unit of work.
+func Detach(ctx context.Context) context.Context {
+	return context.WithValue(ctx, txKey{}, (*gorm.DB)(nil))
+}
@@ -0,0 +1,110 @@
+// Package egress guards requests to URLs supplied by users, such as
+// notification webhooks, so they can't be pointed at the services, the
+// database or cloud metadata endpoints from inside the network.
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrNotPublic is returned for destinations that resolve to an address not
+// routable on the public internet.
+var ErrNotPublic = errors.New("destination is not a public address")
+
+// nonPublicRanges are the special-purpose ranges not covered by the net.IP
+// predicates used in IsPublic.
+var nonPublicRanges = mustParseCIDRs(
+	"0.0.0.0/8",       // "this" network
+	"100.64.0.0/10",   // carrier-grade NAT, common for cluster networks
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // documentation
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // documentation
+	"203.0.113.0/24",  // documentation
+	"240.0.0.0/4",     // reserved, includes broadcast
+	"64:ff9b::/96",    // NAT64, can reach any IPv4 address
+	"2001:db8::/32",   // documentation
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = ipNet
+	}
+	return nets
+}
+
+// IsPublic reports whether ip is a public unicast address: not loopback,
+// private (RFC 1918 and IPv6 ULA), link-local, multicast, unspecified or
+// another special-purpose range.
+func IsPublic(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, ipNet := range nonPublicRanges {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL fails unless raw is an http(s) URL whose host resolves only to
+// public addresses. It gives a clear error when the URL is saved; Transport
+// enforces the same rule when the request is made, since DNS answers can
+// change in between.
+func CheckURL(ctx context.Context, raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return errors.New("must be an http or https URL")
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s", parsed.Hostname())
+	}
+	for _, addr := range addrs {
+		if !IsPublic(addr.IP) {
+			return fmt.Errorf("%s: %w", parsed.Hostname(), ErrNotPublic)
+		}
+	}
+	return nil
+}
+
+// Transport returns an HTTP transport that refuses to connect to addresses
+// that are not public. The check runs on the address actually dialed, after
+// DNS resolution, and proxies from the environment are ignored so requests
+// can't be relayed through one.
+func Transport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !IsPublic(ip) {
+				return fmt.Errorf("%s: %w", host, ErrNotPublic)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
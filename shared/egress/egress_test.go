@@ -0,0 +1,59 @@
+package egress
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublic(t *testing.T) {
+	for ip, public := range map[string]bool{
+		"8.8.8.8":            true,
+		"2606:4700::1111":    true,
+		"127.0.0.1":          false,
+		"10.96.0.1":          false,
+		"172.18.0.5":         false,
+		"192.168.1.10":       false,
+		"169.254.169.254":    false,
+		"100.64.0.1":         false,
+		"0.0.0.0":            false,
+		"::1":                false,
+		"fd00::1":            false,
+		"fe80::1":            false,
+		"::ffff:127.0.0.1":   false,
+		"64:ff9b::a9fe:a9fe": false,
+		"255.255.255.255":    false,
+	} {
+		assert.Equal(t, public, IsPublic(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, CheckURL(ctx, "https://8.8.8.8/hooks"))
+	assert.ErrorIs(t, CheckURL(ctx, "http://169.254.169.254/latest/meta-data"), ErrNotPublic)
+	assert.ErrorIs(t, CheckURL(ctx, "http://[::1]:8080/"), ErrNotPublic)
+	assert.ErrorIs(t, CheckURL(ctx, "http://localhost:8084/api/v1/notifications"), ErrNotPublic)
+	assert.Error(t, CheckURL(ctx, "ftp://8.8.8.8/"))
+}
+
+func TestTransportRefusesNonPublicAddresses(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport()}
+	_, err := client.Get(server.URL)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotPublic)
+	assert.False(t, called)
+}
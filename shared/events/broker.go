@@ -0,0 +1,84 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"recruitment-system/shared/database"
+
+	"gorm.io/gorm"
+)
+
+type Handler func(ctx context.Context, msg Message) error
+
+// Broker carries published messages to subscribed consumers. Subscribe
+// returns once the subscription is active and delivers in the background
+// until ctx is done. Delivery is at least once: consumers must tolerate
+// seeing a message again, see Idempotent.
+type Broker interface {
+	Publish(ctx context.Context, msg Message) error
+	Subscribe(ctx context.Context, consumer string, handler Handler) error
+}
+
+// InProcessBroker delivers synchronously to handlers in the same process.
+// It suits tests and single-binary setups; messages are not persisted, so
+// Publish fails when a handler does and the relay retries the message.
+// Handlers run detached from the publisher's transaction so a failing
+// handler cannot leave partial writes behind in it.
+type InProcessBroker struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+func NewInProcessBroker() *InProcessBroker {
+	return &InProcessBroker{handlers: make(map[string]Handler)}
+}
+
+func (b *InProcessBroker) Publish(ctx context.Context, msg Message) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ctx = database.Detach(ctx)
+
+	var errs []error
+	for consumer, handler := range b.handlers {
+		if err := handler(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", consumer, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *InProcessBroker) Subscribe(ctx context.Context, consumer string, handler Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.handlers[consumer]; exists {
+		return fmt.Errorf("consumer %q is already subscribed", consumer)
+	}
+	b.handlers[consumer] = handler
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.handlers, consumer)
+		b.mu.Unlock()
+	}()
+	return nil
+}
+
+// NewBroker builds the broker named by kind: "postgres" (the default) or
+// "inprocess".
+func NewBroker(kind string, db *gorm.DB, dsn string, pollInterval time.Duration) (Broker, error) {
+	switch kind {
+	case "", "postgres":
+		return NewPostgresBroker(db, dsn, pollInterval), nil
+	case "inprocess":
+		return NewInProcessBroker(), nil
+	default:
+		return nil, fmt.Errorf("unknown event broker %q", kind)
+	}
+}
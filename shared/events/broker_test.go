@@ -0,0 +1,48 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageDecodesPayload(t *testing.T) {
+	payload := JobPayload{JobID: uuid.New(), Title: "Go Developer", Status: "open", CreatedBy: uuid.New()}
+
+	msg, err := NewMessage("job-service", JobCreated, payload.JobID, payload)
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, msg.ID)
+	assert.Equal(t, payload.JobID, msg.AggregateID)
+
+	var decoded JobPayload
+	require.NoError(t, msg.Decode(&decoded))
+	assert.Equal(t, payload, decoded)
+}
+
+func TestInProcessBrokerDeliversToEveryConsumer(t *testing.T) {
+	broker := NewInProcessBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received []string
+	require.NoError(t, broker.Subscribe(ctx, "a", func(ctx context.Context, msg Message) error {
+		received = append(received, "a:"+msg.Type)
+		return nil
+	}))
+	require.NoError(t, broker.Subscribe(ctx, "b", func(ctx context.Context, msg Message) error {
+		received = append(received, "b:"+msg.Type)
+		return errors.New("boom")
+	}))
+	assert.Error(t, broker.Subscribe(ctx, "a", func(context.Context, Message) error { return nil }))
+
+	msg, err := NewMessage("job-service", JobClosed, uuid.New(), JobPayload{})
+	require.NoError(t, err)
+
+	err = broker.Publish(context.Background(), msg)
+	assert.ErrorContains(t, err, "b: boom")
+	assert.ElementsMatch(t, []string{"a:" + JobClosed, "b:" + JobClosed}, received)
+}
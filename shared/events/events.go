@@ -0,0 +1,132 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain events published between services. Each type lists the payload
+// struct it carries.
+const (
+	UserRegistered           = "user.registered"            // UserRegisteredPayload
+	JobCreated               = "job.created"                // JobPayload
+	JobStatusChanged         = "job.status_changed"         // JobStatusChangedPayload
+	JobClosed                = "job.closed"                 // JobClosedPayload
+	ApplicationSubmitted     = "application.submitted"      // ApplicationSubmittedPayload
+	ApplicationStatusChanged = "application.status_changed" // ApplicationStatusChangedPayload
+	ApplicationJobClosed     = "application.job_closed"     // ApplicationJobClosedPayload
+	ResumeProcessed          = "resume.processed"           // ResumePayload
+	ResumeFailed             = "resume.failed"              // ResumePayload
+)
+
+// Message is a domain event as it travels through the outbox and broker.
+// ID is stable across redeliveries so consumers can deduplicate on it.
+type Message struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        string          `json:"type"`
+	Source      string          `json:"source"`
+	AggregateID uuid.UUID       `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+func NewMessage(source, eventType string, aggregateID uuid.UUID, payload interface{}) (Message, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		ID:          uuid.New(),
+		Type:        eventType,
+		Source:      source,
+		AggregateID: aggregateID,
+		Payload:     data,
+		OccurredAt:  time.Now(),
+	}, nil
+}
+
+func (m Message) Decode(payload interface{}) error {
+	return json.Unmarshal(m.Payload, payload)
+}
+
+type UserRegisteredPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	Name   string    `json:"name"`
+	Role   string    `json:"role"`
+}
+
+type JobPayload struct {
+	JobID     uuid.UUID `json:"job_id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	CreatedBy uuid.UUID `json:"created_by"`
+}
+
+// JobStatusChangedPayload marks transitions made by the job scheduler as
+// Scheduled, as opposed to a recruiter changing the status by hand.
+type JobStatusChangedPayload struct {
+	JobID     uuid.UUID `json:"job_id"`
+	Title     string    `json:"title"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Scheduled bool      `json:"scheduled"`
+}
+
+// What happens to the open applications of a job when it closes.
+const (
+	ClosePolicyNotify = "notify"
+	ClosePolicyReject = "reject"
+)
+
+// JobClosedPayload carries the job's close policy. RejectionMessage is a
+// text/template rendered per application under the reject policy.
+type JobClosedPayload struct {
+	JobID            uuid.UUID `json:"job_id"`
+	Title            string    `json:"title"`
+	CreatedBy        uuid.UUID `json:"created_by"`
+	ClosePolicy      string    `json:"close_policy"`
+	RejectionMessage string    `json:"rejection_message,omitempty"`
+}
+
+type ApplicationSubmittedPayload struct {
+	ApplicationID   uuid.UUID `json:"application_id"`
+	JobID           uuid.UUID `json:"job_id"`
+	JobTitle        string    `json:"job_title"`
+	RecruiterID     uuid.UUID `json:"recruiter_id"`
+	CandidateID     uuid.UUID `json:"candidate_id"`
+	CandidateUserID uuid.UUID `json:"candidate_user_id"`
+	CandidateName   string    `json:"candidate_name"`
+	Status          string    `json:"status"`
+}
+
+type ApplicationStatusChangedPayload struct {
+	ApplicationID   uuid.UUID `json:"application_id"`
+	JobID           uuid.UUID `json:"job_id"`
+	JobTitle        string    `json:"job_title"`
+	CandidateUserID uuid.UUID `json:"candidate_user_id"`
+	From            string    `json:"from"`
+	To              string    `json:"to"`
+	Message         string    `json:"message,omitempty"`
+}
+
+// ApplicationJobClosedPayload tells a candidate the job closed while their
+// application was still open and was left as it is.
+type ApplicationJobClosedPayload struct {
+	ApplicationID   uuid.UUID `json:"application_id"`
+	JobID           uuid.UUID `json:"job_id"`
+	JobTitle        string    `json:"job_title"`
+	CandidateUserID uuid.UUID `json:"candidate_user_id"`
+	Status          string    `json:"status"`
+}
+
+type ResumePayload struct {
+	ResumeID        uuid.UUID `json:"resume_id"`
+	CandidateID     uuid.UUID `json:"candidate_id"`
+	CandidateUserID uuid.UUID `json:"candidate_user_id"`
+	Filename        string    `json:"filename"`
+}
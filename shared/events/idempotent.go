@@ -0,0 +1,45 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"recruitment-system/shared/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProcessedEvent struct {
+	Consumer    string    `gorm:"primaryKey"`
+	EventID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProcessedAt time.Time `gorm:"not null"`
+}
+
+func (p *ProcessedEvent) TableName() string {
+	return "processed_events"
+}
+
+// Idempotent wraps handler so each message ID is handled at most once per
+// consumer. The processed marker and the handler's writes share one
+// transaction: a failed handler leaves no marker and the message is retried.
+func Idempotent(db *gorm.DB, consumer string, handler Handler) Handler {
+	uow := database.NewUnitOfWork(db)
+
+	return func(ctx context.Context, msg Message) error {
+		return uow.Do(ctx, func(ctx context.Context) error {
+			result := database.DB(ctx, db).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&ProcessedEvent{Consumer: consumer, EventID: msg.ID, ProcessedAt: time.Now()})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return nil
+			}
+
+			return handler(ctx, msg)
+		})
+	}
+}
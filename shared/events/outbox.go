@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"recruitment-system/shared/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxMessage is a row of the transactional outbox. Services insert it in
+// the same transaction as the state change it describes; the Relay later
+// publishes it and sets PublishedAt.
+type OutboxMessage struct {
+	Seq         int64     `gorm:"primaryKey;autoIncrement"`
+	ID          uuid.UUID `gorm:"type:uuid;uniqueIndex;not null"`
+	Source      string    `gorm:"not null"`
+	Type        string    `gorm:"not null"`
+	AggregateID uuid.UUID `gorm:"type:uuid;not null"`
+	Payload     string    `gorm:"type:jsonb;not null"`
+	OccurredAt  time.Time `gorm:"not null"`
+	PublishedAt *time.Time
+}
+
+func (m *OutboxMessage) TableName() string {
+	return "outbox_events"
+}
+
+func (m *OutboxMessage) Message() Message {
+	return Message{
+		ID:          m.ID,
+		Type:        m.Type,
+		Source:      m.Source,
+		AggregateID: m.AggregateID,
+		Payload:     []byte(m.Payload),
+		OccurredAt:  m.OccurredAt,
+	}
+}
+
+// Outbox records the events of one service. Add joins the unit of work
+// bound to ctx, so the event commits or rolls back with the caller's writes.
+type Outbox struct {
+	db     *gorm.DB
+	source string
+}
+
+func NewOutbox(db *gorm.DB, source string) *Outbox {
+	return &Outbox{db: db, source: source}
+}
+
+func (o *Outbox) Add(ctx context.Context, eventType string, aggregateID uuid.UUID, payload interface{}) error {
+	msg, err := NewMessage(o.source, eventType, aggregateID, payload)
+	if err != nil {
+		return err
+	}
+
+	return database.DB(ctx, o.db).Create(&OutboxMessage{
+		ID:          msg.ID,
+		Source:      msg.Source,
+		Type:        msg.Type,
+		AggregateID: msg.AggregateID,
+		Payload:     string(msg.Payload),
+		OccurredAt:  msg.OccurredAt,
+	}).Error
+}
@@ -0,0 +1,319 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"recruitment-system/shared/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	notifyChannel       = "domain_events"
+	publishLock         = "event_log"
+	maxDeliveryAttempts = 5
+)
+
+// LoggedEvent is a message in the broker's durable log.
+type LoggedEvent struct {
+	Seq         int64     `gorm:"primaryKey;autoIncrement"`
+	ID          uuid.UUID `gorm:"type:uuid;uniqueIndex;not null"`
+	Source      string    `gorm:"not null"`
+	Type        string    `gorm:"not null"`
+	AggregateID uuid.UUID `gorm:"type:uuid;not null"`
+	Payload     string    `gorm:"type:jsonb;not null"`
+	OccurredAt  time.Time `gorm:"not null"`
+	PublishedAt time.Time `gorm:"not null"`
+}
+
+func (e *LoggedEvent) TableName() string {
+	return "event_log"
+}
+
+func (e *LoggedEvent) Message() Message {
+	return Message{
+		ID:          e.ID,
+		Type:        e.Type,
+		Source:      e.Source,
+		AggregateID: e.AggregateID,
+		Payload:     []byte(e.Payload),
+		OccurredAt:  e.OccurredAt,
+	}
+}
+
+// ConsumerOffset is how far a consumer has read the log. Attempts counts
+// the failed deliveries of the event right after LastSeq.
+type ConsumerOffset struct {
+	Consumer  string    `gorm:"primaryKey"`
+	LastSeq   int64     `gorm:"not null"`
+	Attempts  int       `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (o *ConsumerOffset) TableName() string {
+	return "event_consumer_offsets"
+}
+
+// DeadLetter is an event a consumer gave up on after maxDeliveryAttempts.
+// It stays until a replay handles it or an operator deletes it.
+type DeadLetter struct {
+	Consumer  string    `gorm:"primaryKey"`
+	Seq       int64     `gorm:"primaryKey"`
+	EventID   uuid.UUID `gorm:"type:uuid;not null"`
+	EventType string    `gorm:"not null"`
+	Error     string    `gorm:"type:text;not null"`
+	Attempts  int       `gorm:"not null"`
+	FailedAt  time.Time `gorm:"not null"`
+}
+
+func (d *DeadLetter) TableName() string {
+	return "event_dead_letters"
+}
+
+// PostgresBroker keeps messages in an append-only event_log table and
+// tracks how far each consumer has read. LISTEN/NOTIFY only wakes
+// consumers up; they also poll, so a missed notification delays a message
+// by at most the poll interval instead of losing it. Each message is
+// handled in a transaction that also advances the consumer's offset, and
+// the offset row lock keeps replicas of one consumer from racing.
+//
+// Offsets rely on seqs becoming visible in order. A BIGSERIAL alone doesn't
+// guarantee that: two relays can insert seqs 1 and 2 and commit 2 first,
+// and a consumer reading 2 would never go back for 1. Publishers therefore
+// hold a transaction-scoped lock from their first insert until commit, so
+// one publishing transaction at a time allocates seqs.
+type PostgresBroker struct {
+	db           *gorm.DB
+	dsn          string
+	pollInterval time.Duration
+}
+
+func NewPostgresBroker(db *gorm.DB, dsn string, pollInterval time.Duration) *PostgresBroker {
+	return &PostgresBroker{db: db, dsn: dsn, pollInterval: pollInterval}
+}
+
+// Publish appends msg to the log, joining the caller's transaction if
+// there is one. Publishing the same message twice is a no-op, which covers
+// a relay that crashed before marking its outbox row.
+func (b *PostgresBroker) Publish(ctx context.Context, msg Message) error {
+	return database.NewUnitOfWork(b.db).Do(ctx, func(ctx context.Context) error {
+		db := database.DB(ctx, b.db)
+
+		if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", publishLock).Error; err != nil {
+			return err
+		}
+
+		err := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoNothing: true}).
+			Create(&LoggedEvent{
+				ID:          msg.ID,
+				Source:      msg.Source,
+				Type:        msg.Type,
+				AggregateID: msg.AggregateID,
+				Payload:     string(msg.Payload),
+				OccurredAt:  msg.OccurredAt,
+				PublishedAt: time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return db.Exec("SELECT pg_notify(?, '')", notifyChannel).Error
+	})
+}
+
+func (b *PostgresBroker) Subscribe(ctx context.Context, consumer string, handler Handler) error {
+	err := b.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&ConsumerOffset{Consumer: consumer, UpdatedAt: time.Now()}).Error
+	if err != nil {
+		return err
+	}
+
+	conn, err := b.listen(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := b.Replay(ctx, consumer, handler); err != nil {
+			slog.ErrorContext(ctx, "Event consumer failed to replay dead letters", "consumer", consumer, "error", err)
+		}
+		b.consume(ctx, conn, consumer, handler)
+	}()
+	return nil
+}
+
+func (b *PostgresBroker) listen(ctx context.Context) (*pgx.Conn, error) {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		conn.Close(ctx)
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (b *PostgresBroker) consume(ctx context.Context, conn *pgx.Conn, consumer string, handler Handler) {
+	for {
+		b.drain(ctx, consumer, handler)
+
+		waitCtx, cancel := context.WithTimeout(ctx, b.pollInterval)
+		_, err := conn.WaitForNotification(waitCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			conn.Close(context.Background())
+			return
+		}
+
+		if err != nil && conn.IsClosed() {
+			slog.Warn("Event consumer lost its listen connection", "consumer", consumer, "error", err)
+			for conn == nil || conn.IsClosed() {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(b.pollInterval):
+				}
+				if conn, err = b.listen(ctx); err != nil {
+					conn = nil
+				}
+			}
+		}
+	}
+}
+
+// drain hands every message after the consumer's offset to handler, one
+// transaction per message. The handler runs in a savepoint, so a failure,
+// even a SQL error that aborted the transaction, rolls back only its own
+// writes: the failed attempt is then counted on the offset row, where every
+// replica and restart sees it. After maxDeliveryAttempts the message is
+// recorded in event_dead_letters and the offset moves past it, so it
+// cannot block the consumer forever.
+func (b *PostgresBroker) drain(ctx context.Context, consumer string, handler Handler) {
+	uow := database.NewUnitOfWork(b.db)
+
+	for ctx.Err() == nil {
+		handled := false
+
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			db := database.DB(ctx, b.db)
+
+			var offset ConsumerOffset
+			err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("consumer = ?", consumer).
+				First(&offset).Error
+			if err != nil {
+				return err
+			}
+
+			var event LoggedEvent
+			err = db.Where("seq > ?", offset.LastSeq).Order("seq ASC").Take(&event).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			handlerErr := database.Savepoint(ctx, b.db, func(ctx context.Context) error {
+				return handler(ctx, event.Message())
+			})
+			if handlerErr != nil {
+				offset.Attempts++
+				if offset.Attempts < maxDeliveryAttempts {
+					slog.WarnContext(ctx, "Event handler failed", "consumer", consumer, "event_type", event.Type, "event_id", event.ID, "attempt", offset.Attempts, "error", handlerErr)
+					return db.Model(&ConsumerOffset{}).
+						Where("consumer = ?", consumer).
+						Updates(map[string]interface{}{"attempts": offset.Attempts, "updated_at": time.Now()}).Error
+				}
+
+				slog.ErrorContext(ctx, "Event handler gave up, message moved to dead letters", "consumer", consumer, "event_type", event.Type, "event_id", event.ID, "error", handlerErr)
+				err := db.Create(&DeadLetter{
+					Consumer:  consumer,
+					Seq:       event.Seq,
+					EventID:   event.ID,
+					EventType: event.Type,
+					Error:     handlerErr.Error(),
+					Attempts:  offset.Attempts,
+					FailedAt:  time.Now(),
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+			handled = true
+
+			return db.Model(&ConsumerOffset{}).
+				Where("consumer = ?", consumer).
+				Updates(map[string]interface{}{"last_seq": event.Seq, "attempts": 0, "updated_at": time.Now()}).Error
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Event consumer failed", "consumer", consumer, "error", err)
+			return
+		}
+		if !handled {
+			return
+		}
+	}
+}
+
+// Replay hands the consumer's dead letters to handler again, oldest first,
+// removing the ones it now handles and recording the new error on the
+// others. Subscribe replays before consuming, so deploying a fix for a
+// handler retries the events it gave up on.
+func (b *PostgresBroker) Replay(ctx context.Context, consumer string, handler Handler) error {
+	var letters []DeadLetter
+	err := b.db.WithContext(ctx).Where("consumer = ?", consumer).Order("seq ASC").Find(&letters).Error
+	if err != nil {
+		return err
+	}
+
+	uow := database.NewUnitOfWork(b.db)
+	for _, letter := range letters {
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			db := database.DB(ctx, b.db)
+
+			// Another replica may be replaying the same letter.
+			err := db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("consumer = ? AND seq = ?", consumer, letter.Seq).
+				Take(&letter).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			var event LoggedEvent
+			if err := db.Where("seq = ?", letter.Seq).Take(&event).Error; err != nil {
+				return err
+			}
+
+			handlerErr := database.Savepoint(ctx, b.db, func(ctx context.Context) error {
+				return handler(ctx, event.Message())
+			})
+			if handlerErr == nil {
+				slog.InfoContext(ctx, "Dead letter replayed", "consumer", consumer, "event_type", event.Type, "event_id", event.ID)
+				return db.Delete(&letter).Error
+			}
+
+			return db.Model(&letter).Updates(map[string]interface{}{
+				"error":     handlerErr.Error(),
+				"attempts":  letter.Attempts + 1,
+				"failed_at": time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"recruitment-system/shared/database"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testBroker returns a broker on a schema of its own in the database named
+// by TEST_DATABASE_DSN (key=value form), skipping the test when it is unset.
+func testBroker(t *testing.T) (*PostgresBroker, *gorm.DB) {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+
+	config := &gorm.Config{Logger: logger.Discard}
+	admin, err := gorm.Open(postgres.Open(dsn), config)
+	require.NoError(t, err)
+
+	schema := "events_test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+schema).Error)
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	dsn += " search_path=" + schema
+	db, err := gorm.Open(postgres.Open(dsn), config)
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&LoggedEvent{}, &ConsumerOffset{}, &ProcessedEvent{}, &DeadLetter{}))
+
+	return NewPostgresBroker(db, dsn, time.Second), db
+}
+
+func publish(ctx context.Context, broker *PostgresBroker, eventType string) error {
+	msg, err := NewMessage("test", eventType, uuid.New(), JobPayload{})
+	if err != nil {
+		return err
+	}
+	return broker.Publish(ctx, msg)
+}
+
+func TestPostgresBrokerDeliversInterleavedPublishers(t *testing.T) {
+	broker, db := testBroker(t)
+	ctx := context.Background()
+	require.NoError(t, db.Create(&ConsumerOffset{Consumer: "test", UpdatedAt: time.Now()}).Error)
+
+	var received []string
+	handler := func(ctx context.Context, msg Message) error {
+		received = append(received, msg.Type)
+		return nil
+	}
+
+	// The first publisher holds its transaction open after publishing
+	// while a second one publishes and tries to commit.
+	uow := database.NewUnitOfWork(db)
+	published, commit := make(chan struct{}), make(chan struct{})
+	first, second := make(chan error, 1), make(chan error, 1)
+	go func() {
+		first <- uow.Do(ctx, func(ctx context.Context) error {
+			err := publish(ctx, broker, "first")
+			close(published)
+			if err != nil {
+				return err
+			}
+			<-commit
+			return nil
+		})
+	}()
+	<-published
+	go func() {
+		second <- uow.Do(ctx, func(ctx context.Context) error {
+			return publish(ctx, broker, "second")
+		})
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	broker.drain(ctx, "test", handler)
+	assert.Empty(t, received)
+
+	close(commit)
+	require.NoError(t, <-first)
+	require.NoError(t, <-second)
+
+	broker.drain(ctx, "test", handler)
+	assert.Equal(t, []string{"first", "second"}, received)
+}
+
+func TestPostgresBrokerDeadLettersPoisonMessagesWithoutTheirWrites(t *testing.T) {
+	broker, db := testBroker(t)
+	ctx := context.Background()
+	require.NoError(t, db.Create(&ConsumerOffset{Consumer: "test", UpdatedAt: time.Now()}).Error)
+
+	for _, eventType := range []string{"sql_failure", "handler_failure", "ok"} {
+		require.NoError(t, publish(ctx, broker, eventType))
+	}
+
+	broken := true
+	var handled []string
+	handler := Idempotent(db, "test", func(ctx context.Context, msg Message) error {
+		if broken {
+			switch msg.Type {
+			case "sql_failure":
+				return database.DB(ctx, db).Exec("SELECT 1 / 0").Error
+			case "handler_failure":
+				return errors.New("boom")
+			}
+		}
+		handled = append(handled, msg.Type)
+		return nil
+	})
+
+	// Attempts are kept on the offset row, so they add up across drains
+	// the way they would across restarts and replicas.
+	for i := 0; i < 2*maxDeliveryAttempts; i++ {
+		broker.drain(ctx, "test", handler)
+	}
+	assert.Equal(t, []string{"ok"}, handled)
+
+	var offset ConsumerOffset
+	require.NoError(t, db.First(&offset, "consumer = ?", "test").Error)
+	var lastSeq int64
+	require.NoError(t, db.Model(&LoggedEvent{}).Select("MAX(seq)").Scan(&lastSeq).Error)
+	assert.Equal(t, lastSeq, offset.LastSeq)
+	assert.Zero(t, offset.Attempts)
+
+	var processed int64
+	require.NoError(t, db.Model(&ProcessedEvent{}).Count(&processed).Error)
+	assert.Equal(t, int64(1), processed, "only the handled message is marked as processed")
+
+	var letters []DeadLetter
+	require.NoError(t, db.Order("seq").Find(&letters).Error)
+	require.Len(t, letters, 2)
+	assert.Equal(t, "sql_failure", letters[0].EventType)
+	assert.Equal(t, "boom", letters[1].Error)
+	assert.Equal(t, maxDeliveryAttempts, letters[1].Attempts)
+
+	broken = false
+	require.NoError(t, broker.Replay(ctx, "test", handler))
+	assert.Equal(t, []string{"ok", "sql_failure", "handler_failure"}, handled)
+
+	var left int64
+	require.NoError(t, db.Model(&DeadLetter{}).Count(&left).Error)
+	assert.Zero(t, left)
+}
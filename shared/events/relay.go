@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"recruitment-system/shared/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const relayBatchSize = 100
+
+// Relay moves a service's unpublished outbox rows to the broker. Rows are
+// claimed with SKIP LOCKED so several replicas can run a relay safely.
+type Relay struct {
+	db       *gorm.DB
+	uow      database.UnitOfWork
+	broker   Broker
+	source   string
+	interval time.Duration
+}
+
+func NewRelay(db *gorm.DB, broker Broker, source string, interval time.Duration) *Relay {
+	return &Relay{
+		db:       db,
+		uow:      database.NewUnitOfWork(db),
+		broker:   broker,
+		source:   source,
+		interval: interval,
+	}
+}
+
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.run(ctx)
+		}
+	}
+}
+
+func (r *Relay) run(ctx context.Context) {
+	for {
+		published, err := r.RelayPending(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Outbox relay failed", "source", r.source, "error", err)
+			return
+		}
+		if published < relayBatchSize {
+			return
+		}
+	}
+}
+
+// RelayPending publishes one batch in outbox order and returns how many
+// messages went out. Publishing stops at the first failure; the messages
+// already published stay marked and the rest are retried on the next run.
+// The broker sees the relay's transaction in ctx, so a database-backed
+// broker stores the message atomically with marking it published.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	published := 0
+	var publishErr error
+
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		var pending []OutboxMessage
+		err := database.DB(ctx, r.db).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("source = ? AND published_at IS NULL", r.source).
+			Order("seq ASC").
+			Limit(relayBatchSize).
+			Find(&pending).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range pending {
+			if publishErr = r.broker.Publish(ctx, pending[i].Message()); publishErr != nil {
+				return nil
+			}
+
+			err := database.DB(ctx, r.db).Model(&OutboxMessage{}).
+				Where("seq = ?", pending[i].Seq).
+				Update("published_at", time.Now()).Error
+			if err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return published, publishErr
+}
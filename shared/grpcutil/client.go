@@ -0,0 +1,161 @@
+package grpcutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"recruitment-system/shared/httpclient"
+	"recruitment-system/shared/metrics"
+	"recruitment-system/shared/requestid"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenSource provides the service token sent with every call;
+// *serviceauth.TokenSource implements it.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// Dial connects to the internal gRPC API of the service called name. Calls
+// follow the httpclient conventions: config.Timeout bounds each call,
+// UNAVAILABLE responses are retried with jittered backoff (every internal
+// RPC is a read), a circuit breaker stops calls to a failing service and
+// errors wrap the httpclient error kinds, so errors.Is(err,
+// httpclient.ErrNotFound) works whichever transport is used.
+func Dial(name, target string, tokens TokenSource, config httpclient.Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	breaker := httpclient.NewCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown)
+
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(serviceCredentials{tokens: tokens}),
+		grpc.WithDefaultServiceConfig(retryServiceConfig(config)),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(
+			metricsInterceptor(name),
+			errorInterceptor(name),
+			breakerInterceptor(breaker),
+			timeoutInterceptor(config),
+			requestIDClientInterceptor,
+		),
+	}, opts...)
+
+	return grpc.Dial(target, opts...)
+}
+
+type serviceCredentials struct {
+	tokens TokenSource
+}
+
+func (c serviceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to obtain service token: %v", err)
+	}
+	return map[string]string{authorizationKey: "Bearer " + token}, nil
+}
+
+// RequireTransportSecurity is false because internal traffic stays on the
+// private service network.
+func (c serviceCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func retryServiceConfig(config httpclient.Config) string {
+	return fmt.Sprintf(`{"methodConfig": [{
+		"name": [{}],
+		"retryPolicy": {
+			"maxAttempts": %d,
+			"initialBackoff": "%.3fs",
+			"maxBackoff": "%.3fs",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]}`, config.MaxRetries+1, config.RetryBaseDelay.Seconds(), config.RetryMaxDelay.Seconds())
+}
+
+func requestIDClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestIDKey, id)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func timeoutInterceptor(config httpclient.Config) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func breakerInterceptor(breaker *httpclient.CircuitBreaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !breaker.Allow() {
+			return httpclient.ErrCircuitOpen
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		switch {
+		case ctx.Err() != nil:
+			breaker.Cancel()
+		case unavailable(status.Code(err)):
+			breaker.Failure()
+		default:
+			breaker.Success()
+		}
+		return err
+	}
+}
+
+func metricsInterceptor(name string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		metrics.ObserveClientCall(name, method, time.Since(start), httpclient.Outcome(err))
+		return err
+	}
+}
+
+func errorInterceptor(name string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s: %w", name, ctx.Err())
+		}
+
+		st, ok := status.FromError(err)
+		if !ok {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return fmt.Errorf("%s responded with %s: %w: %s", name, st.Code(), errorKind(st.Code()), st.Message())
+	}
+}
+
+func errorKind(code codes.Code) error {
+	switch code {
+	case codes.NotFound:
+		return httpclient.ErrNotFound
+	case codes.Unauthenticated:
+		return httpclient.ErrUnauthorized
+	case codes.PermissionDenied:
+		return httpclient.ErrForbidden
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.AlreadyExists:
+		return httpclient.ErrBadRequest
+	default:
+		return httpclient.ErrUnavailable
+	}
+}
+
+func unavailable(code codes.Code) bool {
+	return code == codes.Unavailable || code == codes.DeadlineExceeded || code == codes.ResourceExhausted
+}
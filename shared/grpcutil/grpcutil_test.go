@@ -0,0 +1,96 @@
+package grpcutil
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"recruitment-system/shared/httpclient"
+	"recruitment-system/shared/pb/authv1"
+	"recruitment-system/shared/requestid"
+	"recruitment-system/shared/serviceauth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testSecret = "test-secret"
+
+type fakeAuthServer struct {
+	authv1.UnimplementedAuthServiceServer
+	caller    string
+	requestID string
+}
+
+func (s *fakeAuthServer) GetUsers(ctx context.Context, req *authv1.GetUsersRequest) (*authv1.GetUsersResponse, error) {
+	s.caller = ServiceName(ctx)
+	s.requestID = requestid.FromContext(ctx)
+	if len(req.Ids) == 0 {
+		return nil, status.Error(codes.NotFound, "no users")
+	}
+	return &authv1.GetUsersResponse{Users: []*authv1.User{{Id: req.Ids[0], Name: "Ana"}}}, nil
+}
+
+type staticToken string
+
+func (t staticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+func signToken(t *testing.T, tokenType string) staticToken {
+	claims := jwt.MapClaims{"sub": "candidate-service", "token_type": tokenType, "exp": time.Now().Add(time.Minute).Unix()}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return staticToken(token)
+}
+
+func startServer(t *testing.T) (*fakeAuthServer, func(TokenSource) authv1.AuthServiceClient) {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(testSecret)
+	fake := &fakeAuthServer{}
+	authv1.RegisterAuthServiceServer(server, fake)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return fake, func(tokens TokenSource) authv1.AuthServiceClient {
+		conn, err := Dial("auth-service", "bufnet", tokens, httpclient.DefaultConfig(),
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}))
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return authv1.NewAuthServiceClient(conn)
+	}
+}
+
+func TestServiceTokenAndRequestIDReachTheServer(t *testing.T) {
+	fake, connect := startServer(t)
+	client := connect(signToken(t, serviceauth.TokenTypeService))
+
+	ctx := requestid.NewContext(context.Background(), "req-42")
+	response, err := client.GetUsers(ctx, &authv1.GetUsersRequest{Ids: []string{"u1"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Ana", response.Users[0].Name)
+	assert.Equal(t, "candidate-service", fake.caller)
+	assert.Equal(t, "req-42", fake.requestID)
+}
+
+func TestErrorsMapToHTTPClientKinds(t *testing.T) {
+	_, connect := startServer(t)
+
+	_, err := connect(signToken(t, serviceauth.TokenTypeService)).GetUsers(context.Background(), &authv1.GetUsersRequest{})
+	assert.ErrorIs(t, err, httpclient.ErrNotFound)
+
+	_, err = connect(signToken(t, serviceauth.TokenTypeUser)).GetUsers(context.Background(), &authv1.GetUsersRequest{Ids: []string{"u1"}})
+	assert.ErrorIs(t, err, httpclient.ErrForbidden)
+
+	_, err = connect(staticToken("garbage")).GetUsers(context.Background(), &authv1.GetUsersRequest{Ids: []string{"u1"}})
+	assert.ErrorIs(t, err, httpclient.ErrUnauthorized)
+}
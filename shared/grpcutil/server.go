@@ -0,0 +1,96 @@
+// Package grpcutil holds what the services' internal gRPC servers and
+// clients share: service-token authentication, request ID propagation and
+// the resilience and error semantics of shared/httpclient.
+package grpcutil
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"recruitment-system/shared/middleware"
+	"recruitment-system/shared/requestid"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	authorizationKey = "authorization"
+	requestIDKey     = "x-request-id"
+)
+
+type serviceNameKey struct{}
+
+// NewServer creates a gRPC server whose calls all require a service token
+// issued by auth-service.
+func NewServer(jwtSecret string) *grpc.Server {
+	return grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			requestIDServerInterceptor,
+			serviceAuthInterceptor(jwtSecret),
+		),
+	)
+}
+
+// ListenAndServe serves server on addr until it is stopped.
+func ListenAndServe(server *grpc.Server, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return server.Serve(listener)
+}
+
+// ServiceName returns the calling service of a gRPC request.
+func ServiceName(ctx context.Context) string {
+	name, _ := ctx.Value(serviceNameKey{}).(string)
+	return name
+}
+
+// Error converts an application error to a gRPC status: missing records
+// become NOT_FOUND, anything else INTERNAL.
+func Error(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, "not found")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func requestIDServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id := requestid.New()
+	if values := metadata.ValueFromIncomingContext(ctx, requestIDKey); len(values) > 0 && values[0] != "" {
+		id = values[0]
+	}
+	return handler(requestid.NewContext(ctx, id), req)
+}
+
+func serviceAuthInterceptor(jwtSecret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		values := metadata.ValueFromIncomingContext(ctx, authorizationKey)
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "service token required")
+		}
+
+		tokenString, ok := strings.CutPrefix(values[0], "Bearer ")
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+		}
+
+		claims, err := middleware.ParseToken(jwtSecret, tokenString)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		if !claims.IsService() {
+			return nil, status.Error(codes.PermissionDenied, "service token required")
+		}
+
+		return handler(context.WithValue(ctx, serviceNameKey{}, claims.Subject), req)
+	}
+}
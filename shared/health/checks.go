@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"recruitment-system/shared/database"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"gorm.io/gorm"
+)
+
+// Database pings the database.
+func Database(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		return database.TestConnection(ctx, db)
+	}
+}
+
+// SchemaVersion fails until the migrations the code expects are applied.
+func SchemaVersion(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		return database.CheckSchemaVersion(ctx, db)
+	}
+}
+
+// GRPC checks that a connection to another service can be established. It
+// does not call the service, so it passes even if that service is itself
+// not ready, which keeps one failing dependency from cascading.
+func GRPC(conn *grpc.ClientConn) Check {
+	return func(ctx context.Context) error {
+		conn.Connect()
+		for {
+			state := conn.GetState()
+			switch state {
+			case connectivity.Ready:
+				return nil
+			case connectivity.Shutdown:
+				return fmt.Errorf("connection to %s is closed", conn.Target())
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("%s not reachable (%s): %w", conn.Target(), state, ctx.Err())
+			}
+		}
+	}
+}
+
+// HTTP checks that url answers 200, typically another service's /livez.
+func HTTP(client *http.Client, url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// DiskSpace fails when the filesystem holding dir has less than minFree
+// bytes available. dir is created if missing, as file storage would.
+func DiskSpace(dir string, minFree uint64) Check {
+	return func(ctx context.Context) error {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		free, err := freeSpace(dir)
+		if err != nil {
+			return err
+		}
+		if free < minFree {
+			return fmt.Errorf("%d MB free in %s, need %d MB", free>>20, dir, minFree>>20)
+		}
+		return nil
+	}
+}
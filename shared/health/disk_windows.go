@@ -0,0 +1,7 @@
+package health
+
+import "errors"
+
+func freeSpace(dir string) (uint64, error) {
+	return 0, errors.New("disk space check is not supported on windows")
+}
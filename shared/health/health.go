@@ -0,0 +1,192 @@
+// Package health serves the liveness and readiness probes of the services.
+// /livez only tells the process is serving requests; /readyz runs the
+// registered checks (database, schema version, downstream services, disk)
+// and reports each one. Results are cached so frequent probes from several
+// sources don't pile up on the database or on other services.
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Check returns nil when the dependency is usable. It must give up when ctx
+// is done.
+type Check func(ctx context.Context) error
+
+type Config struct {
+	// Timeout bounds each check.
+	Timeout time.Duration
+	// CacheTTL is how long a readiness report is served before the checks
+	// run again.
+	CacheTTL time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{Timeout: 2 * time.Second, CacheTTL: 5 * time.Second}
+}
+
+// GetConfigFromEnv reads HEALTH_CHECK_TIMEOUT and HEALTH_CACHE_TTL, keeping
+// the defaults for unset variables.
+func GetConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+	if value := os.Getenv("HEALTH_CHECK_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return Config{}, fmt.Errorf("invalid HEALTH_CHECK_TIMEOUT %q", value)
+		}
+		config.Timeout = timeout
+	}
+	if value := os.Getenv("HEALTH_CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return Config{}, fmt.Errorf("invalid HEALTH_CACHE_TTL %q", value)
+		}
+		config.CacheTTL = ttl
+	}
+	return config, nil
+}
+
+type CheckResult struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status    string                 `json:"status"`
+	Service   string                 `json:"service"`
+	Checks    map[string]CheckResult `json:"checks"`
+	CheckedAt time.Time              `json:"checked_at"`
+}
+
+// Ready reports whether every check passed.
+func (r Report) Ready() bool {
+	return r.Status == "ok"
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+type Checker struct {
+	service string
+	config  Config
+	checks  []namedCheck
+	now     func() time.Time
+
+	mu      sync.Mutex
+	report  Report
+	expires time.Time
+}
+
+func NewChecker(service string, config Config) *Checker {
+	return &Checker{service: service, config: config, now: time.Now}
+}
+
+// Add registers a readiness check. Checks must be added before Register.
+func (c *Checker) Add(name string, check Check) *Checker {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+	return c
+}
+
+// Register mounts /livez, /readyz and /health, which serves the readiness
+// report so existing health checks see real failures.
+func (c *Checker) Register(router gin.IRoutes) {
+	router.GET("/livez", c.Live)
+	router.GET("/readyz", c.Ready)
+	router.GET("/health", c.Ready)
+}
+
+func (c *Checker) Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"service": c.service,
+	})
+}
+
+// Ready responds 200 with the readiness report when every check passes and
+// 503 otherwise.
+func (c *Checker) Ready(ctx *gin.Context) {
+	report := c.Report()
+	code := http.StatusOK
+	if !report.Ready() {
+		code = http.StatusServiceUnavailable
+	}
+	ctx.JSON(code, report)
+}
+
+// Report returns the cached report, running the checks when it has expired.
+// Concurrent callers wait for a single run instead of starting their own.
+func (c *Checker) Report() Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.now().Before(c.expires) {
+		return c.report
+	}
+
+	c.report = c.run()
+	c.expires = c.now().Add(c.config.CacheTTL)
+	return c.report
+}
+
+func (c *Checker) run() Report {
+	results := make([]CheckResult, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = c.runCheck(check)
+		}(i, check.check)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:    "ok",
+		Service:   c.service,
+		Checks:    make(map[string]CheckResult, len(c.checks)),
+		CheckedAt: c.now().UTC(),
+	}
+	for i, check := range c.checks {
+		report.Checks[check.name] = results[i]
+		if results[i].Status != "ok" {
+			report.Status = "degraded"
+		}
+	}
+	return report
+}
+
+// runCheck runs check with its own timeout, detached from the probe request
+// so a client hanging up doesn't cache a failure. A check that ignores its
+// context is abandoned when the timeout expires.
+func (c *Checker) runCheck(check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Status: "ok", LatencyMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = "down"
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(checker *Checker, path string) (int, Report) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	checker.Register(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var report Report
+	json.Unmarshal(w.Body.Bytes(), &report)
+	return w.Code, report
+}
+
+func TestReadyReportsEachCheck(t *testing.T) {
+	checker := NewChecker("job-service", DefaultConfig()).
+		Add("database", func(ctx context.Context) error { return nil }).
+		Add("auth-service", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	code, report := serve(checker, "/readyz")
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "degraded", report.Status)
+	assert.Equal(t, "job-service", report.Service)
+	assert.Equal(t, "ok", report.Checks["database"].Status)
+	assert.Equal(t, "down", report.Checks["auth-service"].Status)
+	assert.Equal(t, "connection refused", report.Checks["auth-service"].Error)
+
+	code, _ = serve(checker, "/livez")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestChecksTimeOut(t *testing.T) {
+	checker := NewChecker("job-service", Config{Timeout: 10 * time.Millisecond}).
+		Add("hung", func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+
+	report := checker.Report()
+
+	assert.False(t, report.Ready())
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["hung"].Error)
+}
+
+func TestReportIsCachedAndShared(t *testing.T) {
+	var runs int32
+	checker := NewChecker("job-service", Config{Timeout: time.Second, CacheTTL: time.Minute}).
+		Add("database", func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+	now := time.Now()
+	checker.now = func() time.Time { return now }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.True(t, checker.Report().Ready())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+
+	now = now.Add(time.Minute)
+	checker.Report()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&runs))
+}
+
+func TestDiskSpace(t *testing.T) {
+	dir := t.TempDir() + "/uploads"
+
+	require.NoError(t, DiskSpace(dir, 1)(context.Background()))
+	assert.DirExists(t, dir)
+	assert.Error(t, DiskSpace(dir, math.MaxUint64)(context.Background()))
+}
@@ -0,0 +1,231 @@
+// Package httpclient is the HTTP client services use to call each other. It
+// bounds every attempt with a timeout, retries idempotent calls with jittered
+// backoff, stops calling a failing service through a circuit breaker, maps
+// response statuses to typed errors and forwards the caller's request ID.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"recruitment-system/shared/metrics"
+	"recruitment-system/shared/requestid"
+	"recruitment-system/shared/tracing"
+)
+
+type Config struct {
+	// Timeout bounds each attempt; the caller's context bounds the whole call.
+	Timeout          time.Duration
+	MaxRetries       int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:          5 * time.Second,
+		MaxRetries:       2,
+		RetryBaseDelay:   100 * time.Millisecond,
+		RetryMaxDelay:    2 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+type Client struct {
+	name       string
+	baseURL    string
+	config     Config
+	httpClient *http.Client
+	breaker    *CircuitBreaker
+}
+
+// New creates a client for the service called name, which appears in errors.
+func New(name, baseURL string, config Config) *Client {
+	return &Client{
+		name:       name,
+		baseURL:    baseURL,
+		config:     config,
+		httpClient: &http.Client{Transport: tracing.Transport(name, http.DefaultTransport)},
+		breaker:    NewCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown),
+	}
+}
+
+type Request struct {
+	Method string
+	Path   string
+	Body   interface{}
+	Header http.Header
+	// Idempotent allows retrying a method that is not idempotent by
+	// definition, such as a POST that only reads.
+	Idempotent bool
+}
+
+func (r Request) retryable() bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return r.Idempotent
+}
+
+// envelope is the response format of shared/utils.
+type envelope struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Error   string          `json:"error"`
+}
+
+func (c *Client) Get(ctx context.Context, path string, header http.Header, out interface{}) error {
+	return c.Do(ctx, Request{Method: http.MethodGet, Path: path, Header: header}, out)
+}
+
+func (c *Client) Post(ctx context.Context, path string, body interface{}, header http.Header, out interface{}) error {
+	return c.Do(ctx, Request{Method: http.MethodPost, Path: path, Body: body, Header: header}, out)
+}
+
+// Do sends req and decodes the data field of the response envelope into
+// out, which may be nil.
+func (c *Client) Do(ctx context.Context, req Request, out interface{}) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ObserveClientCall(c.name, req.Method, time.Since(start), Outcome(err))
+	}()
+	return c.do(ctx, req, out)
+}
+
+func (c *Client) do(ctx context.Context, req Request, out interface{}) error {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = json.Marshal(req.Body); err != nil {
+			return fmt.Errorf("failed to encode %s request: %w", c.name, err)
+		}
+	}
+
+	attempts := 1
+	if req.retryable() {
+		attempts += c.config.MaxRetries
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if waitErr := c.wait(ctx, attempt); waitErr != nil {
+				return fmt.Errorf("%s: %w", c.name, waitErr)
+			}
+		}
+
+		if !c.breaker.Allow() {
+			return fmt.Errorf("%s: %w", c.name, ErrCircuitOpen)
+		}
+
+		err = c.send(ctx, req, body, out)
+		if ctx.Err() != nil {
+			// The caller gave up; that says nothing about the service.
+			c.breaker.Cancel()
+			return fmt.Errorf("%s: %w", c.name, ctx.Err())
+		}
+		if errors.Is(err, ErrUnavailable) {
+			c.breaker.Failure()
+		} else {
+			c.breaker.Success()
+		}
+
+		if err == nil || !retryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (c *Client) send(ctx context.Context, req Request, body []byte, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, c.baseURL+req.Path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", c.name, err)
+	}
+	for key, values := range req.Header {
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if id := requestid.FromContext(ctx); id != "" {
+		httpReq.Header.Set(requestid.Header, id)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w: %v", c.name, ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	var response envelope
+	decodeErr := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&response)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message := response.Error
+		if message == "" {
+			message = response.Message
+		}
+		return &StatusError{Service: c.name, StatusCode: resp.StatusCode, Message: message}
+	}
+	if decodeErr != nil {
+		if errors.Is(decodeErr, io.EOF) && out == nil {
+			return nil
+		}
+		return fmt.Errorf("failed to decode %s response: %w", c.name, decodeErr)
+	}
+	if !response.Success {
+		return &StatusError{Service: c.name, StatusCode: http.StatusBadRequest, Message: response.Error}
+	}
+
+	if out != nil && len(response.Data) > 0 {
+		if err := json.Unmarshal(response.Data, out); err != nil {
+			return fmt.Errorf("failed to decode %s response: %w", c.name, err)
+		}
+	}
+	return nil
+}
+
+// wait sleeps before a retry for a random time up to the exponential delay
+// of the attempt ("full jitter").
+func (c *Client) wait(ctx context.Context, attempt int) error {
+	delay := c.config.RetryBaseDelay << (attempt - 1)
+	if delay <= 0 || delay > c.config.RetryMaxDelay {
+		delay = c.config.RetryMaxDelay
+	}
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(delay)) + 1))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Bearer returns the Authorization header for token.
+func Bearer(token string) http.Header {
+	return http.Header{"Authorization": []string{"Bearer " + token}}
+}
@@ -0,0 +1,151 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"recruitment-system/shared/requestid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() Config {
+	return Config{
+		Timeout:          time.Second,
+		MaxRetries:       2,
+		RetryBaseDelay:   time.Millisecond,
+		RetryMaxDelay:    5 * time.Millisecond,
+		BreakerThreshold: 3,
+		BreakerCooldown:  time.Hour,
+	}
+}
+
+func TestGetRetriesUnavailableAndDecodesData(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"title":"Go Developer"}}`))
+	}))
+	defer server.Close()
+
+	var job struct {
+		Title string `json:"title"`
+	}
+	err := New("job-service", server.URL, testConfig()).Get(context.Background(), "/jobs/1", nil, &job)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Go Developer", job.Title)
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestPostIsNotRetriedUnlessIdempotent(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	config := testConfig()
+	config.BreakerThreshold = 10
+	client := New("auth-service", server.URL, config)
+
+	err := client.Post(context.Background(), "/users", map[string]string{"a": "b"}, nil, nil)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, int32(1), calls)
+
+	err = client.Do(context.Background(), Request{Method: http.MethodPost, Path: "/validate", Idempotent: true}, nil)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, int32(4), calls)
+}
+
+func TestStatusesMapToTypedErrors(t *testing.T) {
+	statuses := map[int]error{
+		http.StatusNotFound:     ErrNotFound,
+		http.StatusUnauthorized: ErrUnauthorized,
+		http.StatusForbidden:    ErrForbidden,
+		http.StatusBadRequest:   ErrBadRequest,
+	}
+	for status, expected := range statuses {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"success":false,"error":"nope"}`))
+		}))
+
+		err := New("job-service", server.URL, testConfig()).Get(context.Background(), "/", nil, nil)
+		server.Close()
+
+		assert.ErrorIs(t, err, expected)
+		var statusErr *StatusError
+		require.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, status, statusErr.StatusCode)
+		assert.Equal(t, "nope", statusErr.Message)
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	assert.Equal(t, "success", Outcome(nil))
+	assert.Equal(t, "circuit_open", Outcome(fmt.Errorf("job-service: %w", ErrCircuitOpen)))
+	assert.Equal(t, "timeout", Outcome(fmt.Errorf("job-service: %w", context.DeadlineExceeded)))
+	assert.Equal(t, "unavailable", Outcome(&StatusError{Service: "job-service", StatusCode: http.StatusBadGateway}))
+	assert.Equal(t, "not_found", Outcome(&StatusError{Service: "job-service", StatusCode: http.StatusNotFound}))
+	assert.Equal(t, "error", Outcome(errors.New("boom")))
+}
+
+func TestBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client := New("job-service", server.URL, testConfig())
+
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, client.Get(context.Background(), "/", nil, nil), ErrUnavailable)
+	}
+	err := client.Get(context.Background(), "/", nil, nil)
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestBreakerHalfOpenProbe(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.Failure()
+	assert.False(t, breaker.Allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow())
+	assert.False(t, breaker.Allow())
+
+	breaker.Success()
+	assert.True(t, breaker.Allow())
+}
+
+func TestRequestIDIsForwarded(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(requestid.Header)
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	ctx := requestid.NewContext(context.Background(), "req-123")
+	require.NoError(t, New("auth-service", server.URL, testConfig()).Get(ctx, "/", Bearer("t"), nil))
+
+	assert.Equal(t, "req-123", received)
+}
@@ -0,0 +1,92 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error kinds. Every error returned by Client wraps exactly one of them, so
+// callers can tell them apart with errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnavailable  = errors.New("service unavailable")
+)
+
+// ErrCircuitOpen is returned without calling the service while its circuit
+// breaker is open. It is also an ErrUnavailable.
+var ErrCircuitOpen = fmt.Errorf("circuit breaker open: %w", ErrUnavailable)
+
+// StatusError is a non-2xx response. Message is taken from the standard
+// response envelope when the body has one.
+type StatusError struct {
+	Service    string
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s responded with status %d: %s", e.Service, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%s responded with status %d", e.Service, e.StatusCode)
+}
+
+func (e *StatusError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500:
+		return ErrUnavailable
+	default:
+		return ErrBadRequest
+	}
+}
+
+// retryable reports whether a failed call may succeed if repeated.
+func retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	return errors.Is(err, ErrUnavailable) && !errors.Is(err, ErrCircuitOpen)
+}
+
+// Outcome classifies the result of a call for metrics: "success" or the kind
+// of error it wraps.
+func Outcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, ErrUnavailable):
+		return "unavailable"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, ErrForbidden):
+		return "forbidden"
+	case errors.Is(err, ErrBadRequest):
+		return "bad_request"
+	default:
+		return "error"
+	}
+}
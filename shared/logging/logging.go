@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"recruitment-system/shared/requestid"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config selects the log level ("debug", "info", "warn", "error") and the
+// output format ("json" or "text").
+type Config struct {
+	Level  string
+	Format string
+}
+
+func GetConfigFromEnv() Config {
+	return Config{
+		Level:  os.Getenv("LOG_LEVEL"),
+		Format: os.Getenv("LOG_FORMAT"),
+	}
+}
+
+// New builds a logger writing to w. Every line carries the service name and,
+// when logged with a request context, the request ID and trace ID.
+func New(w io.Writer, service string, config Config) (*slog.Logger, error) {
+	level, err := ParseLevel(config.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(config.Format) {
+	case "", "json":
+		handler = slog.NewJSONHandler(w, options)
+	case "text":
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", config.Format)
+	}
+
+	return slog.New(contextHandler{handler}).With("service", service), nil
+}
+
+// Setup configures the default logger from LOG_LEVEL and LOG_FORMAT. The
+// standard log package is redirected to it as well.
+func Setup(service string) *slog.Logger {
+	logger, err := New(os.Stdout, service, GetConfigFromEnv())
+	if err != nil {
+		logger, _ = New(os.Stdout, service, Config{})
+		logger.Warn("Invalid logging configuration, using defaults", "error", err)
+	}
+	slog.SetDefault(logger)
+	return logger
+}
+
+func ParseLevel(level string) (slog.Level, error) {
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", level)
+	}
+	return l, nil
+}
+
+// Fatal logs err at error level and exits, for startup failures.
+func Fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// contextHandler adds the request ID and the current trace and span IDs bound
+// to the context to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		r.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
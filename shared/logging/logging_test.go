@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"recruitment-system/shared/requestid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_AddsServiceAndRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "job-service", Config{Level: "info"})
+	require.NoError(t, err)
+
+	ctx := requestid.NewContext(context.Background(), "req-123")
+	logger.InfoContext(ctx, "Job created", "job_id", "job-1")
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "INFO", line["level"])
+	assert.Equal(t, "Job created", line["msg"])
+	assert.Equal(t, "job-service", line["service"])
+	assert.Equal(t, "req-123", line["request_id"])
+	assert.Equal(t, "job-1", line["job_id"])
+}
+
+func TestNew_FiltersByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "job-service", Config{Level: "WARN"})
+	require.NoError(t, err)
+
+	logger.Info("ignored")
+	assert.Zero(t, buf.Len())
+
+	logger.Warn("kept")
+	assert.Contains(t, buf.String(), `"msg":"kept"`)
+	assert.NotContains(t, buf.String(), "request_id")
+}
+
+func TestNew_RejectsInvalidConfig(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "job-service", Config{Level: "verbose"})
+	assert.Error(t, err)
+
+	_, err = New(&bytes.Buffer{}, "job-service", Config{Format: "xml"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+// Package metrics holds the Prometheus metrics shared by every service:
+// HTTP requests, SQL queries, database pool stats and calls to other
+// services. Business counters live with the service that owns them.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+// RouteKey overrides the route label of a request, for handlers serving many
+// paths from one gin route (the gateway labels by its own route prefix).
+const RouteKey = "metrics_route"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests served, by route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency, by route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "SQL query latency, by operation and table.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	clientRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "inter_service_requests_total",
+		Help: "Calls to other services, by target service, operation and outcome.",
+	}, []string{"target", "operation", "outcome"})
+
+	clientDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "inter_service_request_duration_seconds",
+		Help:    "Latency of calls to other services, including retries.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"target", "operation"})
+)
+
+// Handler serves the metrics of the default registry on /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Middleware records count and latency of every request, labelled with the
+// gin route template so IDs don't explode cardinality.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.GetString(RouteKey)
+		if route == "" {
+			route = c.FullPath()
+		}
+		if route == "" {
+			route = "unmatched"
+		}
+
+		status := strconv.Itoa(c.Writer.Status())
+		httpRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		httpDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveClientCall records one call to another service. outcome is
+// "success" or the kind of failure (see httpclient.Outcome).
+func ObserveClientCall(target, operation string, duration time.Duration, outcome string) {
+	clientRequests.WithLabelValues(target, operation, outcome).Inc()
+	clientDuration.WithLabelValues(target, operation).Observe(duration.Seconds())
+}
+
+const startTimeKey = "metrics:start_time"
+
+// InstrumentDB times every GORM operation and exports the connection pool
+// stats of db under the given database name.
+func InstrumentDB(db *gorm.DB, name string) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if err := prometheus.Register(collectors.NewDBStatsCollector(sqlDB, name)); err != nil {
+		return err
+	}
+
+	cb := db.Callback()
+	errs := []error{
+		cb.Create().Before("gorm:create").Register("metrics:before_create", startTimer),
+		cb.Create().After("gorm:create").Register("metrics:after_create", observeQuery("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", startTimer),
+		cb.Query().After("gorm:query").Register("metrics:after_query", observeQuery("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", startTimer),
+		cb.Update().After("gorm:update").Register("metrics:after_update", observeQuery("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", startTimer),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", observeQuery("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", startTimer),
+		cb.Row().After("gorm:row").Register("metrics:after_row", observeQuery("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", startTimer),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", observeQuery("raw")),
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startTimer(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+func observeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startTimeKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		dbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareLabelsByRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/jobs/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/proxy/*path", func(c *gin.Context) {
+		c.Set(RouteKey, "/api/v1/jobs")
+		c.Status(http.StatusBadGateway)
+	})
+
+	for _, path := range []string{"/jobs/1", "/jobs/2", "/proxy/x", "/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/jobs/:id", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/api/v1/jobs", "502")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "unmatched", "404")))
+}
+
+func TestHandlerExposesClientCalls(t *testing.T) {
+	ObserveClientCall("job-service", "GET", 20*time.Millisecond, "unavailable")
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.Contains(w.Body.String(),
+		`inter_service_requests_total{operation="GET",outcome="unavailable",target="job-service"} 1`))
+}
@@ -1,56 +1,130 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"recruitment-system/shared/serviceauth"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TokenType string `json:"token_type,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsService reports whether the token was issued to a service rather than a
+// user.
+func (c *Claims) IsService() bool {
+	return c.TokenType == serviceauth.TokenTypeService
+}
+
+// AuthMiddleware authenticates end users. Service tokens are rejected.
 func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-			c.Abort()
+		claims, ok := parseClaims(c, jwtSecret)
+		if !ok {
 			return
 		}
 
-		bearerToken := strings.Split(authHeader, " ")
-		if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+		if claims.IsService() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Service tokens cannot access user endpoints"})
 			c.Abort()
 			return
 		}
 
-		tokenString := bearerToken[1]
-		claims := &Claims{}
+		c.Set("principal_type", serviceauth.TokenTypeUser)
+		c.Set("user_id", claims.UserID)
+		c.Set("user_email", claims.Email)
+		c.Set("user_role", claims.Role)
+		c.Next()
+	}
+}
 
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
+// ServiceAuthMiddleware authenticates other services for internal
+// endpoints. When services are given, only those clients are accepted.
+func ServiceAuthMiddleware(jwtSecret string, services ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := parseClaims(c, jwtSecret)
+		if !ok {
+			return
+		}
 
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		if !claims.IsService() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Service token required"})
 			c.Abort()
 			return
 		}
 
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
+		if len(services) > 0 && !contains(services, claims.Subject) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Service not allowed"})
+			c.Abort()
+			return
+		}
+
+		c.Set("principal_type", serviceauth.TokenTypeService)
+		c.Set("service_name", claims.Subject)
 		c.Next()
 	}
 }
 
+func parseClaims(c *gin.Context, jwtSecret string) (*Claims, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+		c.Abort()
+		return nil, false
+	}
+
+	bearerToken := strings.Split(authHeader, " ")
+	if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+		c.Abort()
+		return nil, false
+	}
+
+	claims, err := ParseToken(jwtSecret, bearerToken[1])
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		c.Abort()
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// ParseToken verifies a token signed by auth-service and returns its claims.
+func ParseToken(jwtSecret, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(jwtSecret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func RequireRole(role string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("user_role")
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"recruitment-system/shared/gatewayauth"
+	"recruitment-system/shared/serviceauth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GatewayIdentity binds the user forwarded by the gateway to the request
+// context (see gatewayauth.FromContext) when the request carries the
+// gateway secret, so handlers don't have to validate the token again with
+// auth-service. The gateway headers are removed either way; requests that
+// didn't come through the gateway fall back to token validation.
+func GatewayIdentity(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := gatewayauth.Verify(c.Request.Header, secret)
+		for _, header := range gatewayauth.Headers {
+			c.Request.Header.Del(header)
+		}
+
+		if ok {
+			c.Set("principal_type", serviceauth.TokenTypeUser)
+			c.Set("user_id", user.ID.String())
+			c.Set("user_email", user.Email)
+			c.Set("user_role", user.Role)
+			c.Request = c.Request.WithContext(gatewayauth.NewContext(c.Request.Context(), user))
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"recruitment-system/shared/gatewayauth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayIdentityTrustsOnlyTheGateway(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(GatewayIdentity("gateway-secret"))
+	router.GET("/me", func(c *gin.Context) {
+		user, ok := gatewayauth.FromContext(c.Request.Context())
+		if !ok {
+			c.String(http.StatusUnauthorized, c.GetHeader(gatewayauth.HeaderUserID))
+			return
+		}
+		c.String(http.StatusOK, user.ID.String()+" "+user.Role)
+	})
+
+	userID := uuid.New()
+	request := func(secret string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set(gatewayauth.HeaderUserID, userID.String())
+		req.Header.Set(gatewayauth.HeaderUserRole, "admin")
+		if secret != "" {
+			req.Header.Set(gatewayauth.HeaderSecret, secret)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("gateway-secret")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, userID.String()+" admin", w.Body.String())
+
+	for _, secret := range []string{"", "guessed"} {
+		w = request(secret)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Empty(t, w.Body.String(), "spoofed headers are removed")
+	}
+}
+
+func TestGatewayIdentityWithoutSecretTrustsNothing(t *testing.T) {
+	header := http.Header{}
+	header.Set(gatewayauth.HeaderUserID, uuid.NewString())
+
+	_, ok := gatewayauth.Verify(header, "")
+	assert.False(t, ok)
+}
@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitKeyFunc identifies the client a request is counted against.
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// RateLimitPolicy allows Limit requests per Window for each client, with
+// bursts of up to Limit requests. A Limit of zero disables the policy.
+type RateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	Key    RateLimitKeyFunc
+}
+
+// RateLimiter applies named policies backed by a shared store.
+type RateLimiter struct {
+	store    RateLimitStore
+	policies map[string]RateLimitPolicy
+}
+
+// NewRateLimiter registers the policies with their default limits. overrides
+// replaces them from configuration, as "name=limit/window" pairs separated by
+// commas ("login=10/1m,upload=off").
+func NewRateLimiter(store RateLimitStore, overrides string, policies ...RateLimitPolicy) (*RateLimiter, error) {
+	l := &RateLimiter{
+		store:    store,
+		policies: make(map[string]RateLimitPolicy, len(policies)),
+	}
+	for _, policy := range policies {
+		if policy.Key == nil {
+			policy.Key = KeyByIP
+		}
+		l.policies[policy.Name] = policy
+	}
+
+	for _, entry := range strings.Split(overrides, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q: expected name=limit/window", entry)
+		}
+		policy, exists := l.policies[strings.TrimSpace(name)]
+		if !exists {
+			return nil, fmt.Errorf("unknown rate limit policy %q", name)
+		}
+		limit, window, err := ParseRateLimit(spec)
+		if err != nil {
+			return nil, err
+		}
+		policy.Limit, policy.Window = limit, window
+		l.policies[policy.Name] = policy
+	}
+
+	for _, policy := range l.policies {
+		if policy.Limit < 0 || (policy.Limit > 0 && policy.Window <= 0) {
+			return nil, fmt.Errorf("rate limit policy %q must have a positive window", policy.Name)
+		}
+	}
+
+	return l, nil
+}
+
+// ParseRateLimit reads "limit/window" (e.g. "100/1m"). "off" disables the
+// limit.
+func ParseRateLimit(spec string) (int, time.Duration, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "off" {
+		return 0, 0, nil
+	}
+
+	count, per, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid rate limit %q: expected limit/window", spec)
+	}
+	limit, err := strconv.Atoi(count)
+	if err != nil || limit <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate limit %q: limit must be a positive integer", spec)
+	}
+	window, err := time.ParseDuration(per)
+	if err != nil || window <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate limit %q: window must be a positive duration", spec)
+	}
+	return limit, window, nil
+}
+
+// Limit returns a middleware enforcing the named policy. Unknown or disabled
+// policies let every request through.
+func (l *RateLimiter) Limit(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !l.Allow(c, name) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// Allow counts the request against the named policy and sets the
+// RateLimit-* headers. When the limit is exceeded it responds with 429 and
+// aborts the request. Store failures are logged and the request is allowed.
+func (l *RateLimiter) Allow(c *gin.Context, name string) bool {
+	policy, ok := l.policies[name]
+	if !ok || policy.Limit == 0 {
+		return true
+	}
+
+	key := "ratelimit:" + policy.Name + ":" + policy.Key(c)
+	result, err := l.store.Take(c.Request.Context(), key, policy.Limit, policy.Window)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "Rate limit store failed, allowing request", "policy", policy.Name, "error", err)
+		return true
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(policy.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, ceilSeconds(policy.Window)))
+
+	if !result.Allowed {
+		c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// KeyByIP counts requests per client IP.
+func KeyByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// TrustedProxies parses a comma-separated list of proxy IPs or CIDRs for
+// gin's SetTrustedProxies. An empty list trusts no proxy, so ClientIP is the
+// connection's address and a client cannot pick its own key with
+// X-Forwarded-For.
+func TrustedProxies(list string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(list, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// KeyByUser counts requests per authenticated user: the user set by
+// AuthMiddleware, or else the subject of a valid bearer token. Anonymous
+// requests fall back to the client IP.
+func KeyByUser(jwtSecret string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		if userID := c.GetString("user_id"); userID != "" {
+			return "user:" + userID
+		}
+
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if found && token != "" {
+			if claims, err := ParseToken(jwtSecret, token); err == nil {
+				if claims.IsService() {
+					return "service:" + claims.Subject
+				}
+				return "user:" + claims.UserID
+			}
+		}
+
+		return KeyByIP(c)
+	}
+}
+
+// KeyByAPIKey counts requests per API key sent in header, falling back to
+// the client IP. Keys are hashed so they never reach the store.
+func KeyByAPIKey(header string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		key := c.GetHeader(header)
+		if key == "" {
+			return KeyByIP(c)
+		}
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:16])
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
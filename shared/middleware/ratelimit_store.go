@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RateLimitResult is the outcome of taking one request from a bucket.
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long until the next request is allowed (only set
+	// when the request was denied).
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// RateLimitStore keeps token buckets: each key holds up to limit tokens,
+// refilled continuously at limit per window.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+}
+
+// NewRateLimitStore returns the store selected by RATE_LIMIT_STORE: "memory"
+// (per process) or "postgres" (shared by all replicas).
+func NewRateLimitStore(kind string, db *gorm.DB) (RateLimitStore, error) {
+	switch kind {
+	case "", "memory":
+		return NewMemoryRateLimitStore(), nil
+	case "postgres":
+		if db == nil {
+			return nil, fmt.Errorf("postgres rate limit store requires a database")
+		}
+		return NewPostgresRateLimitStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", kind)
+	}
+}
+
+// bucketResult derives the response fields from the tokens left after the
+// request.
+func bucketResult(allowed bool, tokens float64, limit int, window time.Duration) RateLimitResult {
+	perToken := window / time.Duration(limit)
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(limit) - tokens) * float64(perToken)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+	return result
+}
+
+const rateLimitSweepInterval = time.Minute
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+	expires time.Time
+}
+
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	capacity := float64(limit)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	} else {
+		refill := float64(now.Sub(b.updated)) / float64(window) * capacity
+		b.tokens = math.Min(capacity, b.tokens+refill)
+		b.updated = now
+	}
+	b.expires = now.Add(window)
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return bucketResult(allowed, b.tokens, limit, window), nil
+}
+
+// sweep drops buckets idle long enough to be full again.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.expires) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// PostgresRateLimitStore keeps the buckets in the rate_limit_buckets table
+// so every replica shares them. Refill and take happen in one upsert, using
+// the database clock.
+type PostgresRateLimitStore struct {
+	db *gorm.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresRateLimitStore(db *gorm.DB) *PostgresRateLimitStore {
+	return &PostgresRateLimitStore{db: db}
+}
+
+// refillSQL is the bucket level after refilling for the time elapsed since
+// the last request, capped at capacity.
+const refillSQL = `LEAST(CAST(@capacity AS DOUBLE PRECISION), b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * CAST(@rate AS DOUBLE PRECISION))`
+
+const takeTokenSQL = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at, expires_at)
+VALUES (@key, CAST(@capacity AS DOUBLE PRECISION) - 1, TRUE, NOW(), NOW() + CAST(@window AS DOUBLE PRECISION) * INTERVAL '1 second')
+ON CONFLICT (key) DO UPDATE SET
+    tokens = ` + refillSQL + ` - CASE WHEN ` + refillSQL + ` >= 1 THEN 1 ELSE 0 END,
+    allowed = ` + refillSQL + ` >= 1,
+    updated_at = NOW(),
+    expires_at = NOW() + CAST(@window AS DOUBLE PRECISION) * INTERVAL '1 second'
+RETURNING tokens, allowed`
+
+func (s *PostgresRateLimitStore) Take(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	s.sweep()
+
+	var row struct {
+		Tokens  float64
+		Allowed bool
+	}
+	err := s.db.WithContext(ctx).Raw(takeTokenSQL, map[string]interface{}{
+		"key":      key,
+		"capacity": float64(limit),
+		"rate":     float64(limit) / window.Seconds(),
+		"window":   window.Seconds(),
+	}).Scan(&row).Error
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	return bucketResult(row.Allowed, row.Tokens, limit, window), nil
+}
+
+// sweep deletes expired buckets at most once per interval, in the
+// background so it never delays a request.
+func (s *PostgresRateLimitStore) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = time.Now()
+
+	go func() {
+		if err := s.db.Exec("DELETE FROM rate_limit_buckets WHERE expires_at < NOW()").Error; err != nil {
+			slog.Error("Failed to delete expired rate limit buckets", "error", err)
+		}
+	}()
+}
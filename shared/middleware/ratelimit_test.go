@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitedRouter(t *testing.T, store RateLimitStore, overrides string, policy RateLimitPolicy) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	limiter, err := NewRateLimiter(store, overrides, policy)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/login", limiter.Limit(policy.Name), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRateLimiter_DeniesAfterLimitWithHeaders(t *testing.T) {
+	router := newRateLimitedRouter(t, NewMemoryRateLimitStore(), "", RateLimitPolicy{
+		Name: "login", Limit: 2, Window: time.Minute,
+	})
+
+	var last *httptest.ResponseRecorder
+	for i := 0; i < 3; i++ {
+		last = httptest.NewRecorder()
+		router.ServeHTTP(last, httptest.NewRequest(http.MethodPost, "/login", nil))
+		if i < 2 {
+			assert.Equal(t, http.StatusOK, last.Code)
+		}
+	}
+
+	assert.Equal(t, http.StatusTooManyRequests, last.Code)
+	assert.Equal(t, "2", last.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", last.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", last.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", last.Header().Get("RateLimit-Policy"))
+	assert.Equal(t, "30", last.Header().Get("Retry-After"))
+}
+
+func TestRateLimiter_OverridesAndDisables(t *testing.T) {
+	router := newRateLimitedRouter(t, NewMemoryRateLimitStore(), "login=off", RateLimitPolicy{
+		Name: "login", Limit: 1, Window: time.Minute,
+	})
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	}
+
+	_, err := NewRateLimiter(NewMemoryRateLimitStore(), "signup=5/1m", RateLimitPolicy{Name: "login", Limit: 1, Window: time.Minute})
+	assert.Error(t, err)
+	_, err = NewRateLimiter(NewMemoryRateLimitStore(), "login=5", RateLimitPolicy{Name: "login", Limit: 1, Window: time.Minute})
+	assert.Error(t, err)
+}
+
+func TestMemoryRateLimitStore_RefillsOverTime(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		result, err := store.Take(ctx, "k", 4, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+	result, _ := store.Take(ctx, "k", 4, time.Minute)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 15*time.Second, result.RetryAfter)
+
+	now = now.Add(15 * time.Second)
+	result, _ = store.Take(ctx, "k", 4, time.Minute)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	now = now.Add(2 * time.Minute)
+	result, _ = store.Take(ctx, "k", 4, time.Minute)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 3, result.Remaining)
+}
+
+func TestKeyByUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	key := KeyByUser("secret")
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": "user-1",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("Authorization", "Bearer "+token)
+	assert.Equal(t, "user:user-1", key(c))
+
+	c.Request.Header.Set("Authorization", "Bearer forged")
+	assert.Equal(t, "ip:"+c.ClientIP(), key(c))
+}
+
+func TestKeyByIP_IgnoresForwardedForFromUntrustedClients(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	key := func(trusted string) string {
+		router := gin.New()
+		require.NoError(t, router.SetTrustedProxies(TrustedProxies(trusted)))
+		var got string
+		router.GET("/", func(c *gin.Context) { got = KeyByIP(c) })
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+		return got
+	}
+
+	assert.Equal(t, "ip:192.0.2.1", key(""))
+	assert.Equal(t, "ip:203.0.113.7", key("10.0.0.0/8, 192.0.2.1"))
+}
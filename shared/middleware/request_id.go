@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"recruitment-system/shared/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxRequestIDLength = 128
+
+// RequestID reuses the caller's X-Request-ID, or generates one, echoes it in
+// the response and binds it to the request context.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = requestid.New()
+		}
+
+		c.Set("request_id", id)
+		c.Header(requestid.Header, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: candidate/v1/candidate.proto
+
+package candidatev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CandidateService_GetCandidate_FullMethodName       = "/candidate.v1.CandidateService/GetCandidate"
+	CandidateService_GetCandidateByUser_FullMethodName = "/candidate.v1.CandidateService/GetCandidateByUser"
+)
+
+// CandidateServiceClient is the client API for CandidateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CandidateServiceClient interface {
+	GetCandidate(ctx context.Context, in *GetCandidateRequest, opts ...grpc.CallOption) (*Candidate, error)
+	GetCandidateByUser(ctx context.Context, in *GetCandidateByUserRequest, opts ...grpc.CallOption) (*Candidate, error)
+}
+
+type candidateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCandidateServiceClient(cc grpc.ClientConnInterface) CandidateServiceClient {
+	return &candidateServiceClient{cc}
+}
+
+func (c *candidateServiceClient) GetCandidate(ctx context.Context, in *GetCandidateRequest, opts ...grpc.CallOption) (*Candidate, error) {
+	out := new(Candidate)
+	err := c.cc.Invoke(ctx, CandidateService_GetCandidate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) GetCandidateByUser(ctx context.Context, in *GetCandidateByUserRequest, opts ...grpc.CallOption) (*Candidate, error) {
+	out := new(Candidate)
+	err := c.cc.Invoke(ctx, CandidateService_GetCandidateByUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CandidateServiceServer is the server API for CandidateService service.
+// All implementations must embed UnimplementedCandidateServiceServer
+// for forward compatibility
+type CandidateServiceServer interface {
+	GetCandidate(context.Context, *GetCandidateRequest) (*Candidate, error)
+	GetCandidateByUser(context.Context, *GetCandidateByUserRequest) (*Candidate, error)
+	mustEmbedUnimplementedCandidateServiceServer()
+}
+
+// UnimplementedCandidateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCandidateServiceServer struct {
+}
+
+func (UnimplementedCandidateServiceServer) GetCandidate(context.Context, *GetCandidateRequest) (*Candidate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) GetCandidateByUser(context.Context, *GetCandidateByUserRequest) (*Candidate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandidateByUser not implemented")
+}
+func (UnimplementedCandidateServiceServer) mustEmbedUnimplementedCandidateServiceServer() {}
+
+// UnsafeCandidateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CandidateServiceServer will
+// result in compilation errors.
+type UnsafeCandidateServiceServer interface {
+	mustEmbedUnimplementedCandidateServiceServer()
+}
+
+func RegisterCandidateServiceServer(s grpc.ServiceRegistrar, srv CandidateServiceServer) {
+	s.RegisterService(&CandidateService_ServiceDesc, srv)
+}
+
+func _CandidateService_GetCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).GetCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_GetCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).GetCandidate(ctx, req.(*GetCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_GetCandidateByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandidateByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).GetCandidateByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_GetCandidateByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).GetCandidateByUser(ctx, req.(*GetCandidateByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CandidateService_ServiceDesc is the grpc.ServiceDesc for CandidateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CandidateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "candidate.v1.CandidateService",
+	HandlerType: (*CandidateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCandidate",
+			Handler:    _CandidateService_GetCandidate_Handler,
+		},
+		{
+			MethodName: "GetCandidateByUser",
+			Handler:    _CandidateService_GetCandidateByUser_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "candidate/v1/candidate.proto",
+}
@@ -0,0 +1,27 @@
+// Package requestid carries the ID of the request being served through
+// context.Context, so logs and outgoing calls to other services can share it.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const Header = "X-Request-ID"
+
+type contextKey struct{}
+
+func New() string {
+	return uuid.NewString()
+}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID bound to ctx, or "" outside a request.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
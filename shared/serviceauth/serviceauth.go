@@ -0,0 +1,82 @@
+// Package serviceauth lets a service authenticate to other services with a
+// short-lived token issued by auth-service for its client credentials.
+package serviceauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"recruitment-system/shared/httpclient"
+)
+
+// Token types carried in the token_type claim. Tokens issued before service
+// tokens existed have no claim and are user tokens.
+const (
+	TokenTypeUser    = "user"
+	TokenTypeService = "service"
+)
+
+// refreshBefore renews a cached token this long before it expires, so a
+// token never expires while a request is in flight.
+const refreshBefore = time.Minute
+
+type Credentials struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+type tokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TokenSource obtains service tokens from auth-service and caches them until
+// shortly before they expire. It is safe for concurrent use.
+type TokenSource struct {
+	client      *httpclient.Client
+	credentials Credentials
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	now       func() time.Time
+}
+
+func NewTokenSource(authServiceURL string, credentials Credentials) *TokenSource {
+	return &TokenSource{
+		client:      httpclient.New("auth-service", authServiceURL, httpclient.DefaultConfig()),
+		credentials: credentials,
+		now:         time.Now,
+	}
+}
+
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Add(refreshBefore).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	if s.credentials.ClientID == "" || s.credentials.ClientSecret == "" {
+		return "", errors.New("service client credentials are not configured")
+	}
+
+	var response tokenResponse
+	err := s.client.Do(ctx, httpclient.Request{
+		Method:     http.MethodPost,
+		Path:       "/api/v1/auth/service-token",
+		Body:       s.credentials,
+		Idempotent: true,
+	}, &response)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = response.Token
+	s.expiresAt = response.ExpiresAt
+	return s.token, nil
+}
@@ -0,0 +1,54 @@
+package serviceauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenSourceCachesUntilShortlyBeforeExpiry(t *testing.T) {
+	now := time.Now()
+	var issued int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var credentials Credentials
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&credentials))
+		assert.Equal(t, Credentials{ClientID: "candidate-service", ClientSecret: "s3cret"}, credentials)
+
+		issued++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    tokenResponse{Token: fmt.Sprintf("token-%d", issued), ExpiresAt: now.Add(15 * time.Minute)},
+		})
+	}))
+	defer server.Close()
+
+	source := NewTokenSource(server.URL, Credentials{ClientID: "candidate-service", ClientSecret: "s3cret"})
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	now = now.Add(13 * time.Minute)
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	now = now.Add(time.Minute + time.Second)
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
+
+func TestTokenSourceRequiresCredentials(t *testing.T) {
+	_, err := NewTokenSource("http://localhost", Credentials{}).Token(context.Background())
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+// Package tracing sets up OpenTelemetry tracing: spans for gin handlers,
+// GORM queries and outgoing HTTP and gRPC calls, with W3C trace context
+// propagated between services.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const instrumentationName = "recruitment-system/shared/tracing"
+
+// Config selects where spans are exported: "otlp" (OTLP over HTTP, to
+// OTEL_EXPORTER_OTLP_ENDPOINT), "stdout" for local runs, or "none". Sampling
+// follows the standard OTEL_TRACES_SAMPLER variables.
+type Config struct {
+	Exporter string
+}
+
+func GetConfigFromEnv() Config {
+	return Config{Exporter: os.Getenv("OTEL_TRACES_EXPORTER")}
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes pending spans on shutdown.
+// With no exporter, trace context is still propagated so traces started
+// upstream are not broken.
+func Setup(ctx context.Context, service string, config Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch strings.ToLower(config.Exporter) {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "stdout", "console":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", config.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", config.Exporter, err)
+	}
+
+	provider, err := newProvider(service, sdktrace.WithBatcher(exporter))
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// SetupInMemory installs a tracer provider that records spans synchronously
+// in the returned exporter, for tests.
+func SetupInMemory(service string) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := newProvider(service, sdktrace.WithSyncer(exporter))
+	if err != nil {
+		panic(err)
+	}
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return exporter
+}
+
+func newProvider(service string, processor sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(service),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+	return sdktrace.NewTracerProvider(processor, sdktrace.WithResource(res)), nil
+}
+
+// untracedPaths are probes and metric scrapes.
+var untracedPaths = map[string]bool{
+	"/health":  true,
+	"/livez":   true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// Middleware starts a server span per request, continuing the trace of the
+// caller. Health probes and metric scrapes are not traced.
+func Middleware(service string) gin.HandlerFunc {
+	return otelgin.Middleware(service, otelgin.WithFilter(func(r *http.Request) bool {
+		return !untracedPaths[r.URL.Path]
+	}))
+}
+
+// Transport wraps base so every request to the service called name gets a
+// client span and carries the trace context.
+func Transport(name string, base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base, otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return name + " " + r.Method
+	}))
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "no span named %q", name)
+	return tracetest.SpanStub{}
+}
+
+func TestTraceContextPropagatesBetweenServices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	exporter := SetupInMemory("test")
+
+	downstream := gin.New()
+	downstream.Use(Middleware("job-service"))
+	downstream.GET("/jobs/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	downstreamServer := httptest.NewServer(downstream)
+	defer downstreamServer.Close()
+
+	client := &http.Client{Transport: Transport("job-service", http.DefaultTransport)}
+	upstream := gin.New()
+	upstream.Use(Middleware("candidate-service"))
+	upstream.GET("/applications", func(c *gin.Context) {
+		req, _ := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, downstreamServer.URL+"/jobs/1", nil)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		c.Status(resp.StatusCode)
+	})
+	upstream.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/applications", "/health"} {
+		w := httptest.NewRecorder()
+		upstream.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	server := findSpan(t, spans, "/applications")
+	call := findSpan(t, spans, "job-service GET")
+	handler := findSpan(t, spans, "/jobs/:id")
+
+	traceID := server.SpanContext.TraceID()
+	assert.Equal(t, traceID, call.SpanContext.TraceID())
+	assert.Equal(t, traceID, handler.SpanContext.TraceID())
+	assert.Equal(t, server.SpanContext.SpanID(), call.Parent.SpanID())
+	assert.Equal(t, call.SpanContext.SpanID(), handler.Parent.SpanID())
+	assert.True(t, handler.Parent.IsRemote())
+}
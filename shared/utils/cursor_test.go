@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorCodecRoundTrip(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	cursor := Cursor{CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC), ID: uuid.New()}
+
+	decoded, err := codec.Decode(codec.Encode(cursor))
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestCursorCodecRejectsTamperedTokens(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	token := codec.Encode(Cursor{CreatedAt: time.Now(), ID: uuid.New()})
+
+	_, err := NewCursorCodec("other").Decode(token)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	forged := NewCursorCodec("secret").Encode(Cursor{CreatedAt: time.Now(), ID: uuid.New()})
+	_, err = codec.Decode(forged[:len(forged)/2] + token[len(token)/2:])
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	for _, bad := range []string{"", "abc", "abc.def", "!!!.???"} {
+		_, err := codec.Decode(bad)
+		assert.ErrorIs(t, err, ErrInvalidCursor, bad)
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	key := func(id uuid.UUID) Cursor { return Cursor{CreatedAt: time.Now(), ID: id} }
+
+	items, pagination := KeysetPage(codec, ids, PageRequest{Limit: 2, Keyset: true}, key)
+	assert.Equal(t, ids[:2], items)
+	assert.True(t, pagination.HasMore)
+
+	next, err := codec.Decode(pagination.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], next.ID)
+
+	items, pagination = KeysetPage(codec, ids[:2], PageRequest{Limit: 2, Keyset: true}, key)
+	assert.Len(t, items, 2)
+	assert.False(t, pagination.HasMore)
+	assert.Empty(t, pagination.NextCursor)
+}
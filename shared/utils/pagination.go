@@ -8,8 +8,11 @@ import (
 )
 
 type PaginationParams struct {
-	Page  int
-	Limit int
+	Page   int
+	Limit  int
+	Keyset bool
+	Cursor string
+	Sort   string
 }
 
 func GetPaginationParams(c *gin.Context) PaginationParams {
@@ -28,9 +31,14 @@ func GetPaginationParams(c *gin.Context) PaginationParams {
 		}
 	}
 
+	cursor := c.Query("cursor")
+
 	return PaginationParams{
-		Page:  page,
-		Limit: limit,
+		Page:   page,
+		Limit:  limit,
+		Keyset: cursor != "" || c.Query("pagination") == "cursor",
+		Cursor: cursor,
+		Sort:   c.Query("sort"),
 	}
 }
 
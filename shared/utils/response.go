@@ -21,6 +21,14 @@ type PaginatedResponse struct {
 	Pagination Pagination  `json:"pagination,omitempty"`
 }
 
+type CursorPaginatedResponse struct {
+	Success    bool             `json:"success"`
+	Message    string           `json:"message,omitempty"`
+	Data       interface{}      `json:"data,omitempty"`
+	Error      string           `json:"error,omitempty"`
+	Pagination CursorPagination `json:"pagination"`
+}
+
 type Pagination struct {
 	Page       int   `json:"page"`
 	Limit      int   `json:"limit"`
@@ -58,6 +66,15 @@ func PaginatedSuccessResponse(c *gin.Context, statusCode int, message string, da
 	})
 }
 
+func CursorPaginatedSuccessResponse(c *gin.Context, statusCode int, message string, data interface{}, pagination CursorPagination) {
+	c.JSON(statusCode, CursorPaginatedResponse{
+		Success:    true,
+		Message:    message,
+		Data:       data,
+		Pagination: pagination,
+	})
+}
+
 func ValidationErrorResponse(c *gin.Context, err error) {
 	ErrorResponse(c, http.StatusBadRequest, "Validation failed", err)
 }
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const maxSortFields = 3
+
+var ErrSortWithCursor = errors.New("sort is not supported with cursor pagination")
+
+// ErrRankedWithCursor rejects keyset paging of results ordered by relevance
+// or distance: the cursor only encodes (created_at, id).
+var ErrRankedWithCursor = errors.New("cursor pagination is not supported with q or near, use page")
+
+// SortableFields maps the field names clients may send in the sort
+// parameter to the column each one orders by. Only columns listed here ever
+// reach an ORDER BY clause.
+type SortableFields map[string]string
+
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// Clause renders the field for ORDER BY. NULLs always sort last so optional
+// columns such as salaries do not crowd the first page.
+func (f SortField) Clause() string {
+	if f.Desc {
+		return f.Column + " DESC NULLS LAST"
+	}
+	return f.Column + " ASC NULLS LAST"
+}
+
+// ParseSort parses a comma separated list of fields, each optionally
+// prefixed with "-" for descending order, e.g. "-salary_max,title".
+func ParseSort(raw string, allowed SortableFields) ([]SortField, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) > maxSortFields {
+		return nil, fmt.Errorf("at most %d sort fields are allowed", maxSortFields)
+	}
+
+	fields := make([]SortField, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		name := strings.TrimSpace(part)
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "-"), "+")
+
+		column, ok := allowed[name]
+		if !ok {
+			return nil, fmt.Errorf("cannot sort by %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate sort field %q", name)
+		}
+		seen[name] = true
+
+		fields = append(fields, SortField{Column: column, Desc: desc})
+	}
+	return fields, nil
+}
+
+// WithDefaultSort returns page ordered by fields unless the client already
+// chose a sort.
+func (p PageRequest) WithDefaultSort(fields ...SortField) PageRequest {
+	if len(p.Sort) == 0 {
+		p.Sort = fields
+	}
+	return p
+}
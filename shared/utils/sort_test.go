@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSortFields = SortableFields{
+	"title":      "jobs.title",
+	"salary_max": "jobs.salary_max",
+	"created_at": "jobs.created_at",
+}
+
+func TestParseSort(t *testing.T) {
+	fields, err := ParseSort("-salary_max, title", testSortFields)
+	require.NoError(t, err)
+	assert.Equal(t, []SortField{{Column: "jobs.salary_max", Desc: true}, {Column: "jobs.title"}}, fields)
+	assert.Equal(t, "jobs.salary_max DESC NULLS LAST", fields[0].Clause())
+
+	fields, err = ParseSort("", testSortFields)
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+}
+
+func TestParseSortRejectsUnknownFields(t *testing.T) {
+	for _, raw := range []string{
+		"password",
+		"title;DROP TABLE jobs",
+		"title,title",
+		"title,-salary_max,created_at,title",
+		"title,",
+	} {
+		_, err := ParseSort(raw, testSortFields)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestPageRequestRejectsSortWithCursor(t *testing.T) {
+	codec := NewCursorCodec("secret")
+
+	_, err := codec.PageRequest(PaginationParams{Limit: 10, Keyset: true, Sort: "title"}, testSortFields)
+	assert.ErrorIs(t, err, ErrSortWithCursor)
+
+	page, err := codec.PageRequest(PaginationParams{Page: 2, Limit: 10, Sort: "-title"}, testSortFields)
+	require.NoError(t, err)
+	assert.Equal(t, 10, page.Offset)
+	assert.Equal(t, []SortField{{Column: "jobs.title", Desc: true}}, page.Sort)
+}
@@ -30,7 +30,7 @@ func IsValidRole(role string) bool {
 }
 
 func IsValidJobStatus(status string) bool {
-	validStatuses := []string{"open", "closed"}
+	validStatuses := []string{"draft", "scheduled", "open", "paused", "closed", "archived"}
 	for _, validStatus := range validStatuses {
 		if status == validStatus {
 			return true